@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/formicidae-tracker/olympus/pkg/api"
 	"github.com/jessevdk/go-flags"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -16,6 +19,11 @@ type Options struct {
 	Warning bool   `short:"w" long:"warning" description:"send a warning instead of an emergency"`
 	Host    string `short:"h" long:"host" description:"olympus host to connect to" default:"localhost"`
 	Port    int    `short:"p" long:"port" description:"gRPC port to use" default:"3001"`
+	Cert    string `long:"cert" description:"client certificate, enables TLS"`
+	Key     string `long:"key" description:"client certificate key"`
+	CA      string `long:"ca" description:"authority of the server certificate"`
+	Zone    string `short:"z" long:"zone" description:"zone identifier to send the alarm for, i.e. 'host.zone'" required:"yes"`
+	Token   string `short:"t" long:"token" description:"token of the alarm source" env:"OLYMPUS_ALARM_TOKEN" required:"yes"`
 
 	Args struct {
 		Identification string
@@ -30,6 +38,14 @@ func main() {
 	}
 }
 
+func (o Options) BuildZone() (*api.ClimateZone, error) {
+	host, name, ok := strings.Cut(o.Zone, ".")
+	if ok == false || len(host) == 0 || len(name) == 0 {
+		return nil, fmt.Errorf("invalid zone identifier '%s'", o.Zone)
+	}
+	return &api.ClimateZone{Host: host, Name: name}, nil
+}
+
 func (o Options) BuildAlarmUpdate() *api.AlarmUpdate {
 	update := &api.AlarmUpdate{
 		Identification: o.Args.Identification,
@@ -48,9 +64,17 @@ func (o Options) BuildAlarmUpdate() *api.AlarmUpdate {
 }
 
 func (o Options) Dial() (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+	if len(o.Cert) > 0 {
+		config, err := api.NewClientTLSConfig(o.Cert, o.Key, o.CA)
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(config)
+	}
 	return grpc.Dial(
 		fmt.Sprintf("%s:%d", o.Host, o.Port),
-		grpc.WithInsecure(),
+		grpc.WithTransportCredentials(creds),
 	)
 }
 
@@ -60,6 +84,11 @@ func execute() error {
 		return nil
 	}
 
+	zone, err := opts.BuildZone()
+	if err != nil {
+		return err
+	}
+
 	conn, err := opts.Dial()
 	if err != nil {
 		return fmt.Errorf("could not connect to host: %w", err)
@@ -70,13 +99,14 @@ func execute() error {
 
 	client := api.NewOlympusClient(conn)
 
-	_, err = client.SendAlarm(context.Background(), update)
+	ctx := api.WithAlarmToken(context.Background(), opts.Token)
+	_, err = client.SendAlarm(ctx, &api.ZoneAlarmUpdate{Zone: zone, Update: update})
 
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("sent to %s:%d %s\n", opts.Host, opts.Port, update)
+	fmt.Printf("sent to %s:%d %s: %s\n", opts.Host, opts.Port, opts.Zone, update)
 
 	return nil
 }
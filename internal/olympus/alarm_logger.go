@@ -1,11 +1,14 @@
 package olympus
 
 import (
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/formicidae-tracker/olympus/pkg/api"
+	"github.com/formicidae-tracker/olympus/pkg/tm"
+	"github.com/sirupsen/logrus"
 )
 
 type AlarmLogger interface {
@@ -14,6 +17,9 @@ type AlarmLogger interface {
 	// PushAlarms adds a list of AlarmEvents to this logger.
 	PushAlarms([]*api.AlarmUpdate, string)
 	ClearDomain(string, time.Time)
+	// Acknowledge marks the current event of an active alarm as
+	// acknowledged by someone.
+	Acknowledge(identification, by string, now time.Time) (*api.AlarmAcknowledgement, error)
 }
 
 type alarmTimePoint struct {
@@ -26,15 +32,60 @@ type alarmLog struct {
 	level          api.AlarmLevel
 	description    string
 	timepoints     []alarmTimePoint
+	// acknowledgements, sorted by time
+	acknowledgements []api.AlarmAcknowledgement
 }
 
 func (l *alarmLog) getReport() api.AlarmReport {
-	return api.AlarmReport{
+	res := api.AlarmReport{
 		Identification: l.identification,
 		Level:          l.level,
 		Description:    l.description,
 		Events:         l.buildEvents(),
 	}
+	if l.on() == true && len(res.Events) > 0 {
+		res.Acknowledgement = res.Events[len(res.Events)-1].Acknowledgement
+	}
+	return res
+}
+
+// acknowledgementFor returns the first acknowledgement in
+// [start,end], if any. A nil end means no upper bound.
+func (l *alarmLog) acknowledgementFor(start time.Time, end *time.Time) *api.AlarmAcknowledgement {
+	for _, a := range l.acknowledgements {
+		if a.Time.Before(start) {
+			continue
+		}
+		if end != nil && a.Time.After(*end) {
+			return nil
+		}
+		res := a
+		return &res
+	}
+	return nil
+}
+
+func (l *alarmLog) acknowledge(by string, now time.Time) (*api.AlarmAcknowledgement, error) {
+	events := l.buildEvents()
+	if l.on() == false || len(events) == 0 {
+		return nil, AlarmNotActiveError(l.identification)
+	}
+	current := events[len(events)-1]
+	if current.Acknowledgement != nil {
+		return nil, AlarmAlreadyAcknowledgedError{
+			Identification:  l.identification,
+			Acknowledgement: *current.Acknowledgement,
+		}
+	}
+	if now.Before(current.Start) {
+		now = current.Start
+	}
+	res := api.AlarmAcknowledgement{By: by, Time: now}
+	l.acknowledgements = BackInsertionSort(l.acknowledgements, res,
+		func(a, b api.AlarmAcknowledgement) bool {
+			return a.Time.Before(b.Time)
+		})
+	return &res, nil
 }
 
 func (l *alarmLog) buildEvents() []api.AlarmEvent {
@@ -45,7 +96,9 @@ func (l *alarmLog) buildEvents() []api.AlarmEvent {
 			if tp.on == false {
 				continue
 			}
-			start = &tp.time
+			// tp is reused by the loop, we must copy its time.
+			start = new(time.Time)
+			*start = tp.time
 		} else {
 			if tp.on == true {
 				continue
@@ -64,6 +117,10 @@ func (l *alarmLog) buildEvents() []api.AlarmEvent {
 		events = append(events, api.AlarmEvent{Start: *start})
 	}
 
+	for i := range events {
+		events[i].Acknowledgement = l.acknowledgementFor(events[i].Start, events[i].End)
+	}
+
 	return events
 }
 
@@ -130,14 +187,82 @@ type alarmLogger struct {
 	logs map[string]*alarmLog
 
 	warnings, emergencies, failures int
+
+	zone  string
+	store AlarmStore
+	log   *logrus.Entry
 }
 
+// NewAlarmLogger creates an AlarmLogger that only keeps alarms in
+// memory.
 func NewAlarmLogger() AlarmLogger {
 	return &alarmLogger{
 		logs: make(map[string]*alarmLog),
 	}
 }
 
+// NewPersistentAlarmLogger creates an AlarmLogger which restores and
+// saves the alarm history of zone from and to store.
+func NewPersistentAlarmLogger(zone string, store AlarmStore) AlarmLogger {
+	res := &alarmLogger{
+		logs:  make(map[string]*alarmLog),
+		zone:  zone,
+		store: store,
+		log:   tm.NewLogger("alarms").WithField("zone", zone),
+	}
+	res.restore()
+	return res
+}
+
+func (l *alarmLogger) restore() {
+	records, ok := l.store.Load(l.zone)
+	if ok == false {
+		return
+	}
+	for _, r := range records {
+		log := &alarmLog{
+			identification: r.Identification,
+			level:          r.Level,
+			description:    r.Description,
+			timepoints:     make([]alarmTimePoint, len(r.TimePoints)),
+
+			acknowledgements: r.Acknowledgements,
+		}
+		for i, tp := range r.TimePoints {
+			log.timepoints[i] = alarmTimePoint{time: tp.Time, on: tp.On}
+		}
+		l.logs[log.identification] = log
+	}
+	l.computeActives()
+}
+
+func (l *alarmLogger) save() {
+	if l.store == nil {
+		return
+	}
+	records := make([]AlarmLogRecord, 0, len(l.logs))
+	for _, log := range l.logs {
+		r := AlarmLogRecord{
+			Identification: log.identification,
+			Level:          log.level,
+			Description:    log.description,
+			TimePoints:     make([]AlarmTimePoint, len(log.timepoints)),
+
+			Acknowledgements: log.acknowledgements,
+		}
+		for i, tp := range log.timepoints {
+			r.TimePoints[i] = AlarmTimePoint{Time: tp.time, On: tp.on}
+		}
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Identification < records[j].Identification
+	})
+	if err := l.store.Save(l.zone, records); err != nil {
+		l.log.WithError(err).Error("could not save alarms")
+	}
+}
+
 func (l *alarmLogger) ActiveAlarmsCount() (failure, emergencies, warnings int) {
 	l.mx.RLock()
 	defer l.mx.RUnlock()
@@ -165,6 +290,7 @@ func (l *alarmLogger) PushAlarms(updates []*api.AlarmUpdate, domain string) {
 	}
 	l.computeActives()
 	l.decimateLogs(updates)
+	l.save()
 }
 
 func (l *alarmLogger) ClearDomain(domain string, before time.Time) {
@@ -180,6 +306,24 @@ func (l *alarmLogger) ClearDomain(domain string, before time.Time) {
 			log.timepoints = append(log.timepoints, alarmTimePoint{time: before, on: false})
 		}
 	}
+	l.computeActives()
+	l.save()
+}
+
+func (l *alarmLogger) Acknowledge(identification, by string, now time.Time) (*api.AlarmAcknowledgement, error) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	log, ok := l.logs[identification]
+	if ok == false {
+		return nil, AlarmNotFoundError(identification)
+	}
+	res, err := log.acknowledge(by, now)
+	if err != nil {
+		return nil, err
+	}
+	l.save()
+	return res, nil
 }
 
 func (l *alarmLogger) pushUpdateToLog(update *api.AlarmUpdate) {
@@ -191,6 +335,10 @@ func (l *alarmLogger) pushUpdateToLog(update *api.AlarmUpdate) {
 		}
 		l.logs[log.identification] = log
 	}
+	if update.Status == api.AlarmStatus_ON {
+		// server-generated alarms may be raised to a higher level.
+		log.level = update.Level
+	}
 	log.pushUpdate(update)
 }
 
@@ -206,6 +354,7 @@ func (l *alarmLogger) decimateLogs(updates []*api.AlarmUpdate) {
 }
 
 func (l *alarmLogger) computeActives() {
+	l.failures = 0
 	l.emergencies = 0
 	l.warnings = 0
 	for _, log := range l.logs {
@@ -2,6 +2,7 @@ package olympus
 
 import (
 	"math/rand"
+	"sort"
 	"time"
 
 	"github.com/formicidae-tracker/olympus/pkg/api"
@@ -110,3 +111,173 @@ func (s *AlarmLoggerSuite) TestLogsAlarms(c *C) {
 	c.Check(activeEmergencies, Equals, expectedEmergency)
 	c.Check(activeWarnings, Equals, expectedWarning)
 }
+
+type PersistentAlarmLoggerSuite struct {
+	datapath string
+	store    AlarmStore
+}
+
+var _ = Suite(&PersistentAlarmLoggerSuite{})
+
+func (s *PersistentAlarmLoggerSuite) SetUpSuite(c *C) {
+	s.datapath = _datapath
+}
+
+func (s *PersistentAlarmLoggerSuite) TearDownSuite(c *C) {
+	_datapath = s.datapath
+}
+
+func (s *PersistentAlarmLoggerSuite) SetUpTest(c *C) {
+	_datapath = c.MkDir()
+	s.store = NewAlarmStore("alarms", 24*time.Hour)
+}
+
+func (s *PersistentAlarmLoggerSuite) TestRestoresAlarms(c *C) {
+	start := time.Now().Round(0).Add(-time.Hour)
+	l := NewPersistentAlarmLogger("foo.box", s.store)
+	l.PushAlarms([]*api.AlarmUpdate{
+		{
+			Identification: "old",
+			Level:          api.AlarmLevel_WARNING,
+			Status:         api.AlarmStatus_ON,
+			Time:           timestamppb.New(start.Add(-48 * time.Hour)),
+		},
+		{
+			Identification: "foo",
+			Level:          api.AlarmLevel_EMERGENCY,
+			Status:         api.AlarmStatus_ON,
+			Time:           timestamppb.New(start),
+			Description:    "foo is on",
+		},
+		{
+			Identification: "foo",
+			Level:          api.AlarmLevel_EMERGENCY,
+			Status:         api.AlarmStatus_OFF,
+			Time:           timestamppb.New(start.Add(time.Minute)),
+		},
+		{
+			Identification: "bar",
+			Level:          api.AlarmLevel_WARNING,
+			Status:         api.AlarmStatus_ON,
+			Time:           timestamppb.New(start.Add(time.Minute)),
+		},
+	}, "climate")
+
+	// restores from disk, not from the store memory
+	restored := NewPersistentAlarmLogger("foo.box", NewAlarmStore("alarms", 24*time.Hour))
+	reports := restored.GetReports()
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Identification < reports[j].Identification
+	})
+	// climate.old is out of retention
+	c.Assert(reports, HasLen, 2)
+	c.Check(reports[0].Identification, Equals, "climate.bar")
+	c.Check(reports[0].Events, HasLen, 1)
+	c.Check(reports[0].Events[0].End, IsNil)
+	c.Check(reports[1].Identification, Equals, "climate.foo")
+	c.Check(reports[1].Description, Equals, "foo is on")
+	c.Assert(reports[1].Events, HasLen, 1)
+	c.Check(reports[1].Events[0].Start.Equal(start), Equals, true)
+	c.Check(reports[1].Events[0].End, NotNil)
+
+	failures, emergencies, warnings := restored.ActiveAlarmsCount()
+	c.Check(failures, Equals, 0)
+	c.Check(emergencies, Equals, 0)
+	c.Check(warnings, Equals, 1)
+
+	c.Check(NewPersistentAlarmLogger("bar.box", s.store).GetReports(), HasLen, 0)
+}
+
+func (s *PersistentAlarmLoggerSuite) TestAcknowledgesAlarms(c *C) {
+	start := time.Now().Round(0).Add(-time.Hour)
+	l := NewPersistentAlarmLogger("foo.box", s.store)
+	l.PushAlarms([]*api.AlarmUpdate{
+		{
+			Identification: "foo",
+			Level:          api.AlarmLevel_EMERGENCY,
+			Status:         api.AlarmStatus_ON,
+			Time:           timestamppb.New(start),
+		},
+		{
+			Identification: "bar",
+			Level:          api.AlarmLevel_WARNING,
+			Status:         api.AlarmStatus_ON,
+			Time:           timestamppb.New(start),
+		},
+		{
+			Identification: "bar",
+			Level:          api.AlarmLevel_WARNING,
+			Status:         api.AlarmStatus_OFF,
+			Time:           timestamppb.New(start.Add(time.Minute)),
+		},
+	}, "climate")
+
+	_, err := l.Acknowledge("climate.baz", "someone", start.Add(2*time.Minute))
+	c.Check(err, Equals, AlarmNotFoundError("climate.baz"))
+	_, err = l.Acknowledge("climate.bar", "someone", start.Add(2*time.Minute))
+	c.Check(err, Equals, AlarmNotActiveError("climate.bar"))
+
+	ack, err := l.Acknowledge("climate.foo", "someone", start.Add(2*time.Minute))
+	c.Assert(err, IsNil)
+	c.Check(ack.By, Equals, "someone")
+	_, err = l.Acknowledge("climate.foo", "someone else", start.Add(3*time.Minute))
+	c.Check(err, FitsTypeOf, AlarmAlreadyAcknowledgedError{})
+
+	restored := NewPersistentAlarmLogger("foo.box", NewAlarmStore("alarms", 24*time.Hour))
+	reports := restored.GetReports()
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Identification < reports[j].Identification
+	})
+	c.Assert(reports, HasLen, 2)
+	c.Check(reports[0].Acknowledgement, IsNil)
+	c.Assert(reports[1].Acknowledgement, NotNil)
+	c.Check(reports[1].Acknowledgement.By, Equals, "someone")
+	c.Check(reports[1].Acknowledgement.Time.Equal(start.Add(2*time.Minute)), Equals, true)
+
+	// a new event is not acknowledged anymore.
+	l.PushAlarms([]*api.AlarmUpdate{
+		{
+			Identification: "foo",
+			Level:          api.AlarmLevel_EMERGENCY,
+			Status:         api.AlarmStatus_OFF,
+			Time:           timestamppb.New(start.Add(4 * time.Minute)),
+		},
+		{
+			Identification: "foo",
+			Level:          api.AlarmLevel_EMERGENCY,
+			Status:         api.AlarmStatus_ON,
+			Time:           timestamppb.New(start.Add(5 * time.Minute)),
+		},
+	}, "climate")
+	reports = l.GetReports()
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Identification < reports[j].Identification
+	})
+	c.Check(reports[1].Acknowledgement, IsNil)
+	c.Assert(reports[1].Events, HasLen, 2)
+	c.Check(reports[1].Events[0].Acknowledgement, NotNil)
+	c.Check(reports[1].Events[1].Acknowledgement, IsNil)
+}
+
+func (s *AlarmLoggerSuite) TestAlarmLevelCanBeRaised(c *C) {
+	start := time.Now()
+	s.l.PushAlarms([]*api.AlarmUpdate{
+		{
+			Identification: "disk.almost-full",
+			Level:          api.AlarmLevel_WARNING,
+			Status:         api.AlarmStatus_ON,
+			Time:           timestamppb.New(start),
+		},
+		{
+			Identification: "disk.almost-full",
+			Level:          api.AlarmLevel_EMERGENCY,
+			Status:         api.AlarmStatus_ON,
+			Time:           timestamppb.New(start.Add(time.Minute)),
+		},
+	}, "tracking")
+
+	failures, emergencies, warnings := s.l.ActiveAlarmsCount()
+	c.Check(failures, Equals, 0)
+	c.Check(emergencies, Equals, 1)
+	c.Check(warnings, Equals, 0)
+}
@@ -0,0 +1,172 @@
+package olympus
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// An AlarmSource is an external script, i.e. a freezer or power
+// monitor, allowed to send alarms for some zones through the gRPC
+// SendAlarm call.
+type AlarmSource struct {
+	Name      string   `json:"name"`
+	TokenHash string   `json:"token_hash"`
+	Zones     []string `json:"zones"`
+}
+
+// Allows returns true if the source can send alarms for a zone.
+func (s AlarmSource) Allows(zoneIdentifier string) bool {
+	for _, z := range s.Zones {
+		if z == zoneIdentifier {
+			return true
+		}
+	}
+	return false
+}
+
+// An UnknownAlarmSourceError is returned when an alarm source does
+// not exist.
+type UnknownAlarmSourceError string
+
+func (e UnknownAlarmSourceError) Error() string {
+	return "olympus: unknown alarm source '" + string(e) + "'"
+}
+
+// InvalidAlarmTokenError is returned when no alarm source has a token.
+var InvalidAlarmTokenError = errors.New("olympus: invalid alarm source token")
+
+// A ZoneNotAllowedError is returned when an alarm source sends an
+// alarm for a zone it is not allowed to.
+type ZoneNotAllowedError struct {
+	Source string
+	Zone   string
+}
+
+func (e ZoneNotAllowedError) Error() string {
+	return fmt.Sprintf("olympus: alarm source '%s' cannot send alarms for zone '%s'", e.Source, e.Zone)
+}
+
+// splitZone splits a zone identifier in its host and zone name.
+func splitZone(zoneIdentifier string) (host, zone string, err error) {
+	host, zone, ok := strings.Cut(zoneIdentifier, ".")
+	if ok == false || len(host) == 0 || len(zone) == 0 {
+		return "", "", fmt.Errorf("olympus: invalid zone identifier '%s'", zoneIdentifier)
+	}
+	return host, zone, nil
+}
+
+// An AlarmSourceStore persists the alarm sources and authenticates
+// their tokens. Only a hash of the tokens is stored. The sources are
+// re-read from disk on each call, so changes made by another process,
+// i.e. the alarm-source command, take effect immediately.
+type AlarmSourceStore interface {
+	// Add creates a source, or replaces it, and returns its new
+	// token.
+	Add(name string, zones []string) (string, error)
+	// Remove removes a source. It may return an
+	// UnknownAlarmSourceError.
+	Remove(name string) error
+	// List returns all sources, sorted by name.
+	List() []AlarmSource
+	// Authenticate returns the source of a token, or
+	// InvalidAlarmTokenError.
+	Authenticate(token string) (AlarmSource, error)
+}
+
+type alarmSourceStore struct {
+	mx      sync.Mutex
+	name    string
+	sources *PersistentMap[AlarmSource]
+}
+
+// NewAlarmSourceStore creates an AlarmSourceStore in the data
+// directory.
+func NewAlarmSourceStore(name string) AlarmSourceStore {
+	return &alarmSourceStore{name: name}
+}
+
+// load re-reads the sources from disk. It must be called with mx
+// held.
+func (s *alarmSourceStore) load() {
+	s.sources = NewPersistentMap[AlarmSource](s.name)
+}
+
+func hashAlarmToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *alarmSourceStore) Add(name string, zones []string) (string, error) {
+	if len(name) == 0 {
+		return "", errors.New("olympus: empty alarm source name")
+	}
+	if len(zones) == 0 {
+		return "", fmt.Errorf("olympus: alarm source '%s' has no zone", name)
+	}
+	for _, z := range zones {
+		if _, _, err := splitZone(z); err != nil {
+			return "", err
+		}
+	}
+	data := make([]byte, 32)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(data)
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.load()
+	s.sources.Map[name] = AlarmSource{
+		Name:      name,
+		TokenHash: hashAlarmToken(token),
+		Zones:     append([]string(nil), zones...),
+	}
+	return token, s.sources.SaveKey(name)
+}
+
+func (s *alarmSourceStore) Remove(name string) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.load()
+	if _, ok := s.sources.Map[name]; ok == false {
+		return UnknownAlarmSourceError(name)
+	}
+	return s.sources.DeleteKey(name)
+}
+
+func (s *alarmSourceStore) List() []AlarmSource {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.load()
+	res := make([]AlarmSource, 0, len(s.sources.Map))
+	for _, source := range s.sources.Map {
+		res = append(res, source)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+func (s *alarmSourceStore) Authenticate(token string) (AlarmSource, error) {
+	if len(token) == 0 {
+		return AlarmSource{}, InvalidAlarmTokenError
+	}
+	hash := []byte(hashAlarmToken(token))
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.load()
+	for _, source := range s.sources.Map {
+		if subtle.ConstantTimeCompare(hash, []byte(source.TokenHash)) == 1 {
+			return source, nil
+		}
+	}
+	return AlarmSource{}, InvalidAlarmTokenError
+}
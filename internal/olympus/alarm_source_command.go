@@ -0,0 +1,77 @@
+package olympus
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+type AlarmSourceCommand struct{}
+
+type AddAlarmSourceCommand struct {
+	ConfigOptions
+
+	Zones []string `short:"z" long:"zone" description:"zone identifier the source can send alarms for, i.e. 'host.zone'. Can be set multiple times" required:"yes"`
+
+	Args struct {
+		Name string `positional-arg-name:"name" description:"name of the source"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+type RemoveAlarmSourceCommand struct {
+	ConfigOptions
+
+	Args struct {
+		Name string `positional-arg-name:"name" description:"name of the source"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+type ListAlarmSourceCommand struct {
+	ConfigOptions
+}
+
+func (c *AddAlarmSourceCommand) Execute([]string) error {
+	if err := c.apply(); err != nil {
+		return err
+	}
+	token, err := NewAlarmSourceStore("alarm-sources").Add(c.Args.Name, c.Zones)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "token of '%s', it cannot be displayed again:\n", c.Args.Name)
+	fmt.Println(token)
+	return nil
+}
+
+func (c *RemoveAlarmSourceCommand) Execute([]string) error {
+	if err := c.apply(); err != nil {
+		return err
+	}
+	return NewAlarmSourceStore("alarm-sources").Remove(c.Args.Name)
+}
+
+func (c *ListAlarmSourceCommand) Execute([]string) error {
+	if err := c.apply(); err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tZONES")
+	for _, s := range NewAlarmSourceStore("alarm-sources").List() {
+		fmt.Fprintf(tw, "%s\t%s\n", s.Name, strings.Join(s.Zones, ","))
+	}
+	return tw.Flush()
+}
+
+func init() {
+	cmd, err := parser.AddCommand("alarm-source",
+		"manages external alarm sources.",
+		"manages the external scripts allowed to send alarms for named zones, stored in the data_home of the configuration. Each source authenticates with its own token. Changes take effect immediately.",
+		&AlarmSourceCommand{})
+	if err != nil {
+		panic(err.Error())
+	}
+	cmd.AddCommand("add", "adds or renews a source", "adds an alarm source, or replaces its zones, and prints its new token", &AddAlarmSourceCommand{})
+	cmd.AddCommand("remove", "removes a source", "removes an alarm source and revokes its token", &RemoveAlarmSourceCommand{})
+	cmd.AddCommand("list", "lists sources", "lists all alarm sources and their zones", &ListAlarmSourceCommand{})
+}
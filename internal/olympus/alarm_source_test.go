@@ -0,0 +1,56 @@
+package olympus
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type AlarmSourceSuite struct {
+	datapath string
+}
+
+var _ = Suite(&AlarmSourceSuite{})
+
+func (s *AlarmSourceSuite) SetUpSuite(c *C) {
+	s.datapath = _datapath
+}
+
+func (s *AlarmSourceSuite) TearDownSuite(c *C) {
+	_datapath = s.datapath
+}
+
+func (s *AlarmSourceSuite) SetUpTest(c *C) {
+	_datapath = c.MkDir()
+}
+
+func (s *AlarmSourceSuite) TestAuthenticatesTokens(c *C) {
+	store := NewAlarmSourceStore("alarm-sources")
+	_, err := store.Add("freezer", nil)
+	c.Check(err, ErrorMatches, "olympus: alarm source 'freezer' has no zone")
+	_, err = store.Add("freezer", []string{"lab"})
+	c.Check(err, ErrorMatches, "olympus: invalid zone identifier 'lab'")
+
+	old, err := store.Add("freezer", []string{"lab.freezer"})
+	c.Assert(err, IsNil)
+	token, err := store.Add("freezer", []string{"lab.freezer", "lab.fridge"})
+	c.Assert(err, IsNil)
+	c.Check(token, Not(Equals), old)
+
+	restored := NewAlarmSourceStore("alarm-sources")
+	c.Assert(restored.List(), HasLen, 1)
+	c.Check(restored.List()[0].TokenHash, Not(Equals), token)
+	source, err := restored.Authenticate(token)
+	c.Assert(err, IsNil)
+	c.Check(source.Name, Equals, "freezer")
+	c.Check(source.Allows("lab.fridge"), Equals, true)
+	c.Check(source.Allows("lab.incubator"), Equals, false)
+
+	_, err = restored.Authenticate(old)
+	c.Check(err, Equals, InvalidAlarmTokenError)
+	_, err = restored.Authenticate("")
+	c.Check(err, Equals, InvalidAlarmTokenError)
+
+	c.Check(restored.Remove("power"), ErrorMatches, "olympus: unknown alarm source 'power'")
+	c.Check(restored.Remove("freezer"), IsNil)
+	_, err = restored.Authenticate(token)
+	c.Check(err, Equals, InvalidAlarmTokenError)
+}
@@ -0,0 +1,106 @@
+package olympus
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+)
+
+// AlarmTimePoint is a persisted alarm status change.
+type AlarmTimePoint struct {
+	Time time.Time
+	On   bool
+}
+
+// AlarmLogRecord is the persisted history of a single alarm.
+type AlarmLogRecord struct {
+	Identification string
+	Level          api.AlarmLevel
+	Description    string
+	TimePoints     []AlarmTimePoint
+
+	Acknowledgements []api.AlarmAcknowledgement `json:",omitempty"`
+}
+
+// An AlarmStore persists the alarm history of each zone.
+type AlarmStore interface {
+	// Save replaces the persisted alarm history of a zone.
+	Save(zone string, records []AlarmLogRecord) error
+	// Load returns the persisted alarm history of a zone.
+	Load(zone string) ([]AlarmLogRecord, bool)
+	// Zones lists all zones with a persisted alarm history.
+	Zones() []string
+}
+
+type alarmStore struct {
+	mx        sync.Mutex
+	logs      *PersistentMap[[]AlarmLogRecord]
+	retention time.Duration
+}
+
+// NewAlarmStore creates an AlarmStore in the data directory. Time
+// points older than retention are discarded, unless retention is
+// non-positive.
+func NewAlarmStore(name string, retention time.Duration) AlarmStore {
+	return &alarmStore{
+		logs:      NewPersistentMap[[]AlarmLogRecord](name),
+		retention: retention,
+	}
+}
+
+func (s *alarmStore) prune(records []AlarmLogRecord, now time.Time) []AlarmLogRecord {
+	if s.retention <= 0 {
+		return records
+	}
+	limit := now.Add(-s.retention)
+	res := make([]AlarmLogRecord, 0, len(records))
+	for _, r := range records {
+		idx := sort.Search(len(r.TimePoints), func(i int) bool {
+			return r.TimePoints[i].Time.After(limit)
+		})
+		if idx == len(r.TimePoints) {
+			continue
+		}
+		r.TimePoints = r.TimePoints[idx:]
+		first := r.TimePoints[0].Time
+		ackIdx := sort.Search(len(r.Acknowledgements), func(i int) bool {
+			return r.Acknowledgements[i].Time.Before(first) == false
+		})
+		r.Acknowledgements = r.Acknowledgements[ackIdx:]
+		res = append(res, r)
+	}
+	return res
+}
+
+func (s *alarmStore) Save(zone string, records []AlarmLogRecord) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.logs.Map[zone] = s.prune(records, time.Now())
+	return s.logs.SaveKey(zone)
+}
+
+func (s *alarmStore) Load(zone string) ([]AlarmLogRecord, bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	records, ok := s.logs.Map[zone]
+	if ok == false {
+		return nil, false
+	}
+	return s.prune(records, time.Now()), true
+}
+
+func (s *alarmStore) Zones() []string {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	res := make([]string, 0, len(s.logs.Map))
+	for zone := range s.logs.Map {
+		res = append(res, zone)
+	}
+	sort.Strings(res)
+	return res
+}
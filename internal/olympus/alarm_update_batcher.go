@@ -34,7 +34,7 @@ func (b *notificationBuilder) filter(outgoing chan<- []ZonedAlarmUpdate, incomin
 			if ok == false {
 				return
 			}
-			if timer == nil || u.Update.Level == api.AlarmLevel_FAILURE {
+			if timer == nil || (u.Update.Level == api.AlarmLevel_FAILURE && u.Resolution == nil) {
 				outgoing <- []ZonedAlarmUpdate{u}
 				timer = time.After(b.batchPeriod)
 			} else {
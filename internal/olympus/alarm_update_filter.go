@@ -11,6 +11,26 @@ import (
 type ZonedAlarmUpdate struct {
 	Zone   string
 	Update *api.AlarmUpdate
+	// Acknowledgement is set when an operator acknowledged the alarm
+	// described by Update.
+	Acknowledgement *api.AlarmAcknowledgement
+	// Escalation is set when the update is re-sent because the alarm
+	// stayed unacknowledged.
+	Escalation *EscalationStep
+	// Resolution is set on the OFF update of an alarm that was
+	// previously notified.
+	Resolution *AlarmResolution
+}
+
+// AlarmResolution describes when a notified alarm started and ended.
+type AlarmResolution struct {
+	FiredAt    time.Time
+	ResolvedAt time.Time
+}
+
+// Duration returns how long the alarm stayed active.
+func (r AlarmResolution) Duration() time.Duration {
+	return r.ResolvedAt.Sub(r.FiredAt)
 }
 
 func AppendSuffix(str string, suffix string) string {
@@ -36,23 +56,35 @@ func (u ZonedAlarmUpdate) ID() string {
 
 type UpdateFilter func(outgoing chan<- ZonedAlarmUpdate, incoming <-chan ZonedAlarmUpdate)
 
+type firedAlarm struct {
+	level api.AlarmLevel
+	since time.Time
+}
+
 type updateFilter struct {
-	minimumOn time.Duration
+	minimumOn   time.Duration
+	maintenance MaintenanceSchedule
 
-	staged map[string]ZonedAlarmUpdate
-	fired  map[string]api.AlarmLevel
+	staged       map[string]ZonedAlarmUpdate
+	fired        map[string]firedAlarm
+	acknowledged map[string]bool
 }
 
-func newUpdateFilter(minimumOn time.Duration) *updateFilter {
+func newUpdateFilter(minimumOn time.Duration, maintenance MaintenanceSchedule) *updateFilter {
 	return &updateFilter{
-		minimumOn: minimumOn,
-		staged:    make(map[string]ZonedAlarmUpdate),
-		fired:     make(map[string]api.AlarmLevel),
+		minimumOn:    minimumOn,
+		maintenance:  maintenance,
+		staged:       make(map[string]ZonedAlarmUpdate),
+		fired:        make(map[string]firedAlarm),
+		acknowledged: make(map[string]bool),
 	}
 }
 
-func FilterAlarmUpdates(minimumOn time.Duration) UpdateFilter {
-	filter := newUpdateFilter(minimumOn)
+// FilterAlarmUpdates returns an UpdateFilter that only forwards
+// alarms staying ON for more than minimumOn. Alarms silenced by
+// maintenance, if not nil, are dropped.
+func FilterAlarmUpdates(minimumOn time.Duration, maintenance MaintenanceSchedule) UpdateFilter {
+	filter := newUpdateFilter(minimumOn, maintenance)
 	return filter.filter
 }
 
@@ -72,6 +104,16 @@ func (f *updateFilter) filter(
 			}
 			if u.Update == nil {
 				f.cleanUpFired(u.Zone)
+				outgoing <- u
+			} else if u.Acknowledgement != nil {
+				if f.acknowledge(u) == true {
+					outgoing <- u
+				}
+			} else if u.Update.Status == api.AlarmStatus_OFF {
+				if resolution := f.resolve(u); resolution != nil {
+					u.Resolution = resolution
+					outgoing <- u
+				}
 			} else if f.stage(u) == true && timer == nil {
 				wait := u.Update.Time.AsTime().Add(f.minimumOn).Sub(time.Now())
 				timer = time.After(wait)
@@ -100,18 +142,68 @@ func (f *updateFilter) cleanUpFired(zone string) {
 	for _, id := range toDelete {
 		delete(f.fired, id)
 	}
+
+	for id := range f.acknowledged {
+		if strings.HasPrefix(id, zone) == true {
+			delete(f.acknowledged, id)
+		}
+	}
+}
+
+// acknowledge silences an alarm until it turns OFF. It returns true
+// if the alarm was already fired.
+func (f *updateFilter) acknowledge(u ZonedAlarmUpdate) bool {
+	id := u.ID()
+	f.acknowledged[id] = true
+	delete(f.staged, id)
+	_, fired := f.fired[id]
+	return fired
+}
+
+// resolve handles an OFF update. It returns the resolution of the
+// alarm if it was fired, as the following stages may want to know it
+// is resolved, or nil otherwise.
+func (f *updateFilter) resolve(u ZonedAlarmUpdate) *AlarmResolution {
+	id := u.ID()
+	fired, ok := f.fired[id]
+	delete(f.fired, id)
+	delete(f.staged, id)
+	delete(f.acknowledged, id)
+	if ok == false {
+		return nil
+	}
+	resolvedAt := time.Now()
+	if u.Update.Time != nil {
+		resolvedAt = u.Update.Time.AsTime()
+	}
+	return &AlarmResolution{FiredAt: fired.since, ResolvedAt: resolvedAt}
+}
+
+func (f *updateFilter) silenced(u ZonedAlarmUpdate, t time.Time) bool {
+	if f.maintenance == nil {
+		return false
+	}
+	return f.maintenance.Silenced(u.Zone, u.Update.Identification, t)
 }
 
 func (f *updateFilter) stage(u ZonedAlarmUpdate) bool {
 	id := u.ID()
 	if u.Update.Status == api.AlarmStatus_OFF {
-		delete(f.fired, id)
+		f.resolve(u)
+		return false
+	}
+
+	if f.acknowledged[id] == true {
+		return false
+	}
+
+	if f.silenced(u, u.Update.Time.AsTime()) == true {
 		delete(f.staged, id)
 		return false
 	}
 
-	if firedLevel, ok := f.fired[id]; ok == true {
-		if firedLevel >= u.Update.Level {
+	if fired, ok := f.fired[id]; ok == true {
+		if fired.level >= u.Update.Level {
 			return false
 		}
 	}
@@ -135,9 +227,18 @@ func (f *updateFilter) unstage(outgoing chan<- ZonedAlarmUpdate, now time.Time)
 	for idt, u := range f.staged {
 		uTime := u.Update.Time.AsTime()
 		if now.Sub(uTime) > f.minimumOn {
-			outgoing <- u
-			f.fired[idt] = u.Update.Level
 			toDelete = append(toDelete, idt)
+			// a maintenance may have started while staged.
+			if f.silenced(u, now) == true {
+				continue
+			}
+			outgoing <- u
+			fired, ok := f.fired[idt]
+			if ok == false {
+				fired.since = uTime
+			}
+			fired.level = u.Update.Level
+			f.fired[idt] = fired
 			continue
 		}
 
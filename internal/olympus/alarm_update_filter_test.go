@@ -32,7 +32,7 @@ func (s *AlarmUpdateFilterSuite) TestPropagateClosing(c *C) {
 
 	go func(incoming <-chan ZonedAlarmUpdate) {
 		defer close(done)
-		FilterAlarmUpdates(1*time.Millisecond)(s.filtered, incoming)
+		FilterAlarmUpdates(1*time.Millisecond, nil)(s.filtered, incoming)
 	}(s.unfiltered)
 
 	close(s.unfiltered)
@@ -177,7 +177,7 @@ func (s *AlarmUpdateFilterSuite) TestFilterSpuriousAlarm(c *C) {
 	})
 
 	go func(incoming <-chan ZonedAlarmUpdate) {
-		FilterAlarmUpdates(5*time.Millisecond)(s.filtered, incoming)
+		FilterAlarmUpdates(5*time.Millisecond, nil)(s.filtered, incoming)
 	}(s.unfiltered)
 
 	go func() {
@@ -211,6 +211,10 @@ func (s *AlarmUpdateFilterSuite) TestFilterSpuriousAlarm(c *C) {
 
 	received := make([]ZonedAlarmUpdate, 0, len(expected))
 	for update := range s.filtered {
+		if update.Update.Status == api.AlarmStatus_OFF {
+			// resolutions are tested in TestForwardsResolutions
+			continue
+		}
 		received = append(received, update)
 	}
 
@@ -234,6 +238,112 @@ func (s *AlarmUpdateFilterSuite) TestFilterSpuriousAlarm(c *C) {
 
 }
 
+func (s *AlarmUpdateFilterSuite) TestAcknowledgedAlarmsAreSilenced(c *C) {
+	f := newUpdateFilter(5*time.Millisecond, nil)
+	now := time.Now()
+	build := func(level api.AlarmLevel, status api.AlarmStatus) ZonedAlarmUpdate {
+		return ZonedAlarmUpdate{Zone: "foo.box", Update: &api.AlarmUpdate{
+			Identification: "climate.temperature",
+			Level:          level,
+			Status:         status,
+			Time:           timestamppb.New(now),
+		}}
+	}
+
+	c.Check(f.stage(build(api.AlarmLevel_WARNING, api.AlarmStatus_ON)), Equals, true)
+	ack := build(api.AlarmLevel_WARNING, api.AlarmStatus_ON)
+	ack.Acknowledgement = &api.AlarmAcknowledgement{By: "someone", Time: now}
+	f.acknowledge(ack)
+	c.Check(f.staged, HasLen, 0)
+
+	// even an escalation is silenced
+	c.Check(f.stage(build(api.AlarmLevel_EMERGENCY, api.AlarmStatus_ON)), Equals, false)
+	c.Check(f.staged, HasLen, 0)
+
+	// the acknowledgement is reset once the alarm turns off.
+	c.Check(f.stage(build(api.AlarmLevel_WARNING, api.AlarmStatus_OFF)), Equals, false)
+	c.Check(f.stage(build(api.AlarmLevel_WARNING, api.AlarmStatus_ON)), Equals, true)
+
+	f.acknowledge(ack)
+	f.cleanUpFired("foo.box")
+	c.Check(f.acknowledged, HasLen, 0)
+}
+
+func (s *AlarmUpdateFilterSuite) TestForwardsResolutions(c *C) {
+	f := newUpdateFilter(5*time.Millisecond, nil)
+	now := time.Now()
+	build := func(identification string, status api.AlarmStatus) ZonedAlarmUpdate {
+		return ZonedAlarmUpdate{Zone: "foo.box", Update: &api.AlarmUpdate{
+			Identification: identification,
+			Level:          api.AlarmLevel_EMERGENCY,
+			Status:         status,
+			Time:           timestamppb.New(now),
+		}}
+	}
+
+	c.Check(f.stage(build("fired", api.AlarmStatus_ON)), Equals, true)
+	outgoing := make(chan ZonedAlarmUpdate, 1)
+	f.unstage(outgoing, now.Add(10*time.Millisecond))
+	c.Check(outgoing, HasLen, 1)
+	c.Check(f.stage(build("staged", api.AlarmStatus_ON)), Equals, true)
+
+	// only resolutions and acknowledgements of fired alarms are
+	// forwarded.
+	off := build("fired", api.AlarmStatus_OFF)
+	off.Update.Time = timestamppb.New(now.Add(12 * time.Minute))
+	resolution := f.resolve(off)
+	c.Assert(resolution, NotNil)
+	c.Check(resolution.FiredAt.Equal(now), Equals, true)
+	c.Check(resolution.Duration(), Equals, 12*time.Minute)
+	c.Check(f.resolve(off), IsNil)
+	c.Check(f.acknowledge(build("staged", api.AlarmStatus_ON)), Equals, false)
+	c.Check(f.acknowledge(build("other", api.AlarmStatus_ON)), Equals, false)
+}
+
+func (s *AlarmUpdateFilterSuite) TestMaintenanceSilencesAlarms(c *C) {
+	_datapath = c.MkDir()
+	maintenance := NewMaintenanceSchedule("maintenance")
+	now := time.Now()
+	_, err := maintenance.Add(api.MaintenanceWindow{
+		Zone:        "foo.box",
+		AlarmPrefix: "climate.temperature",
+		Start:       now.Add(-time.Minute),
+		End:         now.Add(time.Hour),
+	})
+	c.Assert(err, IsNil)
+
+	f := newUpdateFilter(5*time.Millisecond, maintenance)
+	build := func(zone, identification string) ZonedAlarmUpdate {
+		return ZonedAlarmUpdate{Zone: zone, Update: &api.AlarmUpdate{
+			Identification: identification,
+			Level:          api.AlarmLevel_EMERGENCY,
+			Status:         api.AlarmStatus_ON,
+			Time:           timestamppb.New(now),
+		}}
+	}
+	c.Check(f.stage(build("foo.box", "climate.temperature.out-of-bound")), Equals, false)
+	c.Check(f.stage(build("foo.box", "climate.humidity.out-of-bound")), Equals, true)
+	c.Check(f.stage(build("bar.box", "climate.temperature.out-of-bound")), Equals, true)
+	c.Check(f.staged, HasLen, 2)
+
+	// a window starting while an alarm is staged silences it.
+	_, err = maintenance.Add(api.MaintenanceWindow{
+		Zone:  "bar.box",
+		Start: now,
+		End:   now.Add(time.Hour),
+	})
+	c.Assert(err, IsNil)
+	outgoing := make(chan ZonedAlarmUpdate, 10)
+	f.unstage(outgoing, now.Add(time.Second))
+	close(outgoing)
+	fired := []string{}
+	for u := range outgoing {
+		fired = append(fired, u.ID())
+	}
+	c.Check(fired, DeepEquals, []string{"foo.box/climate.humidity.out-of-bound"})
+	c.Check(f.staged, HasLen, 0)
+}
+
 func Min[T constraints.Ordered](a, b T) T {
 	if a < b {
 		return a
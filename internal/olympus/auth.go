@@ -0,0 +1,480 @@
+package olympus
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"github.com/gorilla/mux"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// A Role grants access rights to the web API. Each role includes the
+// rights of the lower ones.
+type Role int
+
+const (
+	// RoleNone is the role of unauthenticated requests.
+	RoleNone Role = iota
+	// RoleViewer can read zones, climate and alarms.
+	RoleViewer
+	// RoleOperator can also acknowledge alarms, manage maintenance
+	// windows and notification settings.
+	RoleOperator
+	// RoleAdmin can also manage webhooks.
+	RoleAdmin
+)
+
+var roleNames = []string{"none", "viewer", "operator", "admin"}
+
+func (r Role) String() string {
+	if r < 0 || int(r) >= len(roleNames) {
+		return "Role(" + strconv.Itoa(int(r)) + ")"
+	}
+	return roleNames[r]
+}
+
+// ParseRole parses a role name.
+func ParseRole(s string) (Role, error) {
+	for i, name := range roleNames[RoleViewer:] {
+		if name == s {
+			return RoleViewer + Role(i), nil
+		}
+	}
+	return RoleNone, fmt.Errorf("olympus: invalid role '%s'", s)
+}
+
+func (r Role) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+func (r *Role) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	var err error
+	*r, err = ParseRole(s)
+	return err
+}
+
+// A UserAccount is a local account of the web API.
+type UserAccount struct {
+	Name         string `json:"name"`
+	PasswordHash string `json:"password_hash"`
+	Role         Role   `json:"role"`
+}
+
+// An UnknownUserError is returned when an account does not exist.
+type UnknownUserError string
+
+func (e UnknownUserError) Error() string {
+	return "olympus: unknown user '" + string(e) + "'"
+}
+
+// InvalidCredentialsError is returned on authentication failure.
+var InvalidCredentialsError = errors.New("olympus: invalid credentials")
+
+// A UserStore persists the local accounts of the web API.
+type UserStore interface {
+	// Add creates or updates an account.
+	Add(name, password string, role Role) error
+	// Remove removes an account. It may return an UnknownUserError.
+	Remove(name string) error
+	// List returns all accounts, sorted by name.
+	List() []UserAccount
+	// Count returns the number of accounts.
+	Count() int
+	// Authenticate returns the role of an account if password
+	// matches. It returns InvalidCredentialsError otherwise.
+	Authenticate(name, password string) (Role, error)
+	// Role returns the role of an account, or RoleNone if it does
+	// not exist.
+	Role(name string) Role
+}
+
+type userStore struct {
+	mx           sync.Mutex
+	name         string
+	accounts     *PersistentMap[UserAccount]
+	loaded       time.Time
+	reloadPeriod time.Duration
+}
+
+// NewUserStore creates a UserStore in the data directory. Accounts
+// are re-read from disk at most every UserReloadPeriod, so accounts
+// changed by another process, i.e. the user command, apply without
+// restarting.
+func NewUserStore(name string) UserStore {
+	res := &userStore{
+		name:         name,
+		reloadPeriod: UserReloadPeriod,
+	}
+	res.load(true)
+	return res
+}
+
+// load re-reads the accounts from disk if forced or if they are older
+// than reloadPeriod. It must be called with mx held.
+func (s *userStore) load(force bool) {
+	now := time.Now()
+	if force == false && now.Sub(s.loaded) < s.reloadPeriod {
+		return
+	}
+	s.accounts = NewPersistentMap[UserAccount](s.name)
+	s.loaded = now
+}
+
+func (s *userStore) Add(name, password string, role Role) error {
+	if len(name) == 0 || strings.ContainsAny(name, ":") {
+		return fmt.Errorf("olympus: invalid user name '%s'", name)
+	}
+	if len(password) == 0 {
+		return errors.New("olympus: empty password")
+	}
+	if role <= RoleNone || role > RoleAdmin {
+		return fmt.Errorf("olympus: invalid role %s", role)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.load(true)
+	s.accounts.Map[name] = UserAccount{
+		Name:         name,
+		PasswordHash: string(hash),
+		Role:         role,
+	}
+	return s.accounts.SaveKey(name)
+}
+
+func (s *userStore) Remove(name string) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.load(true)
+	if _, ok := s.accounts.Map[name]; ok == false {
+		return UnknownUserError(name)
+	}
+	return s.accounts.DeleteKey(name)
+}
+
+func (s *userStore) List() []UserAccount {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.load(false)
+	res := make([]UserAccount, 0, len(s.accounts.Map))
+	for _, a := range s.accounts.Map {
+		res = append(res, a)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+func (s *userStore) Count() int {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.load(false)
+	return len(s.accounts.Map)
+}
+
+func (s *userStore) Authenticate(name, password string) (Role, error) {
+	s.mx.Lock()
+	s.load(false)
+	account, ok := s.accounts.Map[name]
+	s.mx.Unlock()
+	if ok == false {
+		return RoleNone, InvalidCredentialsError
+	}
+	if bcrypt.CompareHashAndPassword([]byte(account.PasswordHash), []byte(password)) != nil {
+		return RoleNone, InvalidCredentialsError
+	}
+	return account.Role, nil
+}
+
+func (s *userStore) Role(name string) Role {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.load(false)
+	return s.accounts.Map[name].Role
+}
+
+// sessionCookieName is the cookie holding the session of a user.
+const sessionCookieName = "OLYMPUS-SESSION"
+
+// A sessionCodec signs and verifies session cookies using the
+// OLYMPUS_SECRET. A session only holds the user name and its
+// expiration, the role is looked up on each request.
+type sessionCodec struct {
+	secret []byte
+}
+
+func (c sessionCodec) mac(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte("olympus-session:"))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func (c sessionCodec) encode(user string, expires time.Time) string {
+	payload := []byte(strconv.FormatInt(expires.Unix(), 10) + ":" + user)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(c.mac(payload))
+}
+
+func (c sessionCodec) decode(value string, now time.Time) (string, time.Time, error) {
+	invalid := errors.New("olympus: invalid session")
+	parts := strings.Split(value, ".")
+	if len(parts) != 2 {
+		return "", time.Time{}, invalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", time.Time{}, invalid
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || hmac.Equal(mac, c.mac(payload)) == false {
+		return "", time.Time{}, invalid
+	}
+	expiresUnix, user, ok := strings.Cut(string(payload), ":")
+	if ok == false {
+		return "", time.Time{}, invalid
+	}
+	seconds, err := strconv.ParseInt(expiresUnix, 10, 64)
+	if err != nil {
+		return "", time.Time{}, invalid
+	}
+	expires := time.Unix(seconds, 0)
+	if now.Before(expires) == false {
+		return "", time.Time{}, errors.New("olympus: expired session")
+	}
+	return user, expires, nil
+}
+
+type authContextKey string
+
+var authUserKey authContextKey = "user"
+
+type authenticatedUser struct {
+	name    string
+	role    Role
+	expires *time.Time
+}
+
+// AuthenticatedUser returns the user name and role of an
+// authenticated request.
+func AuthenticatedUser(ctx context.Context) (string, Role) {
+	u, ok := ctx.Value(authUserKey).(authenticatedUser)
+	if ok == false {
+		return "", RoleNone
+	}
+	return u.name, u.role
+}
+
+// acknowledgedBy returns who acknowledges an alarm. Authenticated
+// users always acknowledge in their own name, other requests must
+// name who acknowledges.
+func acknowledgedBy(ctx context.Context, by string) (string, error) {
+	name, _ := AuthenticatedUser(ctx)
+	if len(name) == 0 {
+		return by, nil
+	}
+	if len(by) > 0 && by != name {
+		return "", fmt.Errorf("olympus: '%s' cannot acknowledge alarms as '%s'", name, by)
+	}
+	return name, nil
+}
+
+// An Authenticator authenticates the requests to the web API, using
+// a session cookie, HTTP basic authentication or the
+// OLYMPUS_ADMIN_TOKEN, and checks they have the required role. It is
+// only enabled if the UserStore has accounts.
+type Authenticator struct {
+	users      UserStore
+	sessions   *sessionCodec
+	adminToken string
+	clock      Clock
+}
+
+// NewAuthenticator creates an Authenticator. Sessions are disabled
+// if secret is empty.
+func NewAuthenticator(users UserStore, secret []byte, adminToken string) *Authenticator {
+	res := &Authenticator{
+		users:      users,
+		adminToken: adminToken,
+		clock:      SystemClock,
+	}
+	if len(secret) > 0 {
+		res.sessions = &sessionCodec{secret: secret}
+	}
+	return res
+}
+
+// Enabled returns true if requests are authenticated.
+func (a *Authenticator) Enabled() bool {
+	return a.users.Count() > 0
+}
+
+// publicRoutes are accessible without authentication.
+var publicRoutes = map[string]bool{
+	"/api/auth/login":  true,
+	"/api/auth/logout": true,
+	"/api/auth/user":   true,
+	"/api/version":     true,
+}
+
+// adminRoutePrefixes are the route prefixes requiring RoleAdmin.
+var adminRoutePrefixes = []string{"/api/webhooks"}
+
+// requiredRole returns the role needed to access a route. Mutating
+// requests need RoleOperator.
+func requiredRole(r *http.Request) Role {
+	path := r.URL.Path
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			path = template
+		}
+	}
+	if publicRoutes[path] == true {
+		return RoleNone
+	}
+	for _, prefix := range adminRoutePrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return RoleAdmin
+		}
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return RoleViewer
+	default:
+		return RoleOperator
+	}
+}
+
+func (a *Authenticator) authenticate(r *http.Request) authenticatedUser {
+	header := r.Header.Get("Authorization")
+	if token := strings.TrimPrefix(header, "Bearer "); token != header {
+		if len(a.adminToken) > 0 && subtle.ConstantTimeCompare([]byte(token), []byte(a.adminToken)) == 1 {
+			return authenticatedUser{role: RoleAdmin}
+		}
+		return authenticatedUser{}
+	}
+	if name, password, ok := r.BasicAuth(); ok == true {
+		role, err := a.users.Authenticate(name, password)
+		if err != nil {
+			return authenticatedUser{}
+		}
+		return authenticatedUser{name: name, role: role}
+	}
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil || a.sessions == nil {
+		return authenticatedUser{}
+	}
+	name, expires, err := a.sessions.decode(cookie.Value, a.clock.Now())
+	if err != nil {
+		return authenticatedUser{}
+	}
+	// accounts may have been removed since the session was opened.
+	return authenticatedUser{name: name, role: a.users.Role(name), expires: &expires}
+}
+
+// Middleware authenticates requests and rejects those without the
+// role required by their route.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.Enabled() == false {
+			next.ServeHTTP(w, r)
+			return
+		}
+		user := a.authenticate(r)
+		required := requiredRole(r)
+		if user.role < required {
+			if user.role == RoleNone {
+				w.Header().Set("WWW-Authenticate", `Basic realm="olympus"`)
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+			} else {
+				http.Error(w, fmt.Sprintf("%s rights required", required), http.StatusForbidden)
+			}
+			return
+		}
+		ctx := context.WithValue(r.Context(), authUserKey, user)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (a *Authenticator) setSessionCookie(w http.ResponseWriter, value string, expires time.Time) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    value,
+		Path:     "/",
+		Expires:  expires,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (a *Authenticator) userSession(r *http.Request) api.UserSession {
+	if a.Enabled() == false {
+		return api.UserSession{Role: RoleAdmin.String()}
+	}
+	user := a.authenticate(r)
+	return api.UserSession{
+		User:    user.name,
+		Role:    user.role.String(),
+		Expires: user.expires,
+	}
+}
+
+func (a *Authenticator) setRoutes(router *mux.Router) {
+	router.HandleFunc("/api/auth/login", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Cache-Control", "no-store")
+		if a.sessions == nil {
+			http.Error(w, "sessions are disabled", http.StatusServiceUnavailable)
+			return
+		}
+		req, err := Golangify[api.LoginRequest](r)
+		if err != nil {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		role, err := a.users.Authenticate(req.User, req.Password)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		expires := a.clock.Now().Add(SessionDuration).Truncate(time.Second)
+		a.setSessionCookie(w, a.sessions.encode(req.User, expires), expires)
+		JSONify(w, &api.UserSession{
+			User:    req.User,
+			Role:    role.String(),
+			Expires: &expires,
+		})
+	}).Methods("POST")
+
+	router.HandleFunc("/api/auth/logout", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Cache-Control", "no-store")
+		a.setSessionCookie(w, "", time.Unix(0, 0))
+		w.WriteHeader(http.StatusOK)
+	}).Methods("POST")
+
+	router.HandleFunc("/api/auth/user", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Cache-Control", "no-store")
+		res := a.userSession(r)
+		JSONify(w, &res)
+	}).Methods("GET")
+}
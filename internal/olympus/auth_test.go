@@ -0,0 +1,226 @@
+package olympus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"github.com/gorilla/mux"
+	. "gopkg.in/check.v1"
+)
+
+type AuthSuite struct {
+	datapath string
+	clock    *fakeClock
+	users    UserStore
+	auth     *Authenticator
+	router   *mux.Router
+}
+
+var _ = Suite(&AuthSuite{})
+
+func (s *AuthSuite) SetUpSuite(c *C) {
+	s.datapath = _datapath
+}
+
+func (s *AuthSuite) TearDownSuite(c *C) {
+	_datapath = s.datapath
+}
+
+func (s *AuthSuite) SetUpTest(c *C) {
+	_datapath = c.MkDir()
+	s.clock = newFakeClock(time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC))
+	s.users = NewUserStore("users")
+	s.auth = NewAuthenticator(s.users, []byte("some secret"), "admin-token")
+	s.auth.clock = s.clock
+
+	s.router = mux.NewRouter()
+	s.auth.setRoutes(s.router)
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		name, role := AuthenticatedUser(r.Context())
+		w.Write([]byte(name + ":" + role.String()))
+	}
+	s.router.HandleFunc("/api/zones", ok).Methods("GET")
+	s.router.HandleFunc("/api/maintenance", ok).Methods("POST")
+	s.router.HandleFunc("/api/webhooks", ok).Methods("GET")
+	s.router.Use(s.auth.Middleware)
+}
+
+func (s *AuthSuite) do(method, path string, setup func(*http.Request)) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if setup != nil {
+		setup(req)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func basicAuth(user, password string) func(*http.Request) {
+	return func(r *http.Request) { r.SetBasicAuth(user, password) }
+}
+
+func (s *AuthSuite) TestParsesRoles(c *C) {
+	for _, r := range []Role{RoleViewer, RoleOperator, RoleAdmin} {
+		parsed, err := ParseRole(r.String())
+		c.Check(err, IsNil)
+		c.Check(parsed, Equals, r)
+	}
+	_, err := ParseRole("none")
+	c.Check(err, ErrorMatches, "olympus: invalid role 'none'")
+}
+
+func (s *AuthSuite) TestPersistsUsers(c *C) {
+	c.Assert(s.users.Add("alice", "secret", RoleOperator), IsNil)
+	c.Check(s.users.Add("", "secret", RoleOperator), ErrorMatches, "olympus: invalid user name ''")
+	c.Check(s.users.Add("bob", "", RoleOperator), ErrorMatches, "olympus: empty password")
+
+	users := NewUserStore("users")
+	c.Check(users.List(), HasLen, 1)
+	role, err := users.Authenticate("alice", "secret")
+	c.Check(err, IsNil)
+	c.Check(role, Equals, RoleOperator)
+	_, err = users.Authenticate("alice", "wrong")
+	c.Check(err, Equals, InvalidCredentialsError)
+	_, err = users.Authenticate("bob", "secret")
+	c.Check(err, Equals, InvalidCredentialsError)
+
+	c.Check(users.Remove("bob"), ErrorMatches, "olympus: unknown user 'bob'")
+	c.Check(users.Remove("alice"), IsNil)
+	c.Check(users.Role("alice"), Equals, RoleNone)
+}
+
+func (s *AuthSuite) TestAppliesUserChangesLive(c *C) {
+	c.Assert(s.users.Add("alice", "secret", RoleAdmin), IsNil)
+	c.Check(s.auth.Enabled(), Equals, true)
+	s.users.(*userStore).reloadPeriod = 0
+
+	// as done by the user command, in another process.
+	command := NewUserStore("users")
+	c.Assert(command.Add("alice", "secret", RoleViewer), IsNil)
+	c.Check(s.users.Role("alice"), Equals, RoleViewer)
+
+	c.Assert(command.Remove("alice"), IsNil)
+	_, err := s.users.Authenticate("alice", "secret")
+	c.Check(err, Equals, InvalidCredentialsError)
+	c.Check(s.users.Count(), Equals, 0)
+	c.Check(s.auth.Enabled(), Equals, false)
+}
+
+func (s *AuthSuite) TestSessionCodec(c *C) {
+	codec := sessionCodec{secret: []byte("some secret")}
+	now := s.clock.Now()
+	value := codec.encode("alice", now.Add(time.Hour))
+
+	user, expires, err := codec.decode(value, now)
+	c.Check(err, IsNil)
+	c.Check(user, Equals, "alice")
+	c.Check(expires.Equal(now.Add(time.Hour)), Equals, true)
+
+	_, _, err = codec.decode(value, now.Add(time.Hour))
+	c.Check(err, ErrorMatches, "olympus: expired session")
+
+	_, _, err = sessionCodec{secret: []byte("other")}.decode(value, now)
+	c.Check(err, ErrorMatches, "olympus: invalid session")
+
+	payload, _, _ := strings.Cut(codec.encode("mallory", now.Add(time.Hour)), ".")
+	_, mac, _ := strings.Cut(value, ".")
+	_, _, err = codec.decode(payload+"."+mac, now)
+	c.Check(err, ErrorMatches, "olympus: invalid session")
+}
+
+func (s *AuthSuite) TestDisabledWithoutAccounts(c *C) {
+	c.Check(s.auth.Enabled(), Equals, false)
+	c.Check(s.do("POST", "/api/maintenance", nil).Code, Equals, http.StatusOK)
+}
+
+func (s *AuthSuite) TestChecksRoles(c *C) {
+	c.Assert(s.users.Add("viewer", "v", RoleViewer), IsNil)
+	c.Assert(s.users.Add("operator", "o", RoleOperator), IsNil)
+
+	w := s.do("GET", "/api/zones", nil)
+	c.Check(w.Code, Equals, http.StatusUnauthorized)
+	c.Check(w.Header().Get("WWW-Authenticate"), Not(Equals), "")
+	c.Check(s.do("GET", "/api/zones", basicAuth("viewer", "wrong")).Code, Equals, http.StatusUnauthorized)
+
+	w = s.do("GET", "/api/zones", basicAuth("viewer", "v"))
+	c.Check(w.Code, Equals, http.StatusOK)
+	c.Check(w.Body.String(), Equals, "viewer:viewer")
+
+	w = s.do("POST", "/api/maintenance", basicAuth("viewer", "v"))
+	c.Check(w.Code, Equals, http.StatusForbidden)
+	c.Check(w.Body.String(), Equals, "operator rights required\n")
+	c.Check(s.do("POST", "/api/maintenance", basicAuth("operator", "o")).Code, Equals, http.StatusOK)
+
+	c.Check(s.do("GET", "/api/webhooks", basicAuth("operator", "o")).Code, Equals, http.StatusForbidden)
+	w = s.do("GET", "/api/webhooks", func(r *http.Request) {
+		r.Header.Set("Authorization", "Bearer admin-token")
+	})
+	c.Check(w.Code, Equals, http.StatusOK)
+	c.Check(w.Body.String(), Equals, ":admin")
+}
+
+func (s *AuthSuite) TestAcknowledgesAsAuthenticatedUser(c *C) {
+	ctx := context.Background()
+	by, err := acknowledgedBy(ctx, "bob")
+	c.Check(err, IsNil)
+	c.Check(by, Equals, "bob")
+
+	ctx = context.WithValue(ctx, authUserKey, authenticatedUser{name: "alice", role: RoleOperator})
+	by, err = acknowledgedBy(ctx, "")
+	c.Check(err, IsNil)
+	c.Check(by, Equals, "alice")
+	by, err = acknowledgedBy(ctx, "alice")
+	c.Check(err, IsNil)
+	c.Check(by, Equals, "alice")
+	_, err = acknowledgedBy(ctx, "bob")
+	c.Check(err, ErrorMatches, "olympus: 'alice' cannot acknowledge alarms as 'bob'")
+}
+
+func (s *AuthSuite) TestLogsInWithSessions(c *C) {
+	c.Assert(s.users.Add("alice", "secret", RoleOperator), IsNil)
+
+	login := func(password string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(api.LoginRequest{User: "alice", Password: password})
+		req := httptest.NewRequest("POST", "/api/auth/login", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		return w
+	}
+	c.Check(login("wrong").Code, Equals, http.StatusUnauthorized)
+
+	w := login("secret")
+	c.Assert(w.Code, Equals, http.StatusOK)
+	session := api.UserSession{}
+	c.Assert(json.NewDecoder(w.Body).Decode(&session), IsNil)
+	c.Check(session.User, Equals, "alice")
+	c.Check(session.Role, Equals, "operator")
+	c.Assert(session.Expires, NotNil)
+	c.Check(session.Expires.Equal(s.clock.Now().Add(SessionDuration)), Equals, true)
+
+	cookies := w.Result().Cookies()
+	c.Assert(cookies, HasLen, 1)
+	withCookie := func(r *http.Request) { r.AddCookie(cookies[0]) }
+
+	w = s.do("POST", "/api/maintenance", withCookie)
+	c.Check(w.Code, Equals, http.StatusOK)
+	c.Check(w.Body.String(), Equals, "alice:operator")
+
+	w = s.do("GET", "/api/auth/user", withCookie)
+	c.Assert(w.Code, Equals, http.StatusOK)
+	c.Assert(json.NewDecoder(w.Body).Decode(&session), IsNil)
+	c.Check(session.User, Equals, "alice")
+
+	// role changes apply to opened sessions
+	c.Assert(s.users.Add("alice", "secret", RoleViewer), IsNil)
+	c.Check(s.do("POST", "/api/maintenance", withCookie).Code, Equals, http.StatusForbidden)
+
+	s.clock.Advance(SessionDuration)
+	c.Check(s.do("GET", "/api/zones", withCookie).Code, Equals, http.StatusUnauthorized)
+}
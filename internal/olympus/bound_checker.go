@@ -0,0 +1,99 @@
+package olympus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// A BoundCheckPolicy configures the server-side evaluation of climate
+// reports against the bounds declared by a zone.
+type BoundCheckPolicy struct {
+	// TemperatureHysteresis is how far inside its bounds, in °C, the
+	// temperature or an auxiliary temperature must come back to clear
+	// its alarm.
+	TemperatureHysteresis float32
+	// HumidityHysteresis is how far inside its bounds, in %, the
+	// humidity must come back to clear its alarm.
+	HumidityHysteresis float32
+	// MinimumDuration is how long a value must stay out of its
+	// bounds before raising an alarm.
+	MinimumDuration time.Duration
+}
+
+// A boundChecker raises an alarm when a quantity stays out of its
+// bounds.
+type boundChecker struct {
+	identification string
+	quantity, unit string
+	bounds         api.Bounds
+
+	outSince *time.Time
+	on       bool
+}
+
+func newBoundChecker(identification, quantity, unit string, bounds api.Bounds) *boundChecker {
+	if bounds.Minimum == nil && bounds.Maximum == nil {
+		return nil
+	}
+	return &boundChecker{
+		identification: identification,
+		quantity:       quantity,
+		unit:           unit,
+		bounds:         bounds,
+	}
+}
+
+func (c *boundChecker) outOfBounds(value, margin float32) bool {
+	return (c.bounds.Minimum != nil && value < *c.bounds.Minimum+margin) ||
+		(c.bounds.Maximum != nil && value > *c.bounds.Maximum-margin)
+}
+
+func (c *boundChecker) describe(value float32) string {
+	if c.bounds.Minimum != nil && value < *c.bounds.Minimum {
+		return fmt.Sprintf("%s %.1f%s is below its minimum of %.1f%s",
+			c.quantity, value, c.unit, *c.bounds.Minimum, c.unit)
+	}
+	return fmt.Sprintf("%s %.1f%s is above its maximum of %.1f%s",
+		c.quantity, value, c.unit, *c.bounds.Maximum, c.unit)
+}
+
+// check evaluates a new value. It returns an alarm update if the
+// alarm status changed.
+func (c *boundChecker) check(t time.Time, value, hysteresis float32, minimumDuration time.Duration) *api.AlarmUpdate {
+	if c.on == true {
+		if c.outOfBounds(value, hysteresis) == true {
+			return nil
+		}
+		c.on = false
+		c.outSince = nil
+		return &api.AlarmUpdate{
+			Identification: c.identification,
+			Level:          api.AlarmLevel_WARNING,
+			Status:         api.AlarmStatus_OFF,
+			Time:           timestamppb.New(t),
+		}
+	}
+
+	if c.outOfBounds(value, 0) == false {
+		c.outSince = nil
+		return nil
+	}
+	if c.outSince == nil {
+		c.outSince = new(time.Time)
+		*c.outSince = t
+	}
+	if t.Sub(*c.outSince) < minimumDuration {
+		return nil
+	}
+	c.on = true
+	return &api.AlarmUpdate{
+		Identification: c.identification,
+		Level:          api.AlarmLevel_WARNING,
+		Status:         api.AlarmStatus_ON,
+		Time:           timestamppb.New(t),
+		Description:    c.describe(value),
+	}
+}
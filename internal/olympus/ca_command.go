@@ -0,0 +1,95 @@
+package olympus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type CACommand struct{}
+
+type CAOptions struct {
+	ConfigOptions
+
+	Dir string `long:"ca-dir" description:"directory of the certificate authority, default to the ca directory of the configuration data_home" env:"OLYMPUS_CA_DIR"`
+}
+
+func (o CAOptions) dir() string {
+	if len(o.Dir) > 0 {
+		return o.Dir
+	}
+	return filepath.Join(_datapath, "ca")
+}
+
+type InitCACommand struct {
+	CAOptions
+
+	Name     string        `long:"name" description:"common name of the authority" default:"olympus CA"`
+	Validity time.Duration `long:"validity" description:"validity of the authority" default:"87600h"`
+}
+
+type IssueCACommand struct {
+	CAOptions
+
+	DNSNames []string      `long:"dns" description:"additional DNS name of the certificate, can be set multiple times"`
+	Server   bool          `long:"server" description:"issues a certificate usable by the olympus service"`
+	Validity time.Duration `long:"validity" description:"validity of the certificate" default:"17520h"`
+	Output   string        `short:"o" long:"output" description:"directory to write the certificate and key to" default:"."`
+
+	Args struct {
+		Name string `positional-arg-name:"name" description:"hostname of the zeus or leto host, or of the olympus service"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (c *InitCACommand) Execute([]string) error {
+	if err := c.apply(); err != nil {
+		return err
+	}
+	ca, err := NewCertificateAuthority(c.Name, c.Validity)
+	if err != nil {
+		return err
+	}
+	if err := ca.Save(c.dir()); err != nil {
+		return err
+	}
+	fmt.Printf("created authority in %s\n", c.dir())
+	return nil
+}
+
+func (c *IssueCACommand) Execute([]string) error {
+	if err := c.apply(); err != nil {
+		return err
+	}
+	ca, err := LoadCertificateAuthority(c.dir())
+	if err != nil {
+		return fmt.Errorf("could not load authority: %w", err)
+	}
+	cert, key, err := ca.Issue(c.Args.Name, c.DNSNames, c.Server, c.Validity)
+	if err != nil {
+		return err
+	}
+	certFile := filepath.Join(c.Output, c.Args.Name+".crt")
+	keyFile := filepath.Join(c.Output, c.Args.Name+".key")
+	if err := writeNewFile(keyFile, key, 0600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(certFile, cert, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("issued %s and %s, signed by %s\n",
+		certFile, keyFile, filepath.Join(c.dir(), caCertificateFile))
+	return nil
+}
+
+func init() {
+	cmd, err := parser.AddCommand("ca",
+		"manages the gRPC certificate authority.",
+		"manages a certificate authority issuing the certificates of the olympus service and of the zeus and leto hosts, when the gRPC service requires client certificates.",
+		&CACommand{})
+	if err != nil {
+		panic(err.Error())
+	}
+	cmd.AddCommand("init", "creates the authority", "creates a new certificate authority. An existing one is never overwritten.", &InitCACommand{})
+	cmd.AddCommand("issue", "issues a certificate", "issues a certificate and its key for a host. The host name is the identity checked against zone declarations.", &IssueCACommand{})
+}
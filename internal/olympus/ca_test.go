@@ -0,0 +1,87 @@
+package olympus
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	. "gopkg.in/check.v1"
+)
+
+type CASuite struct {
+	dir string
+	ca  *CertificateAuthority
+}
+
+var _ = Suite(&CASuite{})
+
+func (s *CASuite) SetUpTest(c *C) {
+	s.dir = c.MkDir()
+	var err error
+	s.ca, err = NewCertificateAuthority("test CA", 24*time.Hour)
+	c.Assert(err, IsNil)
+	c.Assert(s.ca.Save(s.dir), IsNil)
+}
+
+func (s *CASuite) issue(c *C, name string, server bool) *x509.Certificate {
+	ca, err := LoadCertificateAuthority(s.dir)
+	c.Assert(err, IsNil)
+	certPEM, keyPEM, err := ca.Issue(name, nil, server, 48*time.Hour)
+	c.Assert(err, IsNil)
+	_, err = tls.X509KeyPair(certPEM, keyPEM)
+	c.Assert(err, IsNil)
+	block, _ := pem.Decode(certPEM)
+	c.Assert(block, NotNil)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	c.Assert(err, IsNil)
+	return cert
+}
+
+func (s *CASuite) TestDoesNotOverwrite(c *C) {
+	c.Check(s.ca.Save(s.dir), ErrorMatches, ".*file exists")
+}
+
+func (s *CASuite) TestIssuesCertificates(c *C) {
+	cert := s.issue(c, "zeus-1", false)
+	c.Check(cert.Subject.CommonName, Equals, "zeus-1")
+	c.Check(cert.NotAfter.After(s.ca.Certificate.NotAfter), Equals, false)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(s.ca.Certificate)
+	_, err := cert.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	c.Check(err, IsNil)
+	_, err = cert.Verify(x509.VerifyOptions{Roots: roots})
+	c.Check(err, NotNil)
+
+	cert = s.issue(c, "olympus", true)
+	_, err = cert.Verify(x509.VerifyOptions{Roots: roots, DNSName: "olympus"})
+	c.Check(err, IsNil)
+}
+
+func (s *CASuite) TestChecksClientIdentity(c *C) {
+	cert := s.issue(c, "zeus-1", false)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{cert, s.ca.Certificate}},
+			},
+		},
+	})
+	c.Check(checkClientIdentity(ctx, "zeus-1"), IsNil)
+	c.Check(checkClientIdentity(ctx, "ZEUS-1"), IsNil)
+	c.Check(checkClientIdentity(ctx, "zeus-2"), ErrorMatches,
+		"olympus: client certificate does not identify host 'zeus-2'")
+	c.Check(checkClientIdentity(context.Background(), "zeus-2"), IsNil)
+	c.Check(mapError(ClientIdentityError("zeus-2")), ErrorMatches, "rpc error: code = PermissionDenied .*")
+
+	_, err := api.NewServerTLSConfig("", "", s.dir+"/ca.crt")
+	c.Check(err, ErrorMatches, "both a certificate and a key are required")
+}
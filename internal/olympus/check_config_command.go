@@ -0,0 +1,57 @@
+package olympus
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"gopkg.in/yaml.v3"
+)
+
+type CheckConfigCommand struct {
+	Config string `long:"config" short:"c" description:"YAML configuration file" env:"OLYMPUS_CONFIG"`
+	Print  bool   `long:"print" short:"p" description:"prints the resulting configuration on stdout, secrets are redacted"`
+}
+
+func (c *CheckConfigCommand) Execute([]string) error {
+	config, err := LoadConfig(c.Config)
+	if err != nil {
+		return err
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if len(config.RPC.TLSCert) > 0 {
+		_, err := api.NewServerTLSConfig(config.RPC.TLSCert, config.RPC.TLSKey, config.RPC.TLSClientCA)
+		if err != nil {
+			return fmt.Errorf("could not load TLS configuration: %w", err)
+		}
+	}
+
+	if c.Print == false {
+		fmt.Fprintln(os.Stderr, "configuration is valid")
+		return nil
+	}
+	redact := func(secret *string) {
+		if len(*secret) > 0 {
+			*secret = "<redacted>"
+		}
+	}
+	redact(&config.Secret)
+	redact(&config.AdminToken)
+	redact(&config.Notifications.WebPush.PrivateKey)
+	redact(&config.Notifications.Email.Password)
+	encoder := yaml.NewEncoder(os.Stdout)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(config); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func init() {
+	parser.AddCommand("check-config",
+		"checks a configuration file.",
+		"checks a configuration file of olympus run, with the environment overrides, and exits with an error if it is invalid",
+		&CheckConfigCommand{})
+}
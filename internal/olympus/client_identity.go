@@ -0,0 +1,52 @@
+package olympus
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// A ClientIdentityError is returned when a gRPC client declares a host
+// its certificate does not identify.
+type ClientIdentityError string
+
+func (e ClientIdentityError) Error() string {
+	return "olympus: client certificate does not identify host '" + string(e) + "'"
+}
+
+// withPeer returns ctx with the gRPC peer of stream, as the messages
+// of a stream are handled within a context without it.
+func withPeer(ctx, stream context.Context) context.Context {
+	p, ok := peer.FromContext(stream)
+	if ok == false {
+		return ctx
+	}
+	return peer.NewContext(ctx, p)
+}
+
+// checkClientIdentity checks that the verified certificate of the
+// gRPC peer of ctx identifies host, either by its common name or one
+// of its DNS names. Peers without a verified certificate are only
+// possible if the service does not require them, and are accepted.
+func checkClientIdentity(ctx context.Context, host string) error {
+	p, ok := peer.FromContext(ctx)
+	if ok == false {
+		return nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if ok == false || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	cert := info.State.VerifiedChains[0][0]
+	if strings.EqualFold(cert.Subject.CommonName, host) == true {
+		return nil
+	}
+	for _, name := range cert.DNSNames {
+		if strings.EqualFold(name, host) == true {
+			return nil
+		}
+	}
+	return ClientIdentityError(host)
+}
@@ -0,0 +1,157 @@
+package olympus
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// backlogTimes persists, for each zone, the time of the latest
+// report received by backlog upload. It cannot be deduced from the
+// ClimateStore, as live reports are stored while a backlog is
+// uploaded.
+type backlogTimes struct {
+	mx    sync.Mutex
+	times *PersistentMap[time.Time]
+}
+
+func newBacklogTimes(name string) *backlogTimes {
+	return &backlogTimes{
+		times: NewPersistentMap[time.Time](name),
+	}
+}
+
+func (b *backlogTimes) get(zone string) (time.Time, bool) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	t, ok := b.times.Map[zone]
+	return t, ok
+}
+
+func (b *backlogTimes) update(zone string, t time.Time) (time.Time, error) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	if last, ok := b.times.Map[zone]; ok == true && last.After(t) == true {
+		return last, nil
+	}
+	b.times.Map[zone] = t
+	return t, b.times.SaveKey(zone)
+}
+
+// A climateBacklog deduplicates the reports of a backlog upload
+// against the persisted ones. The timestamps of a day are loaded from
+// the ClimateStore the first time the upload reaches it.
+type climateBacklog struct {
+	zone  string
+	store ClimateStore
+	known map[time.Time]map[int64]struct{}
+}
+
+func newClimateBacklog(zone string, store ClimateStore) *climateBacklog {
+	return &climateBacklog{
+		zone:  zone,
+		store: store,
+		known: make(map[time.Time]map[int64]struct{}),
+	}
+}
+
+func (b *climateBacklog) knownTimes(day time.Time) (map[int64]struct{}, error) {
+	if res, ok := b.known[day]; ok == true {
+		return res, nil
+	}
+	res := make(map[int64]struct{})
+	err := b.store.ForEach(b.zone, day, day.Add(24*time.Hour-1), func(r *api.ClimateReport) error {
+		res[r.Time.AsTime().UnixNano()] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	b.known[day] = res
+	return res, nil
+}
+
+// filter returns the reports which are neither persisted nor
+// duplicated within the upload.
+func (b *climateBacklog) filter(reports []*api.ClimateReport) ([]*api.ClimateReport, error) {
+	res := make([]*api.ClimateReport, 0, len(reports))
+	for _, r := range reports {
+		if r.Time == nil {
+			continue
+		}
+		t := r.Time.AsTime()
+		known, err := b.knownTimes(segmentDay(t))
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := known[t.UnixNano()]; ok == true {
+			continue
+		}
+		known[t.UnixNano()] = struct{}{}
+		res = append(res, r)
+	}
+	return res, nil
+}
+
+// GetClimateBacklogStatus returns the time of the latest report of a
+// zone received by backlog upload.
+func (o *Olympus) GetClimateBacklogStatus(host, zone string) *api.ClimateBacklogStatus {
+	res := &api.ClimateBacklogStatus{}
+	if t, ok := o.backlogTimes.get(ZoneIdentifier(host, zone)); ok == true {
+		res.LastTime = timestamppb.New(t)
+	}
+	return res
+}
+
+// storeClimateBacklog persists the reports of a backlog upload which
+// are not already stored, and pushes them to the zone logger if it is
+// registered. It updates summary accordingly.
+func (o *Olympus) storeClimateBacklog(ctx context.Context, backlog *climateBacklog, reports []*api.ClimateReport, summary *api.ClimateBacklogSummary) error {
+	summary.Received += int64(len(reports))
+	reports, err := backlog.filter(reports)
+	if err != nil {
+		return err
+	}
+	if len(reports) == 0 {
+		return nil
+	}
+	if err := o.climateStore.Append(backlog.zone, reports); err != nil {
+		return err
+	}
+	summary.Stored += int64(len(reports))
+
+	latest := reports[0].Time.AsTime()
+	for _, r := range reports[1:] {
+		if t := r.Time.AsTime(); t.After(latest) {
+			latest = t
+		}
+	}
+	latest, err = o.backlogTimes.update(backlog.zone, latest)
+	if err != nil {
+		return err
+	}
+	summary.LastTime = timestamppb.New(latest)
+
+	o.mx.RLock()
+	sub, ok := o.subscriptions[backlog.zone]
+	var logger ClimateLogger
+	if ok == true && sub.climate != nil {
+		logger = sub.climate.object
+	}
+	o.mx.RUnlock()
+	if logger != nil {
+		logger.PushReports(reports)
+	}
+
+	o.log.WithContext(ctx).WithFields(logrus.Fields{
+		"zone":     backlog.zone,
+		"received": summary.Received,
+		"stored":   summary.Stored,
+		"lastTime": latest,
+	}).Debug("climate backlog progress")
+	return nil
+}
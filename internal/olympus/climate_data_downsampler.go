@@ -34,6 +34,17 @@ var supportedUnits = map[time.Duration]string{
 	24 * time.Hour: "d",
 }
 
+// unitDuration returns the duration of the units of a
+// ClimateTimeSeries, or false if they are not supported.
+func unitDuration(units string) (time.Duration, bool) {
+	for d, u := range supportedUnits {
+		if u == units {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
 func NewClimateDataDownsampler(window, unit time.Duration, samples int) ClimateDataDownsampler {
 	targetPeriod := window / time.Duration(samples)
 	minimumPeriod := time.Duration(float64(targetPeriod) / CutOfFrequencyRatio)
@@ -61,25 +72,72 @@ func (d *climateDataDownsampler) Add(values TimedValues) {
 
 func (d *climateDataDownsampler) computeSeries() {
 	reference := d.values.times[len(d.values.times)-1]
-	series := d.values.Downsample(d.samples, reference, d.unit)
-	d.series = api.ClimateTimeSeries{
+	d.series = buildClimateTimeSeries(d.values, d.samples, reference, d.unit)
+}
+
+// buildClimateTimeSeries downsamples values, as produced by
+// buildBatch, to a ClimateTimeSeries.
+func buildClimateTimeSeries(values TimedValues, samples int, reference time.Time, unit time.Duration) api.ClimateTimeSeries {
+	series := values.Downsample(samples, reference, unit)
+	res := api.ClimateTimeSeries{
 		Reference: reference,
-		Units:     supportedUnits[d.unit],
+		Units:     supportedUnits[unit],
+	}
+
+	get := func(i int) api.PointSeries {
+		if i >= len(series) {
+			return nil
+		}
+		return series[i]
 	}
+	res.Humidity = get(humidityValues)
+	res.Wind = get(windValues)
+	res.VisibleLight = get(visibleLightValues)
+	res.UvLight = get(uvLightValues)
+	res.Temperature = get(temperatureValues)
 
-	if len(series) > 0 {
-		d.series.Humidity = series[0]
+	if len(series) > temperatureValues+1 {
+		res.TemperatureAux = make([]api.PointSeries, len(series)-temperatureValues-1)
+		for i := range res.TemperatureAux {
+			res.TemperatureAux[i] = series[temperatureValues+1+i]
+		}
 	}
-	if len(series) > 1 {
-		d.series.Temperature = series[1]
+	return res
+}
+
+// unitForSpan chooses the time unit used to represent a time span.
+func unitForSpan(span time.Duration) time.Duration {
+	switch {
+	case span <= 2*time.Hour:
+		return time.Minute
+	case span <= 2*24*time.Hour:
+		return time.Hour
+	default:
+		return 24 * time.Hour
 	}
+}
 
-	if len(series) > 2 {
-		d.series.TemperatureAux = make([]api.PointSeries, len(series)-2)
-		for i := range d.series.TemperatureAux {
-			d.series.TemperatureAux[i] = series[i+2]
+// DownsampleClimateReports builds a ClimateTimeSeries of at most
+// samples points from a list of time sorted reports. reference is
+// used as the origin of the time axis.
+func DownsampleClimateReports(reports []*api.ClimateReport, samples int, reference time.Time) api.ClimateTimeSeries {
+	if len(reports) == 0 {
+		return api.ClimateTimeSeries{Reference: reference}
+	}
+	values := buildBatch(reports)
+	// series missing their last values would be ignored when
+	// downsampling.
+	for i, v := range values.values {
+		if len(v) == 0 {
+			continue
+		}
+		for len(v) < len(values.times) {
+			v = append(v, v[len(v)-1])
 		}
+		values.values[i] = v
 	}
+	span := values.times[len(values.times)-1].Sub(values.times[0])
+	return buildClimateTimeSeries(values, samples, reference, unitForSpan(span))
 }
 
 func (d *climateDataDownsampler) TimeSeries() api.ClimateTimeSeries {
@@ -0,0 +1,142 @@
+package olympus
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+)
+
+type ClimateExportFormat string
+
+const (
+	ClimateExportCSV    ClimateExportFormat = "csv"
+	ClimateExportNDJSON ClimateExportFormat = "ndjson"
+)
+
+type UnsupportedExportFormatError string
+
+func (e UnsupportedExportFormatError) Error() string {
+	return fmt.Sprintf("olympus: unsupported export format '%s'", string(e))
+}
+
+// ParseClimateExportFormat parses an export format. An empty string
+// defaults to CSV.
+func ParseClimateExportFormat(format string) (ClimateExportFormat, error) {
+	switch ClimateExportFormat(format) {
+	case "", ClimateExportCSV:
+		return ClimateExportCSV, nil
+	case ClimateExportNDJSON:
+		return ClimateExportNDJSON, nil
+	default:
+		return "", UnsupportedExportFormatError(format)
+	}
+}
+
+func (f ClimateExportFormat) ContentType() string {
+	if f == ClimateExportNDJSON {
+		return "application/x-ndjson"
+	}
+	return "text/csv"
+}
+
+// ExportClimateReports streams all raw reports of a zone within
+// [from,to] to w. A zero from or to means no bound.
+func ExportClimateReports(w io.Writer, store ClimateStore, zone string, from, to time.Time, format ClimateExportFormat) error {
+	switch format {
+	case ClimateExportCSV:
+		return exportClimateCSV(w, store, zone, from, to)
+	case ClimateExportNDJSON:
+		return exportClimateNDJSON(w, store, zone, from, to)
+	default:
+		return UnsupportedExportFormatError(format)
+	}
+}
+
+func exportClimateNDJSON(w io.Writer, store ClimateStore, zone string, from, to time.Time) error {
+	enc := json.NewEncoder(w)
+	return store.ForEach(zone, from, to, func(r *api.ClimateReport) error {
+		return enc.Encode(api.NewClimateRecord(r))
+	})
+}
+
+func formatMayFloat(v *float32) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(float64(*v), 'g', -1, 32)
+}
+
+func exportClimateCSV(w io.Writer, store ClimateStore, zone string, from, to time.Time) error {
+	// The number of auxiliary temperatures may change over time,
+	// and older clients do not send wind and light values, we need a
+	// first pass to build the header.
+	auxs := 0
+	optionals := []struct {
+		name    string
+		value   func(api.ClimateRecord) *float32
+		present bool
+	}{
+		{name: "wind", value: func(r api.ClimateRecord) *float32 { return r.Wind }},
+		{name: "visible_light", value: func(r api.ClimateRecord) *float32 { return r.VisibleLight }},
+		{name: "uv_light", value: func(r api.ClimateRecord) *float32 { return r.UvLight }},
+	}
+	err := store.ForEach(zone, from, to, func(r *api.ClimateReport) error {
+		auxs = Max(auxs, len(r.Temperatures)-1)
+		record := api.NewClimateRecord(r)
+		for i := range optionals {
+			optionals[i].present = optionals[i].present || optionals[i].value(record) != nil
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	header := []string{"time", "humidity", "temperature"}
+	for i := 0; i < auxs; i++ {
+		header = append(header, fmt.Sprintf("temperature_aux_%d", i+1))
+	}
+	for _, o := range optionals {
+		if o.present == true {
+			header = append(header, o.name)
+		}
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	row := make([]string, len(header))
+	err = store.ForEach(zone, from, to, func(r *api.ClimateReport) error {
+		record := api.NewClimateRecord(r)
+		row[0] = record.Time.UTC().Format(time.RFC3339Nano)
+		row[1] = formatMayFloat(record.Humidity)
+		row[2] = formatMayFloat(record.Temperature)
+		for i := 0; i < auxs; i++ {
+			if i < len(record.TemperatureAux) {
+				row[3+i] = formatMayFloat(&record.TemperatureAux[i])
+			} else {
+				row[3+i] = ""
+			}
+		}
+		j := 3 + auxs
+		for _, o := range optionals {
+			if o.present == false {
+				continue
+			}
+			row[j] = formatMayFloat(o.value(record))
+			j += 1
+		}
+		return writer.Write(row)
+	})
+	writer.Flush()
+	if err != nil {
+		return err
+	}
+	return writer.Error()
+}
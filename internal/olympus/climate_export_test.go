@@ -0,0 +1,106 @@
+package olympus
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	. "gopkg.in/check.v1"
+)
+
+type ClimateExportSuite struct {
+	datapath string
+	store    ClimateStore
+	start    time.Time
+}
+
+var _ = Suite(&ClimateExportSuite{})
+
+func (s *ClimateExportSuite) SetUpSuite(c *C) {
+	s.datapath = _datapath
+}
+
+func (s *ClimateExportSuite) TearDownSuite(c *C) {
+	_datapath = s.datapath
+}
+
+func (s *ClimateExportSuite) SetUpTest(c *C) {
+	_datapath = c.MkDir()
+	s.store = NewClimateStore("climate-reports", 0)
+	s.start = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	reports := []*api.ClimateReport{
+		{
+			Time:         timestamppb.New(s.start),
+			Humidity:     newInitialized[float32](55.5),
+			Temperatures: []float32{21.25},
+		},
+		{
+			Time:         timestamppb.New(s.start.Add(time.Second)),
+			Temperatures: []float32{21.5, 19, 18.5},
+		},
+		{
+			Time:     timestamppb.New(s.start.Add(2 * time.Second)),
+			Humidity: newInitialized[float32](56),
+		},
+	}
+	c.Assert(s.store.Append("foo.box", reports), IsNil)
+}
+
+func (s *ClimateExportSuite) TestParseFormat(c *C) {
+	testdata := []struct {
+		Input    string
+		Expected ClimateExportFormat
+		Error    string
+	}{
+		{"", ClimateExportCSV, ""},
+		{"csv", ClimateExportCSV, ""},
+		{"ndjson", ClimateExportNDJSON, ""},
+		{"xml", "", "olympus: unsupported export format 'xml'"},
+	}
+	for _, d := range testdata {
+		format, err := ParseClimateExportFormat(d.Input)
+		if len(d.Error) > 0 {
+			c.Check(err, ErrorMatches, d.Error)
+		} else {
+			c.Check(err, IsNil)
+			c.Check(format, Equals, d.Expected)
+		}
+	}
+}
+
+func (s *ClimateExportSuite) TestExportsCSV(c *C) {
+	buffer := bytes.NewBuffer(nil)
+	c.Assert(ExportClimateReports(buffer, s.store, "foo.box", time.Time{}, time.Time{}, ClimateExportCSV), IsNil)
+	c.Check(buffer.String(), Equals, `time,humidity,temperature,temperature_aux_1,temperature_aux_2
+2023-01-01T00:00:00Z,55.5,21.25,,
+2023-01-01T00:00:01Z,,21.5,19,18.5
+2023-01-01T00:00:02Z,56,,,
+`)
+}
+
+func (s *ClimateExportSuite) TestExportsOnlyPresentOptionalColumns(c *C) {
+	c.Assert(s.store.Append("foo.box", []*api.ClimateReport{
+		{
+			Time:         timestamppb.New(s.start.Add(3 * time.Second)),
+			Temperatures: []float32{22},
+			VisibleLight: newInitialized[float32](80),
+		},
+	}), IsNil)
+	buffer := bytes.NewBuffer(nil)
+	c.Assert(ExportClimateReports(buffer, s.store, "foo.box",
+		s.start.Add(2*time.Second), time.Time{}, ClimateExportCSV), IsNil)
+	c.Check(buffer.String(), Equals, `time,humidity,temperature,visible_light
+2023-01-01T00:00:02Z,56,,
+2023-01-01T00:00:03Z,,22,80
+`)
+}
+
+func (s *ClimateExportSuite) TestExportsNDJSON(c *C) {
+	buffer := bytes.NewBuffer(nil)
+	c.Assert(ExportClimateReports(buffer, s.store, "foo.box",
+		s.start.Add(time.Second), time.Time{}, ClimateExportNDJSON), IsNil)
+	c.Check(buffer.String(), Equals, `{"time":"2023-01-01T00:00:01Z","temperature":21.5,"temperatureAux":[19,18.5]}
+{"time":"2023-01-01T00:00:02Z","humidity":56}
+`)
+}
@@ -1,12 +1,20 @@
 package olympus
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/formicidae-tracker/olympus/pkg/api"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// ClimateStaleAlarmIdentification is the identification, within the
+// climate domain, of the server-generated alarm raised when a zone
+// stops sending reports.
+const ClimateStaleAlarmIdentification = "reports.stale"
+
 type ClimateLogger interface {
 	Host() string
 	ZoneName() string
@@ -17,6 +25,20 @@ type ClimateLogger interface {
 	PushReports([]*api.ClimateReport)
 	GetClimateTimeSeries(window string) api.ClimateTimeSeries
 	GetClimateReport() *api.ZoneClimateReport
+	// CheckStale checks if no report was received for more than
+	// period, a non-positive period disabling the check. It returns
+	// the server-generated alarm updates, in the climate domain, if
+	// the staleness of the zone changed.
+	CheckStale(now time.Time, period time.Duration) []*api.AlarmUpdate
+	// CheckBounds evaluates reports against the declared bounds of
+	// the zone. It returns the server-generated alarm updates, in the
+	// olympus domain, caused by these reports.
+	CheckBounds(reports []*api.ClimateReport, policy BoundCheckPolicy) []*api.AlarmUpdate
+	// TrackTarget accounts the deviation of reports from the current
+	// target in the tracking error statistics. If policy is not nil,
+	// it returns the server-generated alarm updates, in the olympus
+	// domain, caused by these deviations.
+	TrackTarget(reports []*api.ClimateReport, policy *TargetTrackingPolicy) []*api.AlarmUpdate
 }
 
 const (
@@ -32,28 +54,45 @@ type climateLogger struct {
 	host, name     string
 	currentReport  *api.ZoneClimateReport
 	lastReportTime time.Time
+	registered     time.Time
+	stale          bool
+
+	temperatureChecker, humidityChecker *boundChecker
+	// indexed by auxiliary temperature, nil if it has no bounds.
+	auxCheckers []*boundChecker
+
+	targetStart                                         time.Time
+	trackingErrors                                      trackingErrorStatistics
+	temperatureTrackingChecker, humidityTrackingChecker *boundChecker
 
 	samplers         []ClimateDataDownsampler
 	samplersByWindow map[string]ClimateDataDownsampler
 }
 
 func NewClimateLogger(declaration *api.ClimateDeclaration) ClimateLogger {
-	samplers := []ClimateDataDownsampler{
-		NewClimateDataDownsampler(10*time.Minute, time.Minute, 500),
-		NewClimateDataDownsampler(1*time.Hour, time.Minute, 400),
-		NewClimateDataDownsampler(24*time.Hour, time.Hour, 300),
-		NewClimateDataDownsampler(7*24*time.Hour, 24*time.Hour, 300),
-	}
-	samplersByWindow := map[string]ClimateDataDownsampler{
-		"10-minute":  samplers[0],
-		"10m":        samplers[0],
-		"10-minutes": samplers[0],
-		"1h":         samplers[1],
-		"hour":       samplers[1],
-		"1d":         samplers[2],
-		"day":        samplers[2],
-		"1w":         samplers[3],
-		"week":       samplers[3],
+	samplers := make([]ClimateDataDownsampler, len(ClimateWindows))
+	samplersByWindow := make(map[string]ClimateDataDownsampler)
+	for i, w := range ClimateWindows {
+		samplers[i] = NewClimateDataDownsampler(w.Duration, w.Unit, w.Samples)
+		for _, name := range w.Names {
+			samplersByWindow[name] = samplers[i]
+		}
+	}
+
+	sensors := api.NewSensorDescriptions(declaration.TemperatureSensors)
+	temperatureBounds := api.Bounds{
+		Minimum: declaration.MinTemperature,
+		Maximum: declaration.MaxTemperature,
+	}
+	if len(sensors) > 0 {
+		// the declaration bounds take precedence over the main sensor ones.
+		if temperatureBounds.Minimum == nil {
+			temperatureBounds.Minimum = sensors[0].Bounds.Minimum
+		}
+		if temperatureBounds.Maximum == nil {
+			temperatureBounds.Maximum = sensors[0].Bounds.Maximum
+		}
+		sensors[0].Bounds = temperatureBounds
 	}
 
 	res := &climateLogger{
@@ -61,20 +100,34 @@ func NewClimateLogger(declaration *api.ClimateDeclaration) ClimateLogger {
 		samplersByWindow: samplersByWindow,
 		host:             declaration.Host,
 		name:             declaration.Name,
+		registered:       time.Now(),
 		currentReport: &api.ZoneClimateReport{
-			Temperature: nil,
-			TemperatureBounds: api.Bounds{
-				Minimum: declaration.MinTemperature,
-				Maximum: declaration.MaxTemperature,
-			},
-			Humidity: nil,
+			Temperature:       nil,
+			TemperatureBounds: temperatureBounds,
+			Humidity:          nil,
 			HumidityBounds: api.Bounds{
 				Minimum: declaration.MinHumidity,
 				Maximum: declaration.MaxHumidity,
 			},
+			TemperatureSensors: sensors,
 		},
 	}
 
+	res.temperatureChecker = newBoundChecker("temperature.out-of-bound",
+		"temperature", "°C", res.currentReport.TemperatureBounds)
+	res.humidityChecker = newBoundChecker("humidity.out-of-bound",
+		"humidity", "%", res.currentReport.HumidityBounds)
+	for i := 1; i < len(sensors); i++ {
+		key, quantity := sensors[i].Name, sensors[i].Name
+		if len(key) == 0 {
+			key = fmt.Sprintf("aux%d", i)
+			quantity = fmt.Sprintf("auxiliary temperature %d", i)
+		}
+		res.auxCheckers = append(res.auxCheckers, newBoundChecker(
+			"temperature."+key+".out-of-bound",
+			quantity, sensors[i].Unit, sensors[i].Bounds))
+	}
+
 	if declaration.Since != nil {
 		res.currentReport.Since = declaration.Since.AsTime()
 	} else {
@@ -84,6 +137,16 @@ func NewClimateLogger(declaration *api.ClimateDeclaration) ClimateLogger {
 	return res
 }
 
+// Indexes of the series in the TimedValues built from climate
+// reports. Temperature is followed by the auxiliary temperatures.
+const (
+	humidityValues = iota
+	windValues
+	visibleLightValues
+	uvLightValues
+	temperatureValues
+)
+
 func buildBatch(reports []*api.ClimateReport) TimedValues {
 	if len(reports) == 0 {
 		return TimedValues{}
@@ -105,17 +168,21 @@ func buildBatch(reports []*api.ClimateReport) TimedValues {
 
 	for i, r := range reports {
 		times[i] = r.Time.AsTime()
-		if r.Humidity != nil {
-			if len(values) == 0 {
-				values = append(values, nil)
+		set := func(j int, v *float32) {
+			if v == nil {
+				return
 			}
-			values[0] = appendValue(values[0], *r.Humidity, i+1)
-		}
-		for j, t := range r.Temperatures {
-			for len(values) <= j+1 {
+			for len(values) <= j {
 				values = append(values, nil)
 			}
-			values[j+1] = appendValue(values[j+1], t, i+1)
+			values[j] = appendValue(values[j], *v, i+1)
+		}
+		set(humidityValues, r.Humidity)
+		set(windValues, r.Wind)
+		set(visibleLightValues, r.VisibleLight)
+		set(uvLightValues, r.UvLight)
+		for j := range r.Temperatures {
+			set(temperatureValues+j, &r.Temperatures[j])
 		}
 	}
 	return TimedValues{times: times, values: values}
@@ -147,6 +214,9 @@ func (l *climateLogger) PushReports(reports []*api.ClimateReport) {
 		}
 		*l.currentReport.Temperature = lastReport.Temperatures[0]
 	}
+	if len(lastReport.Temperatures) > 1 {
+		l.currentReport.TemperatureAux = append([]float32(nil), lastReport.Temperatures[1:]...)
+	}
 	if lastReport.Humidity != nil {
 		if l.currentReport.Humidity == nil {
 			l.currentReport.Humidity = new(float32)
@@ -159,6 +229,9 @@ func (l *climateLogger) PushTarget(target *api.ClimateTarget) {
 	l.mx.Lock()
 	defer l.mx.Unlock()
 
+	if proto.Equal(l.currentReport.Current, target.Current) == false {
+		l.targetStart = time.Now()
+	}
 	l.currentReport.Current = target.Current
 	l.currentReport.CurrentEnd = target.CurrentEnd
 	if target.Next != nil && target.NextTime != nil {
@@ -173,6 +246,30 @@ func (l *climateLogger) PushTarget(target *api.ClimateTarget) {
 	}
 }
 
+// A ClimateWindow is a time window of the climate time series kept
+// in memory for each zone.
+type ClimateWindow struct {
+	// Names identify the window in time series requests.
+	Names    []string      `yaml:"names"`
+	Duration time.Duration `yaml:"duration"`
+	// Unit is the time unit of the series, one of 1s, 1m, 1h or 24h.
+	Unit    time.Duration `yaml:"unit"`
+	Samples int           `yaml:"samples"`
+}
+
+// climateWindow returns the duration of a time series window, with
+// the same fallback than GetClimateTimeSeries.
+func climateWindow(window string) time.Duration {
+	for _, w := range ClimateWindows {
+		for _, name := range w.Names {
+			if name == window {
+				return w.Duration
+			}
+		}
+	}
+	return ClimateWindows[0].Duration
+}
+
 func (l *climateLogger) fromWindow(window string) api.ClimateTimeSeries {
 	sampler, ok := l.samplersByWindow[window]
 	if ok == false {
@@ -185,7 +282,9 @@ func (l *climateLogger) GetClimateTimeSeries(window string) api.ClimateTimeSerie
 	l.mx.RLock()
 	defer l.mx.RUnlock()
 	// already a data copy, so it is safe
-	return l.fromWindow(window)
+	res := l.fromWindow(window)
+	res.TemperatureSensors = l.currentReport.TemperatureSensors
+	return res
 }
 
 func (l *climateLogger) GetClimateReport() *api.ZoneClimateReport {
@@ -195,9 +294,44 @@ func (l *climateLogger) GetClimateReport() *api.ZoneClimateReport {
 	if l.currentReport.NextTime != nil {
 		res.NextTime = l.currentReport.NextTime
 	}
+	res.TrackingErrors = l.trackingErrors.compute()
+	if l.lastReportTime.IsZero() == false {
+		res.LastReportTime = new(time.Time)
+		*res.LastReportTime = l.lastReportTime
+	}
 	return res
 }
 
+func (l *climateLogger) CheckStale(now time.Time, period time.Duration) []*api.AlarmUpdate {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	// a zone is given period to send its first report.
+	last := l.lastReportTime
+	if l.registered.After(last) {
+		last = l.registered
+	}
+	stale := period > 0 && now.Sub(last) > period
+	if stale == l.stale {
+		return nil
+	}
+	l.stale = stale
+	l.currentReport.Stale = stale
+
+	update := &api.AlarmUpdate{
+		Identification: ClimateStaleAlarmIdentification,
+		Level:          api.AlarmLevel_WARNING,
+		Status:         api.AlarmStatus_OFF,
+		Time:           timestamppb.New(now),
+	}
+	if stale == true {
+		update.Status = api.AlarmStatus_ON
+		update.Description = fmt.Sprintf("no climate report received for %s",
+			formatAlarmDuration(now.Sub(last)))
+	}
+	return []*api.AlarmUpdate{update}
+}
+
 func (l *climateLogger) Host() string {
 	return l.host
 }
@@ -209,3 +343,104 @@ func (l *climateLogger) ZoneName() string {
 func (l *climateLogger) ZoneIdentifier() string {
 	return ZoneIdentifier(l.host, l.name)
 }
+
+func (l *climateLogger) CheckBounds(reports []*api.ClimateReport, policy BoundCheckPolicy) []*api.AlarmUpdate {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	var res []*api.AlarmUpdate
+	for _, r := range reports {
+		t := r.Time.AsTime()
+		if l.temperatureChecker != nil && len(r.Temperatures) > 0 {
+			u := l.temperatureChecker.check(t, r.Temperatures[0],
+				policy.TemperatureHysteresis, policy.MinimumDuration)
+			if u != nil {
+				res = append(res, u)
+			}
+		}
+		if l.humidityChecker != nil && r.Humidity != nil {
+			u := l.humidityChecker.check(t, *r.Humidity,
+				policy.HumidityHysteresis, policy.MinimumDuration)
+			if u != nil {
+				res = append(res, u)
+			}
+		}
+		for i, checker := range l.auxCheckers {
+			if checker == nil || len(r.Temperatures) <= i+1 {
+				continue
+			}
+			u := checker.check(t, r.Temperatures[i+1],
+				policy.TemperatureHysteresis, policy.MinimumDuration)
+			if u != nil {
+				res = append(res, u)
+			}
+		}
+	}
+	return res
+}
+
+func (l *climateLogger) TrackTarget(reports []*api.ClimateReport, policy *TargetTrackingPolicy) []*api.AlarmUpdate {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	if l.currentReport.Current == nil {
+		return nil
+	}
+	if policy != nil {
+		l.buildTrackingCheckers(*policy)
+	}
+
+	var res []*api.AlarmUpdate
+	for _, r := range reports {
+		t := r.Time.AsTime()
+		targetTemperature, targetHumidity := interpolateTarget(t, l.targetStart,
+			l.currentReport.Current, l.currentReport.CurrentEnd, l.currentReport.NextTime)
+
+		var temperature, humidity *float64
+		if targetTemperature != nil && len(r.Temperatures) > 0 {
+			temperature = new(float64)
+			*temperature = float64(r.Temperatures[0]) - *targetTemperature
+		}
+		if targetHumidity != nil && r.Humidity != nil {
+			humidity = new(float64)
+			*humidity = float64(*r.Humidity) - *targetHumidity
+		}
+		l.trackingErrors.add(t, temperature, humidity)
+
+		if policy == nil {
+			continue
+		}
+		if temperature != nil && l.temperatureTrackingChecker != nil {
+			u := l.temperatureTrackingChecker.check(t, float32(*temperature), 0, policy.MinimumDuration)
+			if u != nil {
+				res = append(res, u)
+			}
+		}
+		if humidity != nil && l.humidityTrackingChecker != nil {
+			u := l.humidityTrackingChecker.check(t, float32(*humidity), 0, policy.MinimumDuration)
+			if u != nil {
+				res = append(res, u)
+			}
+		}
+	}
+	return res
+}
+
+func symmetricBounds(threshold float32) api.Bounds {
+	if threshold <= 0 {
+		return api.Bounds{}
+	}
+	minimum, maximum := -threshold, threshold
+	return api.Bounds{Minimum: &minimum, Maximum: &maximum}
+}
+
+func (l *climateLogger) buildTrackingCheckers(policy TargetTrackingPolicy) {
+	if l.temperatureTrackingChecker == nil {
+		l.temperatureTrackingChecker = newBoundChecker("temperature.tracking-error",
+			"temperature deviation", "°C", symmetricBounds(policy.TemperatureThreshold))
+	}
+	if l.humidityTrackingChecker == nil {
+		l.humidityTrackingChecker = newBoundChecker("humidity.tracking-error",
+			"humidity deviation", "%", symmetricBounds(policy.HumidityThreshold))
+	}
+}
@@ -105,8 +105,190 @@ func (s *ClimateLoggerSuite) TestLogsTemperatureOnlyClimate(c *C) {
 	c.Check(*report.Temperature, Equals, float32(20.0))
 }
 
+func (s *ClimateLoggerSuite) TestLogsWindAndLight(c *C) {
+	start := time.Now().Round(0)
+	report := func(i int) *api.ClimateReport {
+		return &api.ClimateReport{
+			Time:         timestamppb.New(start.Add(time.Duration(i) * time.Second)),
+			Humidity:     newInitialized[float32](55.0),
+			Temperatures: []float32{21.0, 20.0},
+		}
+	}
+	// older clients do not send wind and light values.
+	s.l.PushReports([]*api.ClimateReport{report(0), report(1)})
+	series := s.l.GetClimateTimeSeries("")
+	c.Check(series.Wind, HasLen, 0)
+	c.Check(series.VisibleLight, HasLen, 0)
+	c.Check(series.UvLight, HasLen, 0)
+
+	last := report(2)
+	last.Wind = newInitialized[float32](30.0)
+	last.VisibleLight = newInitialized[float32](100.0)
+	s.l.PushReports([]*api.ClimateReport{last})
+	batch := []*api.ClimateReport{report(3), report(4)}
+	for _, r := range batch {
+		r.Wind = newInitialized[float32](40.0)
+		r.UvLight = newInitialized[float32](5.0)
+	}
+	s.l.PushReports(batch)
+
+	series = s.l.GetClimateTimeSeries("")
+	c.Check(series.Humidity, HasLen, 5)
+	c.Check(series.Temperature, HasLen, 5)
+	c.Check(series.TemperatureAux, HasLen, 1)
+	c.Assert(series.Wind, HasLen, 5)
+	c.Check(series.Wind[0].Y, Equals, float32(30.0))
+	c.Check(series.Wind[4].Y, Equals, float32(40.0))
+	// series missing their last values are not reported
+	c.Check(series.VisibleLight, HasLen, 0)
+	c.Check(series.UvLight, HasLen, 5)
+
+	last = report(5)
+	last.VisibleLight = newInitialized[float32](50.0)
+	s.l.PushReports([]*api.ClimateReport{last})
+	series = s.l.GetClimateTimeSeries("")
+	c.Assert(series.VisibleLight, HasLen, 6)
+	c.Check(series.VisibleLight[4].Y, Equals, float32(100.0))
+	c.Check(series.VisibleLight[5].Y, Equals, float32(50.0))
+	c.Check(series.UvLight, HasLen, 0)
+}
+
 func newWithValue[T any](v T) *T {
 	res := new(T)
 	*res = v
 	return res
 }
+
+func (s *ClimateLoggerSuite) TestDetectsStaleReports(c *C) {
+	start := time.Now().Round(0)
+	period := 5 * time.Minute
+
+	c.Check(s.l.CheckStale(start, period), HasLen, 0)
+
+	alarms := s.l.CheckStale(start.Add(6*time.Minute), period)
+	c.Assert(alarms, HasLen, 1)
+	c.Check(alarms[0].Identification, Equals, ClimateStaleAlarmIdentification)
+	c.Check(alarms[0].Status, Equals, api.AlarmStatus_ON)
+	c.Check(alarms[0].Description, Equals, "no climate report received for 6 min")
+	c.Check(s.l.GetClimateReport().Stale, Equals, true)
+	// only transitions are reported
+	c.Check(s.l.CheckStale(start.Add(7*time.Minute), period), HasLen, 0)
+
+	reportTime := start.Add(8 * time.Minute)
+	s.l.PushReports([]*api.ClimateReport{
+		{
+			Time:         timestamppb.New(reportTime),
+			Temperatures: []float32{21.0},
+		},
+	})
+	alarms = s.l.CheckStale(start.Add(8*time.Minute), period)
+	c.Assert(alarms, HasLen, 1)
+	c.Check(alarms[0].Status, Equals, api.AlarmStatus_OFF)
+	report := s.l.GetClimateReport()
+	c.Check(report.Stale, Equals, false)
+	c.Assert(report.LastReportTime, NotNil)
+	c.Check(report.LastReportTime.Equal(reportTime), Equals, true)
+
+	c.Check(s.l.CheckStale(start.Add(time.Hour), 0), HasLen, 0)
+}
+
+func (s *ClimateLoggerSuite) TestChecksBounds(c *C) {
+	l := NewClimateLogger(&api.ClimateDeclaration{
+		Host:           "foo",
+		Name:           "bar",
+		MaxTemperature: newInitialized[float32](30.0),
+		MinHumidity:    newInitialized[float32](40.0),
+	})
+	policy := BoundCheckPolicy{
+		TemperatureHysteresis: 0.5,
+		HumidityHysteresis:    2.0,
+		MinimumDuration:       5 * time.Minute,
+	}
+	start := time.Now().Round(0)
+
+	testdata := []struct {
+		Minutes     int
+		Temperature float32
+		Humidity    float32
+		Expected    []*api.AlarmUpdate
+	}{
+		{0, 29.0, 50.0, nil},
+		{1, 31.0, 39.0, nil},
+		// a short spike does not raise the alarm
+		{2, 29.8, 50.0, nil},
+		{3, 31.0, 50.0, nil},
+		{7, 31.2, 50.0, nil},
+		{8, 31.3, 50.0, []*api.AlarmUpdate{{
+			Identification: "temperature.out-of-bound",
+			Level:          api.AlarmLevel_WARNING,
+			Status:         api.AlarmStatus_ON,
+			Description:    "temperature 31.3°C is above its maximum of 30.0°C",
+		}}},
+		// within hysteresis, the alarm stays on
+		{9, 29.8, 50.0, nil},
+		{10, 29.4, 39.0, []*api.AlarmUpdate{{
+			Identification: "temperature.out-of-bound",
+			Level:          api.AlarmLevel_WARNING,
+			Status:         api.AlarmStatus_OFF,
+		}}},
+		{15, 29.4, 38.0, []*api.AlarmUpdate{{
+			Identification: "humidity.out-of-bound",
+			Level:          api.AlarmLevel_WARNING,
+			Status:         api.AlarmStatus_ON,
+			Description:    "humidity 38.0% is below its minimum of 40.0%",
+		}}},
+	}
+
+	for _, d := range testdata {
+		t := start.Add(time.Duration(d.Minutes) * time.Minute)
+		alarms := l.CheckBounds([]*api.ClimateReport{{
+			Time:         timestamppb.New(t),
+			Temperatures: []float32{d.Temperature},
+			Humidity:     newInitialized(d.Humidity),
+		}}, policy)
+		comment := Commentf("at %d minutes", d.Minutes)
+		c.Assert(alarms, HasLen, len(d.Expected), comment)
+		for i, a := range alarms {
+			c.Check(a.Time.AsTime().Equal(t), Equals, true, comment)
+			a.Time = nil
+			c.Check(a, DeepEquals, d.Expected[i], comment)
+		}
+	}
+}
+
+func (s *ClimateLoggerSuite) TestDescribesTemperatureSensors(c *C) {
+	l := NewClimateLogger(&api.ClimateDeclaration{
+		Host:           "foo",
+		Name:           "bar",
+		MaxTemperature: newInitialized[float32](30.0),
+		TemperatureSensors: []*api.TemperatureSensor{
+			{Name: "air", Minimum: newInitialized[float32](18.0), Maximum: newInitialized[float32](35.0)},
+			{Name: "nest", Maximum: newInitialized[float32](28.0)},
+			{Name: "arena", Unit: "°F"},
+		},
+	})
+	sensors := l.GetClimateReport().TemperatureSensors
+	c.Assert(sensors, HasLen, 3)
+	c.Check(sensors[0].Name, Equals, "air")
+	c.Check(*sensors[0].Bounds.Minimum, Equals, float32(18.0))
+	// declared bounds take precedence
+	c.Check(*sensors[0].Bounds.Maximum, Equals, float32(30.0))
+	c.Check(sensors[1].Unit, Equals, api.DefaultTemperatureUnit)
+	c.Check(sensors[2].Unit, Equals, "°F")
+	c.Check(*l.GetClimateReport().TemperatureBounds.Minimum, Equals, float32(18.0))
+
+	start := time.Now().Round(0)
+	reports := []*api.ClimateReport{
+		{
+			Time:         timestamppb.New(start),
+			Temperatures: []float32{25.0, 29.0, 70.0},
+		},
+	}
+	l.PushReports(reports)
+	c.Check(l.GetClimateTimeSeries("10m").TemperatureSensors, DeepEquals, sensors)
+
+	alarms := l.CheckBounds(reports, BoundCheckPolicy{})
+	c.Assert(alarms, HasLen, 1)
+	c.Check(alarms[0].Identification, Equals, "temperature.nest.out-of-bound")
+	c.Check(alarms[0].Description, Equals, "nest 29.0°C is above its maximum of 28.0°C")
+}
@@ -0,0 +1,424 @@
+package olympus
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"github.com/formicidae-tracker/olympus/pkg/tm"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// A ClimateStore persists raw ClimateReport for each zone on disk,
+// in order to keep climate data across restarts.
+type ClimateStore interface {
+	// Append saves a list of reports for a zone.
+	Append(zone string, reports []*api.ClimateReport) error
+	// Query returns all reports of a zone within [from,to], sorted by
+	// time. A zero from or to means no lower or upper bound.
+	Query(zone string, from, to time.Time) ([]*api.ClimateReport, error)
+	// ForEach calls fn for each report of a zone within [from,to],
+	// in chronological order, without loading all of them in
+	// memory. It stops at the first error returned by fn.
+	ForEach(zone string, from, to time.Time, fn func(*api.ClimateReport) error) error
+	// Zones lists all zones with persisted data.
+	Zones() ([]string, error)
+	// Prune removes all data older than the retention period.
+	Prune() error
+}
+
+// climateStore stores data in one directory per zone, and one
+// segment file per UTC day. Each segment is a list of frames
+// containing a single protobuf encoded ClimateReport, prefixed by
+// its length and CRC32 checksum. Segments are only appended to, and
+// any partially written frame (i.e. after a crash) is truncated the
+// first time a segment is re-opened for writing.
+type climateStore struct {
+	mx sync.Mutex
+
+	path      string
+	retention time.Duration
+	checked   map[string]bool
+	lastPrune time.Time
+
+	log *logrus.Entry
+}
+
+const (
+	climateSegmentLayout = "2006-01-02"
+	climateSegmentExt    = ".climate"
+	climateFrameHeader   = 8
+	climateFrameMaxSize  = 1 << 16
+)
+
+var segmentRx = regexp.MustCompile(`\A[0-9]{4}-[0-9]{2}-[0-9]{2}\.climate\z`)
+
+var ErrCorruptedClimateFrame = errors.New("corrupted climate frame")
+
+// NewClimateStore creates a ClimateStore in the data directory. A
+// non-positive retention means that data is kept forever.
+func NewClimateStore(name string, retention time.Duration) ClimateStore {
+	res := &climateStore{
+		path:      filepath.Join(_datapath, name),
+		retention: retention,
+		checked:   make(map[string]bool),
+		log:       tm.NewLogger("climate-store"),
+	}
+	return res
+}
+
+func (s *climateStore) zonePath(zone string) string {
+	return filepath.Join(s.path, url.PathEscape(zone))
+}
+
+func segmentDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+func (s *climateStore) segmentPath(zone string, day time.Time) string {
+	return filepath.Join(s.zonePath(zone), day.Format(climateSegmentLayout)+climateSegmentExt)
+}
+
+func (s *climateStore) Append(zone string, reports []*api.ClimateReport) error {
+	if len(reports) == 0 {
+		return nil
+	}
+
+	bySegment := make(map[time.Time][]byte)
+	days := make([]time.Time, 0, 1)
+	for _, r := range reports {
+		if r.Time == nil {
+			continue
+		}
+		day := segmentDay(r.Time.AsTime())
+		data, ok := bySegment[day]
+		if ok == false {
+			days = append(days, day)
+		}
+		var err error
+		data, err = appendClimateFrame(data, r)
+		if err != nil {
+			return err
+		}
+		bySegment[day] = data
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if err := os.MkdirAll(s.zonePath(zone), 0755); err != nil {
+		return err
+	}
+
+	for _, day := range days {
+		if err := s.write(s.segmentPath(zone, day), bySegment[day]); err != nil {
+			return err
+		}
+	}
+
+	s.mayPrune()
+
+	return nil
+}
+
+func appendClimateFrame(data []byte, r *api.ClimateReport) ([]byte, error) {
+	payload, err := proto.Marshal(r)
+	if err != nil {
+		return data, err
+	}
+	header := make([]byte, climateFrameHeader)
+	binary.LittleEndian.PutUint32(header[0:], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(header[4:], crc32.ChecksumIEEE(payload))
+	data = append(data, header...)
+	return append(data, payload...), nil
+}
+
+func (s *climateStore) write(filename string, data []byte) error {
+	if s.checked[filename] == false {
+		if err := repairClimateSegment(filename); err != nil {
+			return fmt.Errorf("repairing %s: %w", filename, err)
+		}
+		s.checked[filename] = true
+	}
+
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	// data is written in a single call, so a crash could only
+	// leave a partial frame at the end of the file.
+	_, err = file.Write(data)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// repairClimateSegment truncates a segment after its last valid
+// frame.
+func repairClimateSegment(filename string) error {
+	file, err := os.OpenFile(filename, os.O_RDWR, 0644)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var valid int64
+	reader := bufio.NewReader(file)
+	for {
+		size, err := readClimateFrame(reader, nil)
+		if err != nil {
+			break
+		}
+		valid += int64(size)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == valid {
+		return nil
+	}
+	return file.Truncate(valid)
+}
+
+// readClimateFrame reads the next frame of a segment. It returns
+// the number of bytes read, and decodes the report if r is not nil.
+func readClimateFrame(reader io.Reader, r *api.ClimateReport) (int, error) {
+	header := make([]byte, climateFrameHeader)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return 0, ErrCorruptedClimateFrame
+		}
+		return 0, err
+	}
+	size := binary.LittleEndian.Uint32(header[0:])
+	checksum := binary.LittleEndian.Uint32(header[4:])
+	if size > climateFrameMaxSize {
+		return 0, ErrCorruptedClimateFrame
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return 0, ErrCorruptedClimateFrame
+	}
+	if crc32.ChecksumIEEE(payload) != checksum {
+		return 0, ErrCorruptedClimateFrame
+	}
+	if r != nil {
+		if err := proto.Unmarshal(payload, r); err != nil {
+			return 0, ErrCorruptedClimateFrame
+		}
+	}
+	return climateFrameHeader + int(size), nil
+}
+
+func (s *climateStore) readSegment(filename string, from, to time.Time, res []*api.ClimateReport) ([]*api.ClimateReport, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return res, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		r := &api.ClimateReport{}
+		_, err := readClimateFrame(reader, r)
+		if err == io.EOF || err == ErrCorruptedClimateFrame {
+			// a corrupted frame can only be the last one written
+			// before a crash, there is no data to read after it.
+			return res, nil
+		}
+		if err != nil {
+			return res, err
+		}
+		t := r.Time.AsTime()
+		if (from.IsZero() == false && t.Before(from)) ||
+			(to.IsZero() == false && t.After(to)) {
+			continue
+		}
+		res = append(res, r)
+	}
+}
+
+// segments lists the days of all segments of a zone, in
+// chronological order.
+func (s *climateStore) segments(zone string) ([]time.Time, error) {
+	entries, err := os.ReadDir(s.zonePath(zone))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := make([]time.Time, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() == true || segmentRx.MatchString(e.Name()) == false {
+			continue
+		}
+		day, err := time.Parse(climateSegmentLayout, e.Name()[:len(climateSegmentLayout)])
+		if err != nil {
+			continue
+		}
+		res = append(res, day)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Before(res[j]) })
+	return res, nil
+}
+
+func (s *climateStore) Query(zone string, from, to time.Time) ([]*api.ClimateReport, error) {
+	var res []*api.ClimateReport
+	err := s.ForEach(zone, from, to, func(r *api.ClimateReport) error {
+		res = append(res, r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *climateStore) ForEach(zone string, from, to time.Time, fn func(*api.ClimateReport) error) error {
+	s.mx.Lock()
+	days, err := s.segments(zone)
+	s.mx.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, day := range days {
+		if from.IsZero() == false && day.Add(24*time.Hour).Before(from) {
+			continue
+		}
+		if to.IsZero() == false && day.After(to) {
+			continue
+		}
+		// we only lock while reading a segment, so fn can take
+		// its time without blocking any Append.
+		s.mx.Lock()
+		reports, err := s.readSegment(s.segmentPath(zone, day), from, to, nil)
+		s.mx.Unlock()
+		if errors.Is(err, os.ErrNotExist) {
+			// pruned in the meantime
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		// backlogs may have been appended after more recent data.
+		sort.SliceStable(reports, func(i, j int) bool {
+			return reports[i].Time.AsTime().Before(reports[j].Time.AsTime())
+		})
+
+		for _, r := range reports {
+			if err := fn(r); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *climateStore) Zones() ([]string, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	entries, err := os.ReadDir(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() == false {
+			continue
+		}
+		zone, err := url.PathUnescape(e.Name())
+		if err != nil {
+			continue
+		}
+		res = append(res, zone)
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+func (s *climateStore) mayPrune() {
+	now := time.Now()
+	if now.Sub(s.lastPrune) < time.Hour {
+		return
+	}
+	if err := s.prune(now); err != nil {
+		s.log.WithError(err).Error("could not prune old climate data")
+	}
+}
+
+func (s *climateStore) Prune() error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.prune(time.Now())
+}
+
+func (s *climateStore) prune(now time.Time) error {
+	s.lastPrune = now
+	if s.retention <= 0 {
+		return nil
+	}
+	zones, err := os.ReadDir(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	limit := now.Add(-s.retention)
+
+	var errs multipleError
+	for _, z := range zones {
+		if z.IsDir() == false {
+			continue
+		}
+		zone, err := url.PathUnescape(z.Name())
+		if err != nil {
+			continue
+		}
+		days, err := s.segments(zone)
+		if err != nil {
+			errs = appendError(errs, err)
+			continue
+		}
+		for _, day := range days {
+			if day.Add(24 * time.Hour).After(limit) {
+				break
+			}
+			filename := s.segmentPath(zone, day)
+			errs = appendError(errs, os.Remove(filename))
+			delete(s.checked, filename)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
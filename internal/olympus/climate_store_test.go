@@ -0,0 +1,121 @@
+package olympus
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	. "gopkg.in/check.v1"
+)
+
+type ClimateStoreSuite struct {
+	datapath string
+	dir      string
+	start    time.Time
+	s        ClimateStore
+}
+
+var _ = Suite(&ClimateStoreSuite{})
+
+func (s *ClimateStoreSuite) SetUpSuite(c *C) {
+	s.datapath = _datapath
+}
+
+func (s *ClimateStoreSuite) TearDownSuite(c *C) {
+	_datapath = s.datapath
+}
+
+func (s *ClimateStoreSuite) SetUpTest(c *C) {
+	s.dir = c.MkDir()
+	_datapath = s.dir
+	s.start = time.Now().Round(0).Add(-time.Hour)
+	s.s = NewClimateStore("climate-reports", 0)
+}
+
+func (s *ClimateStoreSuite) buildReports(start time.Time, n int, period time.Duration) []*api.ClimateReport {
+	res := make([]*api.ClimateReport, n)
+	for i := range res {
+		res[i] = &api.ClimateReport{
+			Time:         timestamppb.New(start.Add(time.Duration(i) * period)),
+			Humidity:     newInitialized[float32](50.0 + float32(i)),
+			Temperatures: []float32{20.0, 21.0},
+		}
+	}
+	return res
+}
+
+func (s *ClimateStoreSuite) TestIsEmptyUponCreation(c *C) {
+	reports, err := s.s.Query("foo.box", time.Time{}, time.Time{})
+	c.Check(err, IsNil)
+	c.Check(reports, HasLen, 0)
+}
+
+func (s *ClimateStoreSuite) TestReportPersistence(c *C) {
+	reports := s.buildReports(s.start, 10, time.Second)
+	c.Assert(s.s.Append("foo.box", reports[5:]), IsNil)
+	// out of order append, like a backlog would do
+	c.Assert(s.s.Append("foo.box", reports[:5]), IsNil)
+	c.Assert(s.s.Append("bar.box", reports[:3]), IsNil)
+
+	restored := NewClimateStore("climate-reports", 0)
+	res, err := restored.Query("foo.box", time.Time{}, time.Time{})
+	c.Assert(err, IsNil)
+	c.Assert(res, HasLen, 10)
+	for i, r := range res {
+		c.Check(r.Time.AsTime().Equal(reports[i].Time.AsTime()), Equals, true, Commentf("report %d", i))
+		c.Check(*r.Humidity, Equals, *reports[i].Humidity, Commentf("report %d", i))
+		c.Check(r.Temperatures, DeepEquals, reports[i].Temperatures, Commentf("report %d", i))
+	}
+
+	res, err = restored.Query("foo.box",
+		reports[2].Time.AsTime(),
+		reports[6].Time.AsTime())
+	c.Assert(err, IsNil)
+	c.Check(res, HasLen, 5)
+
+	res, err = restored.Query("bar.box", time.Time{}, time.Time{})
+	c.Assert(err, IsNil)
+	c.Check(res, HasLen, 3)
+}
+
+func (s *ClimateStoreSuite) TestSurvivesPartialWrites(c *C) {
+	reports := s.buildReports(s.start, 4, time.Second)
+	c.Assert(s.s.Append("foo.box", reports[:2]), IsNil)
+
+	filename := s.s.(*climateStore).segmentPath("foo.box", segmentDay(s.start))
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND, 0644)
+	c.Assert(err, IsNil)
+	// a partial frame header, as left by a crash.
+	_, err = file.Write([]byte{42, 0, 0})
+	c.Assert(err, IsNil)
+	c.Assert(file.Close(), IsNil)
+
+	restored := NewClimateStore("climate-reports", 0)
+	res, err := restored.Query("foo.box", time.Time{}, time.Time{})
+	c.Check(err, IsNil)
+	c.Check(res, HasLen, 2)
+
+	c.Assert(restored.Append("foo.box", reports[2:]), IsNil)
+	res, err = restored.Query("foo.box", time.Time{}, time.Time{})
+	c.Check(err, IsNil)
+	c.Check(res, HasLen, 4)
+}
+
+func (s *ClimateStoreSuite) TestPrunesOldData(c *C) {
+	s.s = NewClimateStore("climate-reports", 48*time.Hour)
+	old := s.start.Add(-10 * 24 * time.Hour)
+	c.Assert(s.s.Append("foo.box", s.buildReports(old, 3, time.Second)), IsNil)
+	c.Assert(s.s.Append("foo.box", s.buildReports(s.start, 3, time.Second)), IsNil)
+
+	c.Assert(s.s.Prune(), IsNil)
+
+	res, err := s.s.Query("foo.box", time.Time{}, time.Time{})
+	c.Check(err, IsNil)
+	c.Check(res, HasLen, 3)
+
+	entries, err := os.ReadDir(filepath.Join(s.dir, "climate-reports", "foo.box"))
+	c.Check(err, IsNil)
+	c.Check(entries, HasLen, 1)
+}
@@ -0,0 +1,23 @@
+package olympus
+
+import "time"
+
+// A Clock abstracts the passing of time, so time dependent stages of
+// the notification pipeline can be tested.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SystemClock is the Clock of the operating system.
+var SystemClock Clock = systemClock{}
@@ -0,0 +1,446 @@
+package olympus
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/mail"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"gopkg.in/yaml.v3"
+)
+
+// A Config is the runtime configuration of the olympus service. It is
+// read from a YAML file, see misc/olympus.yml. Fields left out of the
+// file keep their default value, and the ones with an env tag are
+// overridden by their environment variable if it is set.
+type Config struct {
+	// DataHome is the directory where olympus stores its data.
+	DataHome string `yaml:"data_home" env:"OLYMPUS_DATA_HOME"`
+	// Secret is the base64 encoded secret of the CSRF tokens and
+	// session cookies.
+	Secret string `yaml:"secret" env:"OLYMPUS_SECRET"`
+	// AdminToken grants access to the administration endpoints.
+	AdminToken string `yaml:"admin_token" env:"OLYMPUS_ADMIN_TOKEN"`
+	// OtelEndpoint is the Open Telemetry exporter endpoint.
+	OtelEndpoint string `yaml:"otel_endpoint" env:"OLYMPUS_OTEL_ENDPOINT"`
+
+	HTTP          HTTPConfig         `yaml:"http"`
+	RPC           RPCConfig          `yaml:"rpc"`
+	Retention     RetentionConfig    `yaml:"retention"`
+	Notifications NotificationConfig `yaml:"notifications"`
+	Climate       ClimateConfig      `yaml:"climate"`
+	Tracking      TrackingConfig     `yaml:"tracking"`
+}
+
+// HTTPConfig configures the HTTP listener of the web application.
+type HTTPConfig struct {
+	Address         string        `yaml:"address" env:"OLYMPUS_HTTP_LISTEN"`
+	AllowCORS       []string      `yaml:"allow_cors" env:"OLYMPUS_ALLOW_CORS"`
+	SessionDuration time.Duration `yaml:"session_duration" env:"OLYMPUS_SESSION_DURATION"`
+}
+
+// RPCConfig configures the gRPC listener of the clients. TLS is
+// enabled when TLSCert and TLSKey are set, and client certificates
+// are required when TLSClientCA is set.
+type RPCConfig struct {
+	Port            int    `yaml:"port" env:"OLYMPUS_RPC_LISTEN"`
+	BacklogPageSize int    `yaml:"backlog_page_size" env:"OLYMPUS_BACKLOG_PAGE_SIZE"`
+	TLSCert         string `yaml:"tls_cert" env:"OLYMPUS_TLS_CERT"`
+	TLSKey          string `yaml:"tls_key" env:"OLYMPUS_TLS_KEY"`
+	TLSClientCA     string `yaml:"tls_client_ca" env:"OLYMPUS_TLS_CLIENT_CA"`
+}
+
+// RetentionConfig are the durations data is kept on disk. A
+// non-positive value keeps it forever.
+type RetentionConfig struct {
+	Climate time.Duration `yaml:"climate" env:"OLYMPUS_CLIMATE_RETENTION"`
+	Alarms  time.Duration `yaml:"alarms" env:"OLYMPUS_ALARM_RETENTION"`
+}
+
+// NotificationConfig configures the timings of the alarm
+// notifications. An empty Escalation disables escalation.
+type NotificationConfig struct {
+	MinimumOn   time.Duration    `yaml:"minimum_on" env:"OLYMPUS_ALARM_MINIMUM_ON"`
+	BatchPeriod time.Duration    `yaml:"batch_period" env:"OLYMPUS_NOTIFICATION_BATCH_PERIOD"`
+	Escalation  []EscalationStep `yaml:"escalation" env:"OLYMPUS_ESCALATION"`
+	WebPush     WebPushConfig    `yaml:"web_push"`
+	Email       EmailConfig      `yaml:"email"`
+}
+
+// WebPushConfig are the VAPID credentials of the web push
+// notifications.
+type WebPushConfig struct {
+	PublicKey  string `yaml:"public_key" env:"OLYMPUS_VAPID_PUBLIC"`
+	PrivateKey string `yaml:"private_key" env:"OLYMPUS_VAPID_PRIVATE"`
+	Subscriber string `yaml:"subscriber" env:"OLYMPUS_PUSH_SUBSCRIBER"`
+}
+
+// Enabled returns true if all credentials are set.
+func (c WebPushConfig) Enabled() bool {
+	return len(c.PublicKey) > 0 && len(c.PrivateKey) > 0 && len(c.Subscriber) > 0
+}
+
+// EmailConfig configures the SMTP server of the email
+// notifications. They are enabled when Host and From are set, and
+// authenticate when User is set. PublicURL makes their links
+// absolute.
+type EmailConfig struct {
+	Host      string `yaml:"smtp_host" env:"OLYMPUS_SMTP_HOST"`
+	Port      int    `yaml:"smtp_port" env:"OLYMPUS_SMTP_PORT"`
+	User      string `yaml:"smtp_user" env:"OLYMPUS_SMTP_USER"`
+	Password  string `yaml:"smtp_password" env:"OLYMPUS_SMTP_PASSWORD"`
+	From      string `yaml:"from" env:"OLYMPUS_SMTP_FROM"`
+	PublicURL string `yaml:"public_url" env:"OLYMPUS_PUBLIC_URL"`
+}
+
+// Enabled returns true if the SMTP host and sender are set.
+func (c EmailConfig) Enabled() bool {
+	return len(c.Host) > 0 && len(c.From) > 0
+}
+
+// ClimateConfig configures the climate time series and alarms. A
+// non-positive StaleAfter disables the stale data alarm.
+type ClimateConfig struct {
+	StaleAfter     time.Duration        `yaml:"stale_after" env:"OLYMPUS_CLIMATE_STALE_AFTER"`
+	Windows        []ClimateWindow      `yaml:"windows"`
+	BoundCheck     BoundCheckConfig     `yaml:"bound_check"`
+	TargetTracking TargetTrackingConfig `yaml:"target_tracking"`
+}
+
+// BoundCheckConfig configures the ServerBoundCheck policy.
+type BoundCheckConfig struct {
+	Enabled               bool          `yaml:"enabled" env:"OLYMPUS_BOUND_CHECK"`
+	TemperatureHysteresis float32       `yaml:"temperature_hysteresis" env:"OLYMPUS_TEMPERATURE_HYSTERESIS"`
+	HumidityHysteresis    float32       `yaml:"humidity_hysteresis" env:"OLYMPUS_HUMIDITY_HYSTERESIS"`
+	MinimumDuration       time.Duration `yaml:"minimum_duration" env:"OLYMPUS_BOUND_MINIMUM_DURATION"`
+}
+
+// TargetTrackingConfig configures the TargetTrackingAlarm policy.
+type TargetTrackingConfig struct {
+	Enabled              bool          `yaml:"enabled" env:"OLYMPUS_TRACKING_ERROR_ALARM"`
+	TemperatureDeviation float32       `yaml:"temperature_deviation" env:"OLYMPUS_TEMPERATURE_DEVIATION"`
+	HumidityDeviation    float32       `yaml:"humidity_deviation" env:"OLYMPUS_HUMIDITY_DEVIATION"`
+	MinimumDuration      time.Duration `yaml:"minimum_duration" env:"OLYMPUS_DEVIATION_MINIMUM_DURATION"`
+}
+
+// TrackingConfig configures the tracking disk alarms.
+type TrackingConfig struct {
+	DiskFullWarning   time.Duration `yaml:"disk_full_warning" env:"OLYMPUS_DISK_FULL_WARNING"`
+	DiskFullEmergency time.Duration `yaml:"disk_full_emergency" env:"OLYMPUS_DISK_FULL_EMERGENCY"`
+}
+
+// DefaultConfig returns the configuration used when no file is
+// given, made of the current package settings.
+func DefaultConfig() *Config {
+	boundCheck := DefaultBoundCheckPolicy
+	if ServerBoundCheck != nil {
+		boundCheck = *ServerBoundCheck
+	}
+	targetTracking := DefaultTargetTrackingPolicy
+	if TargetTrackingAlarm != nil {
+		targetTracking = *TargetTrackingAlarm
+	}
+	return &Config{
+		DataHome:   _datapath,
+		Secret:     OlympusSecret,
+		AdminToken: AdminToken,
+		HTTP: HTTPConfig{
+			Address:         ":3000",
+			SessionDuration: SessionDuration,
+		},
+		RPC: RPCConfig{
+			Port:            3001,
+			BacklogPageSize: BackLogPageSize,
+		},
+		Retention: RetentionConfig{
+			Climate: ClimateReportRetention,
+			Alarms:  AlarmRetention,
+		},
+		Notifications: NotificationConfig{
+			MinimumOn:   AlarmMinimumOn,
+			BatchPeriod: NotificationBatchPeriod,
+			Escalation:  append([]EscalationStep(nil), EscalationPolicy...),
+			WebPush:     WebPush,
+			Email:       Email,
+		},
+		Climate: ClimateConfig{
+			StaleAfter: ClimateStalePeriod,
+			Windows:    append([]ClimateWindow(nil), ClimateWindows...),
+			BoundCheck: BoundCheckConfig{
+				Enabled:               ServerBoundCheck != nil,
+				TemperatureHysteresis: boundCheck.TemperatureHysteresis,
+				HumidityHysteresis:    boundCheck.HumidityHysteresis,
+				MinimumDuration:       boundCheck.MinimumDuration,
+			},
+			TargetTracking: TargetTrackingConfig{
+				Enabled:              TargetTrackingAlarm != nil,
+				TemperatureDeviation: targetTracking.TemperatureThreshold,
+				HumidityDeviation:    targetTracking.HumidityThreshold,
+				MinimumDuration:      targetTracking.MinimumDuration,
+			},
+		},
+		Tracking: TrackingConfig{
+			DiskFullWarning:   DiskFullWarning,
+			DiskFullEmergency: DiskFullEmergency,
+		},
+	}
+}
+
+// LoadConfig reads a configuration file over the default one, and
+// applies the environment overrides. An empty path only applies the
+// overrides. The configuration is not validated.
+func LoadConfig(path string) (*Config, error) {
+	res := DefaultConfig()
+	if len(path) > 0 {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		if err := res.decode(f); err != nil {
+			return nil, fmt.Errorf("olympus: could not parse '%s': %w", path, err)
+		}
+	}
+	if err := res.overrideFromEnvironment(os.LookupEnv); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *Config) decode(r io.Reader) error {
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// overrideFromEnvironment sets the fields whose environment variable
+// is not empty.
+func (c *Config) overrideFromEnvironment(lookup func(string) (string, bool)) error {
+	if err := overrideFields(reflect.ValueOf(c).Elem(), lookup); err != nil {
+		return err
+	}
+	// OLYMPUS_DEBUG_WEBPUSH shortens the notification timings, to
+	// debug web push notifications. Its value is the batch period.
+	if debug, _ := lookup("OLYMPUS_DEBUG_WEBPUSH"); len(debug) > 0 {
+		c.Notifications.MinimumOn = time.Second
+		var err error
+		c.Notifications.BatchPeriod, err = time.ParseDuration(debug)
+		if err != nil {
+			c.Notifications.BatchPeriod = 5 * time.Second
+		}
+	}
+	return nil
+}
+
+func overrideFields(v reflect.Value, lookup func(string) (string, bool)) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if v.Field(i).Kind() == reflect.Struct {
+			if err := overrideFields(v.Field(i), lookup); err != nil {
+				return err
+			}
+			continue
+		}
+		key := t.Field(i).Tag.Get("env")
+		if len(key) == 0 {
+			continue
+		}
+		value, _ := lookup(key)
+		if len(value) == 0 {
+			continue
+		}
+		if err := setField(v.Field(i), value); err != nil {
+			return fmt.Errorf("olympus: invalid %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setField parses value like the command line options. Slices are
+// comma separated.
+func setField(field reflect.Value, value string) error {
+	if u, ok := field.Addr().Interface().(flags.Unmarshaler); ok == true {
+		return u.UnmarshalFlag(value)
+	}
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		field.SetInt(int64(d))
+		return err
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int:
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(i))
+	case reflect.Float32:
+		f, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		parts := strings.Split(value, ",")
+		slice := reflect.MakeSlice(field.Type(), len(parts), len(parts))
+		for i, p := range parts {
+			if err := setField(slice.Index(i), strings.TrimSpace(p)); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
+
+// A ConfigError lists the invalid settings of a configuration.
+type ConfigError []string
+
+func (e ConfigError) Error() string {
+	return "olympus: invalid configuration:\n  " + strings.Join(e, "\n  ")
+}
+
+// Validate returns a ConfigError if some settings are invalid.
+func (c *Config) Validate() error {
+	var res ConfigError
+	check := func(valid bool, format string, args ...any) {
+		if valid == false {
+			res = append(res, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(len(c.DataHome) > 0, "data_home: must be set")
+	if len(c.Secret) > 0 {
+		_, err := base64.URLEncoding.DecodeString(c.Secret)
+		check(err == nil, "secret: must be URL base64 encoded")
+	}
+
+	check(len(c.HTTP.Address) > 0, "http.address: must be set")
+	check(c.HTTP.SessionDuration > 0, "http.session_duration: must be positive")
+
+	check(c.RPC.Port > 0 && c.RPC.Port < 65536, "rpc.port: invalid port %d", c.RPC.Port)
+	check(c.RPC.BacklogPageSize > 0, "rpc.backlog_page_size: must be positive")
+	check((len(c.RPC.TLSCert) > 0) == (len(c.RPC.TLSKey) > 0), "rpc.tls_cert, rpc.tls_key: must be set together")
+	check(len(c.RPC.TLSClientCA) == 0 || len(c.RPC.TLSCert) > 0, "rpc.tls_client_ca: requires rpc.tls_cert and rpc.tls_key")
+
+	n := c.Notifications
+	check(n.MinimumOn >= 0, "notifications.minimum_on: must not be negative")
+	check(n.BatchPeriod > 0, "notifications.batch_period: must be positive")
+	for i, step := range n.Escalation {
+		check(step.After > 0, "notifications.escalation[%d]: delay must be positive", i)
+	}
+	push := n.WebPush
+	check(push.Enabled() == true || push == WebPushConfig{},
+		"notifications.web_push: public_key, private_key and subscriber must be set together")
+	email := n.Email
+	check((len(email.Host) > 0) == (len(email.From) > 0), "notifications.email: smtp_host and from must be set together")
+	check(email.Port > 0 && email.Port < 65536, "notifications.email.smtp_port: invalid port %d", email.Port)
+	if len(email.From) > 0 {
+		_, err := mail.ParseAddress(email.From)
+		check(err == nil, "notifications.email.from: invalid address '%s'", email.From)
+	}
+
+	check(len(c.Climate.Windows) > 0, "climate.windows: at least one window is required")
+	names := make(map[string]bool)
+	for i, w := range c.Climate.Windows {
+		check(len(w.Names) > 0, "climate.windows[%d].names: at least one name is required", i)
+		for _, name := range w.Names {
+			check(names[name] == false, "climate.windows[%d].names: duplicated name '%s'", i, name)
+			names[name] = true
+		}
+		check(w.Duration > 0, "climate.windows[%d].duration: must be positive", i)
+		_, ok := supportedUnits[w.Unit]
+		check(ok, "climate.windows[%d].unit: must be one of 1s, 1m, 1h or 24h", i)
+		check(w.Samples > 0, "climate.windows[%d].samples: must be positive", i)
+	}
+	b := c.Climate.BoundCheck
+	check(b.TemperatureHysteresis >= 0, "climate.bound_check.temperature_hysteresis: must not be negative")
+	check(b.HumidityHysteresis >= 0, "climate.bound_check.humidity_hysteresis: must not be negative")
+	check(b.MinimumDuration >= 0, "climate.bound_check.minimum_duration: must not be negative")
+	check(c.Climate.TargetTracking.MinimumDuration >= 0, "climate.target_tracking.minimum_duration: must not be negative")
+
+	t := c.Tracking
+	check(t.DiskFullEmergency > 0, "tracking.disk_full_emergency: must be positive")
+	check(t.DiskFullWarning >= t.DiskFullEmergency, "tracking.disk_full_warning: must not be shorter than tracking.disk_full_emergency")
+
+	if len(res) > 0 {
+		return res
+	}
+	return nil
+}
+
+// ConfigOptions selects the configuration of the commands accessing
+// the data of olympus run, so they use the same data_home.
+type ConfigOptions struct {
+	Config string `long:"config" short:"c" description:"YAML configuration file of olympus run" env:"OLYMPUS_CONFIG"`
+}
+
+// apply loads, validates and applies the selected configuration.
+func (o ConfigOptions) apply() error {
+	config, err := LoadConfig(o.Config)
+	if err != nil {
+		return err
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	config.Apply()
+	return nil
+}
+
+// Apply sets the package settings from a valid configuration.
+func (c *Config) Apply() {
+	_datapath = c.DataHome
+	OlympusSecret = c.Secret
+	AdminToken = c.AdminToken
+	SessionDuration = c.HTTP.SessionDuration
+	BackLogPageSize = c.RPC.BacklogPageSize
+	ClimateReportRetention = c.Retention.Climate
+	AlarmRetention = c.Retention.Alarms
+	AlarmMinimumOn = c.Notifications.MinimumOn
+	NotificationBatchPeriod = c.Notifications.BatchPeriod
+	EscalationPolicy = c.Notifications.Escalation
+	WebPush = c.Notifications.WebPush
+	Email = c.Notifications.Email
+	ClimateStalePeriod = c.Climate.StaleAfter
+	ClimateWindows = c.Climate.Windows
+	ServerBoundCheck = nil
+	if b := c.Climate.BoundCheck; b.Enabled == true {
+		ServerBoundCheck = &BoundCheckPolicy{
+			TemperatureHysteresis: b.TemperatureHysteresis,
+			HumidityHysteresis:    b.HumidityHysteresis,
+			MinimumDuration:       b.MinimumDuration,
+		}
+	}
+	TargetTrackingAlarm = nil
+	if t := c.Climate.TargetTracking; t.Enabled == true {
+		TargetTrackingAlarm = &TargetTrackingPolicy{
+			TemperatureThreshold: t.TemperatureDeviation,
+			HumidityThreshold:    t.HumidityDeviation,
+			MinimumDuration:      t.MinimumDuration,
+		}
+	}
+	DiskFullWarning = c.Tracking.DiskFullWarning
+	DiskFullEmergency = c.Tracking.DiskFullEmergency
+}
@@ -0,0 +1,182 @@
+package olympus
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type ConfigSuite struct{}
+
+var _ = Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestDefaultsMatchSettings(c *C) {
+	config := DefaultConfig()
+	c.Check(config.Validate(), IsNil)
+	c.Check(config.HTTP.SessionDuration, Equals, SessionDuration)
+	c.Check(config.RPC.BacklogPageSize, Equals, BackLogPageSize)
+	c.Check(config.Retention.Climate, Equals, ClimateReportRetention)
+	c.Check(config.Retention.Alarms, Equals, AlarmRetention)
+	c.Check(config.Notifications.MinimumOn, Equals, AlarmMinimumOn)
+	c.Check(config.Notifications.BatchPeriod, Equals, NotificationBatchPeriod)
+	c.Check(config.Notifications.Escalation, DeepEquals, EscalationPolicy)
+	c.Check(config.Climate.StaleAfter, Equals, ClimateStalePeriod)
+	c.Check(config.Climate.Windows, DeepEquals, ClimateWindows)
+	c.Check(config.Tracking.DiskFullWarning, Equals, DiskFullWarning)
+	c.Check(config.Tracking.DiskFullEmergency, Equals, DiskFullEmergency)
+}
+
+func (s *ConfigSuite) TestExampleHasDefaults(c *C) {
+	f, err := os.Open("../../misc/olympus.yml")
+	c.Assert(err, IsNil)
+	defer f.Close()
+	config := &Config{}
+	c.Assert(config.decode(f), IsNil)
+	expected := DefaultConfig()
+	expected.DataHome = "/tmp/fort/olympus"
+	expected.HTTP.AllowCORS = []string{}
+	expected.Notifications.Escalation = []EscalationStep{}
+	c.Check(config, DeepEquals, expected)
+}
+
+func (s *ConfigSuite) TestParsesFiles(c *C) {
+	config := DefaultConfig()
+	c.Assert(config.decode(strings.NewReader(`
+rpc:
+  port: 3003
+notifications:
+  escalation: []
+climate:
+  windows:
+    - names: [1m]
+      duration: 1m
+      unit: 1s
+      samples: 60
+`)), IsNil)
+	c.Check(config.RPC.Port, Equals, 3003)
+	c.Check(config.RPC.BacklogPageSize, Equals, 4000)
+	c.Check(config.Notifications.Escalation, HasLen, 0)
+	c.Check(config.Climate.Windows, DeepEquals, []ClimateWindow{
+		{Names: []string{"1m"}, Duration: time.Minute, Unit: time.Second, Samples: 60},
+	})
+
+	c.Check(DefaultConfig().decode(strings.NewReader("rpc:\n  prot: 3003\n")),
+		ErrorMatches, "(?s).*field prot not found.*")
+	c.Check(DefaultConfig().decode(strings.NewReader("notifications:\n  escalation: [15m:everyone]\n")),
+		ErrorMatches, "invalid escalation target 'everyone', only 'group' is supported")
+}
+
+func (s *ConfigSuite) TestOverridesFromEnvironment(c *C) {
+	env := map[string]string{
+		"OLYMPUS_DATA_HOME":              "/data/olympus",
+		"OLYMPUS_ALLOW_CORS":             "a.org, b.org",
+		"OLYMPUS_RPC_LISTEN":             "3003",
+		"OLYMPUS_ESCALATION":             "10m,20m:group",
+		"OLYMPUS_BOUND_CHECK":            "true",
+		"OLYMPUS_HUMIDITY_HYSTERESIS":    "3.5",
+		"OLYMPUS_CLIMATE_STALE_AFTER":    "0s",
+		"OLYMPUS_TEMPERATURE_HYSTERESIS": "",
+	}
+	lookup := func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+	config := DefaultConfig()
+	c.Assert(config.overrideFromEnvironment(lookup), IsNil)
+	c.Check(config.DataHome, Equals, "/data/olympus")
+	c.Check(config.HTTP.AllowCORS, DeepEquals, []string{"a.org", "b.org"})
+	c.Check(config.RPC.Port, Equals, 3003)
+	c.Check(config.Notifications.Escalation, DeepEquals, []EscalationStep{
+		{After: 10 * time.Minute},
+		{After: 20 * time.Minute, Group: true},
+	})
+	c.Check(config.Climate.BoundCheck.Enabled, Equals, true)
+	c.Check(config.Climate.BoundCheck.HumidityHysteresis, Equals, float32(3.5))
+	c.Check(config.Climate.BoundCheck.TemperatureHysteresis, Equals, float32(0.5))
+	c.Check(config.Climate.StaleAfter, Equals, time.Duration(0))
+	c.Check(config.Notifications.MinimumOn, Equals, time.Minute)
+
+	env["OLYMPUS_DEBUG_WEBPUSH"] = "10s"
+	c.Assert(config.overrideFromEnvironment(lookup), IsNil)
+	c.Check(config.Notifications.MinimumOn, Equals, time.Second)
+	c.Check(config.Notifications.BatchPeriod, Equals, 10*time.Second)
+
+	env["OLYMPUS_RPC_LISTEN"] = "http"
+	c.Check(config.overrideFromEnvironment(lookup), ErrorMatches,
+		"olympus: invalid OLYMPUS_RPC_LISTEN: .*")
+}
+
+func (s *ConfigSuite) TestCommandLineOverrides(c *C) {
+	port := 3003
+	stale := time.Duration(0)
+	cmd := &RunCommand{
+		RPC:               &port,
+		ClimateStaleAfter: &stale,
+		NoEscalation:      true,
+		BoundCheck:        true,
+	}
+	config := DefaultConfig()
+	cmd.override(config)
+	c.Check(config.RPC.Port, Equals, 3003)
+	c.Check(config.HTTP.Address, Equals, ":3000")
+	c.Check(config.Climate.StaleAfter, Equals, time.Duration(0))
+	c.Check(config.Notifications.Escalation, HasLen, 0)
+	c.Check(config.Climate.BoundCheck.Enabled, Equals, true)
+	c.Check(config.Climate.TargetTracking.Enabled, Equals, false)
+}
+
+func (s *ConfigSuite) TestCommandsUseDataHome(c *C) {
+	saved := _datapath
+	defer func() { _datapath = saved }()
+	dir := c.MkDir()
+	configFile := filepath.Join(dir, "olympus.yml")
+	c.Assert(os.WriteFile(configFile, []byte("data_home: "+dir+"\n"), 0644), IsNil)
+
+	cmd := &AddUserCommand{ConfigOptions: ConfigOptions{Config: configFile}, Password: "secret"}
+	cmd.Role = "viewer"
+	cmd.Args.Name = "alice"
+	c.Assert(cmd.Execute(nil), IsNil)
+	c.Check(_datapath, Equals, dir)
+	c.Check(NewUserStore("users").List(), HasLen, 1)
+}
+
+func (s *ConfigSuite) TestValidates(c *C) {
+	testdata := []struct {
+		Modify func(*Config)
+		Error  string
+	}{
+		{
+			func(config *Config) { config.Secret = "not base64!" },
+			"secret: must be URL base64 encoded",
+		},
+		{
+			func(config *Config) { config.RPC.TLSKey = "olympus.key" },
+			"rpc.tls_cert, rpc.tls_key: must be set together",
+		},
+		{
+			func(config *Config) { config.Notifications.WebPush.PublicKey = "public" },
+			"notifications.web_push: public_key, private_key and subscriber must be set together",
+		},
+		{
+			func(config *Config) { config.Climate.Windows[1].Names = []string{"10m"} },
+			"climate.windows\\[1\\].names: duplicated name '10m'",
+		},
+		{
+			func(config *Config) { config.Climate.Windows[0].Unit = 10 * time.Minute },
+			"climate.windows\\[0\\].unit: must be one of 1s, 1m, 1h or 24h",
+		},
+		{
+			func(config *Config) { config.Tracking.DiskFullWarning = time.Hour },
+			"tracking.disk_full_warning: must not be shorter than tracking.disk_full_emergency",
+		},
+	}
+
+	for _, d := range testdata {
+		config := DefaultConfig()
+		d.Modify(config)
+		c.Check(config.Validate(), ErrorMatches, "olympus: invalid configuration:\n  "+d.Error)
+	}
+}
@@ -1,3 +1,167 @@
 package olympus
 
+import "time"
+
+// BackLogPageSize is the number of climate reports sent in each page
+// of a climate backlog.
 var BackLogPageSize int = 4000
+
+// ClimateWindows are the time windows of the climate time series,
+// the first one is used for unknown windows.
+var ClimateWindows []ClimateWindow = []ClimateWindow{
+	{Names: []string{"10m", "10-minute", "10-minutes"}, Duration: 10 * time.Minute, Unit: time.Minute, Samples: 500},
+	{Names: []string{"1h", "hour"}, Duration: time.Hour, Unit: time.Minute, Samples: 400},
+	{Names: []string{"1d", "day"}, Duration: 24 * time.Hour, Unit: time.Hour, Samples: 300},
+	{Names: []string{"1w", "week"}, Duration: 7 * 24 * time.Hour, Unit: 24 * time.Hour, Samples: 300},
+}
+
+// AlarmMinimumOn is the duration an alarm must stay ON before it is
+// notified.
+var AlarmMinimumOn time.Duration = time.Minute
+
+// NotificationBatchPeriod is the period at which alarm updates are
+// batched in notifications.
+var NotificationBatchPeriod time.Duration = 5 * time.Minute
+
+// ClimateReportRetention is the duration raw climate reports are
+// kept on disk. A non-positive value keeps them forever.
+var ClimateReportRetention time.Duration = 90 * 24 * time.Hour
+
+// AlarmRetention is the duration alarm events are kept on disk. A
+// non-positive value keeps them forever.
+var AlarmRetention time.Duration = 365 * 24 * time.Hour
+
+// EscalationPolicy lists the steps taken when an EMERGENCY or
+// FAILURE stays active and unacknowledged. An empty policy disables
+// escalation, which is the default.
+var EscalationPolicy []EscalationStep = nil
+
+// DefaultClimateSamples is the number of points returned for
+// arbitrary climate time ranges, when not specified by the client.
+const DefaultClimateSamples = 500
+
+// MaxClimateSamples is the maximal number of points that can be
+// requested for arbitrary climate time ranges.
+const MaxClimateSamples = 5000
+
+// MaxClimateRange is the maximal duration of an arbitrary climate
+// time range, as all its raw reports are loaded before downsampling.
+const MaxClimateRange = 31 * 24 * time.Hour
+
+// WebhookMaxAttempts is the number of times a webhook delivery is
+// attempted before giving up.
+const WebhookMaxAttempts = 5
+
+// WebhookBackoff is the delay before the first retry of a failed
+// webhook delivery. It doubles after each attempt.
+const WebhookBackoff = 1 * time.Second
+
+// WebhookTimeout is the timeout of a single webhook request.
+const WebhookTimeout = 10 * time.Second
+
+// WebhookDeliveryLogSize is the number of deliveries kept per
+// webhook.
+const WebhookDeliveryLogSize = 50
+
+// EmailTimeout is the timeout of a whole SMTP session sending a
+// notification email.
+const EmailTimeout = 30 * time.Second
+
+// EmailQueueSize is the number of notification emails waiting to be
+// sent. Notifications are dropped when the queue is full.
+const EmailQueueSize = 100
+
+// EventClientBufferSize is the number of live events buffered for
+// each client of the event stream. Clients lagging behind are
+// disconnected.
+const EventClientBufferSize = 64
+
+// EventKeepAlive is the period of the comments sent on idle event
+// streams, to keep proxies from closing them.
+const EventKeepAlive = 30 * time.Second
+
+// DiskFullWarning is the forecasted time to full under which a
+// tracking disk raises a WARNING.
+var DiskFullWarning time.Duration = 72 * time.Hour
+
+// DiskFullEmergency is the forecasted time to full under which a
+// tracking disk raises an EMERGENCY.
+var DiskFullEmergency time.Duration = 24 * time.Hour
+
+// DiskForecastWindow is the duration of disk status history used to
+// observe the filling trend of a tracking disk.
+const DiskForecastWindow = 6 * time.Hour
+
+// DiskForecastMinimumSpan is the minimal history needed before the
+// observed trend is used in the forecast.
+const DiskForecastMinimumSpan = 15 * time.Minute
+
+// ClimateStalePeriod is the duration without climate reports after
+// which a zone raises a stale data alarm. A non-positive value
+// disables the check.
+var ClimateStalePeriod time.Duration = 5 * time.Minute
+
+// ClimateStaleCheckPeriod is the period at which zones are checked
+// for stale climate data.
+const ClimateStaleCheckPeriod = 30 * time.Second
+
+// ServerBoundCheck is the policy used to check climate reports
+// against the bounds declared by their zone. A nil policy leaves
+// bound checking to the clients.
+var ServerBoundCheck *BoundCheckPolicy = nil
+
+// DefaultBoundCheckPolicy is the ServerBoundCheck policy used when
+// bound checking is enabled without other settings.
+var DefaultBoundCheckPolicy = BoundCheckPolicy{
+	TemperatureHysteresis: 0.5,
+	HumidityHysteresis:    2,
+	MinimumDuration:       5 * time.Minute,
+}
+
+// TrackingErrorResolution is the time resolution of the target
+// tracking error statistics.
+const TrackingErrorResolution = time.Minute
+
+// TargetTrackingAlarm is the policy used to raise alarms when a zone
+// deviates from its climate target. A nil policy disables these
+// alarms, but tracking error statistics are still computed.
+var TargetTrackingAlarm *TargetTrackingPolicy = nil
+
+// DefaultTargetTrackingPolicy is the TargetTrackingAlarm policy used
+// when tracking error alarms are enabled without other settings.
+var DefaultTargetTrackingPolicy = TargetTrackingPolicy{
+	TemperatureThreshold: 1,
+	HumidityThreshold:    5,
+	MinimumDuration:      15 * time.Minute,
+}
+
+// SessionDuration is the validity of the session cookies opened on
+// login.
+var SessionDuration time.Duration = 12 * time.Hour
+
+// UserReloadPeriod is the maximal delay before changes to the user
+// accounts made by the user command apply to a running service.
+const UserReloadPeriod = 5 * time.Second
+
+// HeartbeatMissedIntervals is the number of heartbeat intervals
+// without heartbeat after which a virtual zone raises an alarm.
+const HeartbeatMissedIntervals = 2
+
+// HeartbeatCheckPeriod is the period at which virtual zones are
+// checked for missed heartbeats.
+const HeartbeatCheckPeriod = 10 * time.Second
+
+// OlympusSecret is the base64 encoded secret of the CSRF tokens and
+// session cookies. They are disabled if it is empty.
+var OlympusSecret string
+
+// AdminToken is the token granting access to the administration
+// endpoints. They are disabled if it is empty and users are not
+// enabled.
+var AdminToken string
+
+// WebPush are the VAPID credentials of the web push notifications.
+var WebPush WebPushConfig
+
+// Email are the SMTP settings of the email notifications.
+var Email EmailConfig = EmailConfig{Port: 587}
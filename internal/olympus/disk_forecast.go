@@ -0,0 +1,130 @@
+package olympus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DiskFullAlarmIdentification is the identification, within the
+// tracking domain, of the server-generated alarm raised when a
+// tracking disk is forecasted to be full soon.
+const DiskFullAlarmIdentification = "disk.almost-full"
+
+type diskSample struct {
+	time      time.Time
+	freeBytes int64
+}
+
+// A diskForecaster keeps the recent history of a tracking disk to
+// forecast when it will be full.
+type diskForecaster struct {
+	samples []diskSample
+	level   *api.AlarmLevel
+}
+
+func (f *diskForecaster) push(now time.Time, freeBytes int64) {
+	f.samples = append(f.samples, diskSample{time: now, freeBytes: freeBytes})
+	oldest := now.Add(-DiskForecastWindow)
+	i := 0
+	for ; i < len(f.samples)-1; i++ {
+		if f.samples[i].time.After(oldest) {
+			break
+		}
+	}
+	f.samples = f.samples[i:]
+}
+
+// observedBytesPerSecond returns the rate at which the disk is
+// filled, from a least square fit of the free bytes history. It
+// returns false if the history is too short.
+func (f *diskForecaster) observedBytesPerSecond() (int64, bool) {
+	if len(f.samples) < 2 {
+		return 0, false
+	}
+	start := f.samples[0].time
+	if f.samples[len(f.samples)-1].time.Sub(start) < DiskForecastMinimumSpan {
+		return 0, false
+	}
+
+	var sumT, sumF float64
+	for _, s := range f.samples {
+		sumT += s.time.Sub(start).Seconds()
+		sumF += float64(s.freeBytes)
+	}
+	n := float64(len(f.samples))
+	meanT, meanF := sumT/n, sumF/n
+
+	var cov, variance float64
+	for _, s := range f.samples {
+		dt := s.time.Sub(start).Seconds() - meanT
+		cov += dt * (float64(s.freeBytes) - meanF)
+		variance += dt * dt
+	}
+	if variance == 0 {
+		return 0, false
+	}
+	return Max(0, int64(-cov/variance)), true
+}
+
+// forecast returns the time the disk will be full, using the fastest
+// of the reported and observed filling rates. It returns nil if the
+// disk is not filling up.
+func (f *diskForecaster) forecast(now time.Time, freeBytes, bytesPerSecond int64) *time.Time {
+	if observed, ok := f.observedBytesPerSecond(); ok == true {
+		bytesPerSecond = Max(bytesPerSecond, observed)
+	}
+	if freeBytes <= 0 {
+		return &now
+	}
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	res := now.Add(time.Duration(float64(freeBytes) / float64(bytesPerSecond) * float64(time.Second)))
+	return &res
+}
+
+// alarms returns the alarm updates needed to reflect fullAt, if the
+// alarm level changed since the last call.
+func (f *diskForecaster) alarms(now time.Time, fullAt *time.Time) []*api.AlarmUpdate {
+	var level *api.AlarmLevel
+	if fullAt != nil {
+		remaining := fullAt.Sub(now)
+		if remaining < DiskFullEmergency {
+			level = new(api.AlarmLevel)
+			*level = api.AlarmLevel_EMERGENCY
+		} else if remaining < DiskFullWarning {
+			level = new(api.AlarmLevel)
+			*level = api.AlarmLevel_WARNING
+		}
+	}
+
+	if level == nil {
+		if f.level == nil {
+			return nil
+		}
+		update := &api.AlarmUpdate{
+			Identification: DiskFullAlarmIdentification,
+			Level:          *f.level,
+			Status:         api.AlarmStatus_OFF,
+			Time:           timestamppb.New(now),
+		}
+		f.level = nil
+		return []*api.AlarmUpdate{update}
+	}
+
+	if f.level != nil && *f.level == *level {
+		return nil
+	}
+	f.level = level
+	return []*api.AlarmUpdate{{
+		Identification: DiskFullAlarmIdentification,
+		Level:          *level,
+		Status:         api.AlarmStatus_ON,
+		Time:           timestamppb.New(now),
+		Description: fmt.Sprintf("tracking disk will be full in %s",
+			formatAlarmDuration(fullAt.Sub(now))),
+	}}
+}
@@ -0,0 +1,322 @@
+package olympus
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/tm"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+type emailSender struct {
+	host      string
+	address   string
+	auth      smtp.Auth
+	from      *mail.Address
+	publicURL string
+	timeout   time.Duration
+	log       *logrus.Entry
+
+	queue chan NotificationFor
+	wg    sync.WaitGroup
+}
+
+// NewEmailNotificationSender creates a NotificationSender sending
+// mails through the SMTP server of the Email settings. Links are made
+// absolute with their PublicURL if set. Mails are sent asynchronously
+// by a single worker, so a slow SMTP server does not delay other
+// notifications, until the sender is closed.
+func NewEmailNotificationSender() (NotificationSender, error) {
+	if Email.Enabled() == false {
+		return discardNotification{}, errors.New("missing SMTP host or sender address")
+	}
+
+	fromAddress, err := mail.ParseAddress(Email.From)
+	if err != nil {
+		return discardNotification{}, fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	res := &emailSender{
+		host:      Email.Host,
+		address:   net.JoinHostPort(Email.Host, strconv.Itoa(Email.Port)),
+		from:      fromAddress,
+		publicURL: strings.TrimSuffix(Email.PublicURL, "/"),
+		timeout:   EmailTimeout,
+		log:       tm.NewLogger("email"),
+		queue:     make(chan NotificationFor, EmailQueueSize),
+	}
+
+	if len(Email.User) > 0 {
+		res.auth = smtp.PlainAuth("", Email.User, Email.Password, Email.Host)
+	}
+
+	res.wg.Add(1)
+	go func() {
+		defer res.wg.Done()
+		for n := range res.queue {
+			res.deliver(n)
+		}
+	}()
+
+	return res, nil
+}
+
+func (s *emailSender) Send(n NotificationFor) error {
+	if len(n.Updates) == 0 {
+		return nil
+	}
+	select {
+	case s.queue <- n:
+		return nil
+	default:
+		return fmt.Errorf("email: queue is full, dropping notification to '%s'", n.Email)
+	}
+}
+
+// Close waits for all queued mails to be sent.
+func (s *emailSender) Close() error {
+	close(s.queue)
+	s.wg.Wait()
+	return nil
+}
+
+func (s *emailSender) deliver(n NotificationFor) (err error) {
+	defer func() {
+		entry := s.log.WithFields(logrus.Fields{
+			"email":   n.Email,
+			"updates": n.Updates,
+		})
+
+		if err != nil {
+			entry.WithField("error", err).Errorf("could not send notification")
+		} else {
+			entry.Debugf("sent")
+		}
+	}()
+
+	msg, err := BuildNotificationEmail(s.from, n.Email, n.Updates, s.publicURL, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return s.sendMail(n.Email, msg)
+}
+
+// sendMail is smtp.SendMail, with a deadline on the whole session so
+// an unresponsive server does not block the queue.
+func (s *emailSender) sendMail(to string, msg []byte) error {
+	conn, err := net.DialTimeout("tcp", s.address, s.timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if ok, _ := client.Extension("STARTTLS"); ok == true {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if s.auth != nil {
+		if ok, _ := client.Extension("AUTH"); ok == false {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err := client.Auth(s.auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(s.from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+type emailAlarm struct {
+	Zone           string
+	Level          string
+	Identification string
+	Description    string
+	Resolution     string
+	URL            string
+}
+
+type emailContent struct {
+	Title  string
+	Body   string
+	URL    string
+	Alarms []emailAlarm
+}
+
+func newEmailContent(updates []ZonedAlarmUpdate, publicURL string) (emailContent, error) {
+	notification, err := NewWebPushNotification(updates)
+	if err != nil {
+		return emailContent{}, err
+	}
+	res := emailContent{
+		Title:  notification.Title,
+		Body:   notification.Body,
+		URL:    publicURL + notification.Data.OnActionClick["default"].URL,
+		Alarms: make([]emailAlarm, 0, len(updates)),
+	}
+	for _, u := range updates {
+		alarm := emailAlarm{
+			Zone:           u.Zone,
+			Level:          cases.Title(language.English).String(u.Update.Level.String()),
+			Identification: u.Update.Identification,
+			Description:    u.Update.Description,
+			URL:            publicURL + buildURL(u.Zone),
+		}
+		if u.Resolution != nil {
+			alarm.Resolution = "resolved after " + formatAlarmDuration(u.Resolution.Duration())
+		}
+		res.Alarms = append(res.Alarms, alarm)
+	}
+	sort.SliceStable(res.Alarms, func(i, j int) bool {
+		return res.Alarms[i].Zone < res.Alarms[j].Zone
+	})
+	return res, nil
+}
+
+var textEmailTemplate = template.Must(template.New("text").Parse(`{{.Title}}
+{{if .Body}}
+{{.Body}}
+{{end}}
+{{range .Alarms}}- {{.Level}} on {{.Zone}}: {{.Identification}}{{if .Description}} ({{.Description}}){{end}}{{if .Resolution}}, {{.Resolution}}{{end}}
+{{end}}
+{{.URL}}
+`))
+
+var htmlEmailTemplate = htmltemplate.Must(htmltemplate.New("html").Parse(`<!DOCTYPE html>
+<html>
+<body>
+<h2>{{.Title}}</h2>
+{{if .Body}}<p>{{.Body}}</p>{{end}}
+<ul>
+{{range .Alarms}}<li><b>{{.Level}}</b> on <a href="{{.URL}}">{{.Zone}}</a>: {{.Identification}}{{if .Description}} ({{.Description}}){{end}}{{if .Resolution}}, <i>{{.Resolution}}</i>{{end}}</li>
+{{end}}</ul>
+<p><a href="{{.URL}}">Open in Olympus</a></p>
+</body>
+</html>
+`))
+
+// BuildNotificationEmail renders updates as a multipart plain text
+// and HTML mail, with the same content than NewWebPushNotification.
+func BuildNotificationEmail(from *mail.Address, to string, updates []ZonedAlarmUpdate, publicURL string, date time.Time) ([]byte, error) {
+	content, err := newEmailContent(updates, publicURL)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	parts := []struct {
+		contentType string
+		render      func(*bytes.Buffer) error
+	}{
+		{"text/plain; charset=utf-8", func(b *bytes.Buffer) error { return textEmailTemplate.Execute(b, content) }},
+		{"text/html; charset=utf-8", func(b *bytes.Buffer) error { return htmlEmailTemplate.Execute(b, content) }},
+	}
+	for _, p := range parts {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"8bit"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		buffer := &bytes.Buffer{}
+		if err := p.render(buffer); err != nil {
+			return nil, err
+		}
+		if _, err := part.Write(bytes.ReplaceAll(buffer.Bytes(), []byte("\n"), []byte("\r\n"))); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	res := &bytes.Buffer{}
+	headers := [][2]string{
+		{"From", from.String()},
+		{"To", to},
+		{"Subject", mime.QEncoding.Encode("utf-8", content.Title)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + writer.Boundary()},
+	}
+	for _, h := range headers {
+		fmt.Fprintf(res, "%s: %s\r\n", h[0], h[1])
+	}
+	res.WriteString("\r\n")
+	res.Write(body.Bytes())
+	return res.Bytes(), nil
+}
+
+type notificationDispatcher struct {
+	push, email, webhook NotificationSender
+}
+
+// NewNotificationDispatcher returns a NotificationSender that sends
+// notifications to push, email or webhook, depending on their target.
+func NewNotificationDispatcher(push, email, webhook NotificationSender) NotificationSender {
+	return notificationDispatcher{push: push, email: email, webhook: webhook}
+}
+
+// Close closes the senders delivering asynchronously.
+func (d notificationDispatcher) Close() error {
+	for _, s := range []NotificationSender{d.push, d.email, d.webhook} {
+		closeNotificationSender(s)
+	}
+	return nil
+}
+
+func (d notificationDispatcher) Send(n NotificationFor) error {
+	if n.Webhook != nil {
+		return d.webhook.Send(n)
+	}
+	if len(n.Email) > 0 {
+		return d.email.Send(n)
+	}
+	if n.Subscription != nil {
+		return d.push.Send(n)
+	}
+	return errors.New("notification has no target")
+}
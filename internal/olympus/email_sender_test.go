@@ -0,0 +1,301 @@
+package olympus
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SherClockHolmes/webpush-go"
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	. "gopkg.in/check.v1"
+)
+
+type fakeSMTPMail struct {
+	From string
+	To   []string
+	Data string
+}
+
+// fakeSMTPServer is a minimal SMTP server, accepting any mail.
+type fakeSMTPServer struct {
+	listener net.Listener
+	mails    chan fakeSMTPMail
+}
+
+func newFakeSMTPServer() (*fakeSMTPServer, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	res := &fakeSMTPServer{listener: l, mails: make(chan fakeSMTPMail, 10)}
+	go res.serve()
+	return res, nil
+}
+
+func (s *fakeSMTPServer) Close() {
+	s.listener.Close()
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 localhost fake SMTP")
+	current := fakeSMTPMail{}
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			text.PrintfLine("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			current.From = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+			text.PrintfLine("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			current.To = append(current.To, strings.Trim(line[len("RCPT TO:"):], "<> "))
+			text.PrintfLine("250 OK")
+		case command == "DATA":
+			text.PrintfLine("354 go ahead")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			current.Data = string(data)
+			s.mails <- current
+			current = fakeSMTPMail{}
+			text.PrintfLine("250 OK")
+		case command == "QUIT":
+			text.PrintfLine("221 bye")
+			return
+		default:
+			text.PrintfLine("250 OK")
+		}
+	}
+}
+
+type EmailSenderSuite struct {
+	server *fakeSMTPServer
+	email  EmailConfig
+}
+
+var _ = Suite(&EmailSenderSuite{})
+
+func (s *EmailSenderSuite) SetUpTest(c *C) {
+	var err error
+	s.server, err = newFakeSMTPServer()
+	c.Assert(err, IsNil)
+	host, port, err := net.SplitHostPort(s.server.listener.Addr().String())
+	c.Assert(err, IsNil)
+	s.email = Email
+	Email = EmailConfig{
+		Host:      host,
+		From:      "Olympus <olympus@example.com>",
+		PublicURL: "https://olympus.example.com/",
+	}
+	Email.Port, err = strconv.Atoi(port)
+	c.Assert(err, IsNil)
+}
+
+func (s *EmailSenderSuite) TearDownTest(c *C) {
+	s.server.Close()
+	Email = s.email
+}
+
+func (s *EmailSenderSuite) TestNeedsConfiguration(c *C) {
+	Email.Host = ""
+	_, err := NewEmailNotificationSender()
+	c.Check(err, ErrorMatches, "missing SMTP host or sender address")
+}
+
+func (s *EmailSenderSuite) TestTimesOut(c *C) {
+	// a server which never answers.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, IsNil)
+	defer l.Close()
+	host, port, err := net.SplitHostPort(l.Addr().String())
+	c.Assert(err, IsNil)
+	Email.Host = host
+	Email.Port, err = strconv.Atoi(port)
+	c.Assert(err, IsNil)
+
+	sender, err := NewEmailNotificationSender()
+	c.Assert(err, IsNil)
+	defer sender.(*emailSender).Close()
+	sender.(*emailSender).timeout = 50 * time.Millisecond
+	n := NotificationFor{
+		Email: "someone@example.com",
+		Updates: []ZonedAlarmUpdate{{
+			Zone:   "somehost.box",
+			Update: &api.AlarmUpdate{Identification: "foo", Level: api.AlarmLevel_WARNING, Status: api.AlarmStatus_ON},
+		}},
+	}
+	start := time.Now()
+	c.Check(sender.(*emailSender).deliver(n), ErrorMatches, ".*i/o timeout")
+	c.Check(time.Since(start) < time.Second, Equals, true)
+
+	// sending does not wait for the server.
+	start = time.Now()
+	c.Check(sender.Send(n), IsNil)
+	c.Check(time.Since(start) < 50*time.Millisecond, Equals, true)
+}
+
+func readMailParts(c *C, data string) (*mail.Message, map[string]string) {
+	msg, err := mail.ReadMessage(strings.NewReader(data))
+	c.Assert(err, IsNil)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	c.Assert(err, IsNil)
+	c.Assert(mediaType, Equals, "multipart/alternative")
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	parts := make(map[string]string)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		c.Assert(err, IsNil)
+		content, err := io.ReadAll(part)
+		c.Assert(err, IsNil)
+		mediaType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		c.Assert(err, IsNil)
+		parts[mediaType] = string(content)
+	}
+	return msg, parts
+}
+
+func (s *EmailSenderSuite) TestSendsSingleUpdates(c *C) {
+	sender, err := NewEmailNotificationSender()
+	c.Assert(err, IsNil)
+	defer closeNotificationSender(sender)
+
+	err = sender.Send(NotificationFor{
+		Email: "someone@example.com",
+		Updates: []ZonedAlarmUpdate{
+			{Zone: "somehost.box", Update: &api.AlarmUpdate{
+				Identification: "climate.temperature.out-of-bound",
+				Level:          api.AlarmLevel_EMERGENCY,
+				Description:    "temperature is 30°C",
+			}},
+		},
+	})
+	c.Assert(err, IsNil)
+
+	var received fakeSMTPMail
+	select {
+	case received = <-s.server.mails:
+	case <-time.After(time.Second):
+		c.Fatalf("no mail received")
+	}
+	c.Check(received.From, Equals, "olympus@example.com")
+	c.Check(received.To, DeepEquals, []string{"someone@example.com"})
+
+	msg, parts := readMailParts(c, received.Data)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	c.Check(err, IsNil)
+	c.Check(subject, Equals, "One Emergency on somehost.box")
+	c.Check(msg.Header.Get("To"), Equals, "someone@example.com")
+
+	c.Check(parts["text/plain"], Matches, `(?s).*temperature is 30°C.*`)
+	c.Check(parts["text/plain"], Matches, `(?s).*https://olympus.example.com/host/somehost/zone/box.*`)
+	c.Check(parts["text/html"], Matches, `(?s).*<h2>One Emergency on somehost.box</h2>.*`)
+	c.Check(parts["text/html"], Matches, `(?s).*href="https://olympus.example.com/host/somehost/zone/box".*`)
+}
+
+func (s *EmailSenderSuite) TestSendsMultipleUpdates(c *C) {
+	sender, err := NewEmailNotificationSender()
+	c.Assert(err, IsNil)
+	defer closeNotificationSender(sender)
+
+	updates := []ZonedAlarmUpdate{
+		{Zone: "somehost.box", Update: &api.AlarmUpdate{
+			Identification: "climate.temperature.out-of-bound",
+			Level:          api.AlarmLevel_EMERGENCY,
+		}},
+		{Zone: "another.box", Update: &api.AlarmUpdate{
+			Identification: "<script>",
+			Level:          api.AlarmLevel_WARNING,
+		}},
+	}
+	c.Assert(sender.Send(NotificationFor{Email: "someone@example.com", Updates: updates}), IsNil)
+
+	received := <-s.server.mails
+	msg, parts := readMailParts(c, received.Data)
+	c.Check(msg.Header.Get("Subject"), Equals, "1 New Emergency and 1 New Warning")
+	c.Check(parts["text/plain"], Matches, `(?s).*another.box and somehost.box have alarms.*`)
+	c.Check(parts["text/plain"], Matches, `(?s).*- Warning on another.box: <script>.*- Emergency on somehost.box.*`)
+	c.Check(strings.Contains(parts["text/html"], "<script>"), Equals, false)
+	c.Check(parts["text/html"], Matches, `(?s).*&lt;script&gt;.*`)
+}
+
+func (s *EmailSenderSuite) TestDispatchesOnTarget(c *C) {
+	push, email, webhook := NewNotificationLogger(), NewNotificationLogger(), NewNotificationLogger()
+	dispatcher := NewNotificationDispatcher(push, email, webhook)
+
+	c.Check(dispatcher.Send(NotificationFor{Email: "someone@example.com"}), IsNil)
+	c.Check(dispatcher.Send(NotificationFor{Subscription: &webpush.Subscription{Endpoint: "a"}}), IsNil)
+	c.Check(dispatcher.Send(NotificationFor{Webhook: &api.Webhook{ID: "a"}}), IsNil)
+	c.Check(dispatcher.Send(NotificationFor{}), ErrorMatches, "notification has no target")
+	c.Check(push.Logs, HasLen, 1)
+	c.Check(email.Logs, HasLen, 1)
+	c.Check(webhook.Logs, HasLen, 1)
+}
+
+func (s *EmailSenderSuite) TestResolvedAlarms(c *C) {
+	now := time.Now()
+	resolved := ZonedAlarmUpdate{
+		Zone: "somehost.box",
+		Update: &api.AlarmUpdate{
+			Identification: "climate.temperature.out-of-bound",
+			Level:          api.AlarmLevel_EMERGENCY,
+			Status:         api.AlarmStatus_OFF,
+		},
+		Resolution: &AlarmResolution{FiredAt: now.Add(-12 * time.Minute), ResolvedAt: now},
+	}
+	notification, err := NewWebPushNotification([]ZonedAlarmUpdate{resolved})
+	c.Assert(err, IsNil)
+	c.Check(notification.Title, Equals, "Emergency on somehost.box resolved after 12 min")
+
+	warning := ZonedAlarmUpdate{Zone: "another.box", Update: &api.AlarmUpdate{
+		Identification: "climate.humidity.out-of-bound",
+		Level:          api.AlarmLevel_WARNING,
+	}}
+	notification, err = NewWebPushNotification([]ZonedAlarmUpdate{resolved, warning})
+	c.Assert(err, IsNil)
+	c.Check(notification.Title, Equals, "1 New Warning, 1 Resolved")
+	notification, err = NewWebPushNotification([]ZonedAlarmUpdate{resolved, resolved})
+	c.Assert(err, IsNil)
+	c.Check(notification.Title, Equals, "2 Alarms Resolved")
+
+	msg, err := BuildNotificationEmail(&mail.Address{Address: "olympus@example.com"},
+		"someone@example.com", []ZonedAlarmUpdate{resolved, warning}, "", now)
+	c.Assert(err, IsNil)
+	_, parts := readMailParts(c, string(msg))
+	c.Check(parts["text/plain"], Matches,
+		`(?s).*- Emergency on somehost.box: climate.temperature.out-of-bound, resolved after 12 min.*`)
+
+	for d, expected := range map[time.Duration]string{
+		10 * time.Second:             "less than a minute",
+		time.Hour:                    "1 h",
+		90*time.Minute + time.Second: "1 h 30 min",
+		26*time.Hour + 5*time.Minute: "26 h 5 min",
+	} {
+		c.Check(formatAlarmDuration(d), Equals, expected)
+	}
+}
@@ -0,0 +1,174 @@
+package olympus
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"gopkg.in/yaml.v3"
+)
+
+// An EscalationStep re-notifies an EMERGENCY or FAILURE which is
+// still active and unacknowledged After its first notification.
+type EscalationStep struct {
+	After time.Duration
+	// Group notifies the escalation group instead of the original
+	// subscribers.
+	Group bool
+}
+
+func (s EscalationStep) String() string {
+	if s.Group == true {
+		return s.After.String() + ":group"
+	}
+	return s.After.String()
+}
+
+// UnmarshalFlag parses a step from '<duration>' or
+// '<duration>:group'.
+func (s *EscalationStep) UnmarshalFlag(value string) error {
+	after, group, found := strings.Cut(value, ":")
+	if found == true && group != "group" {
+		return fmt.Errorf("invalid escalation target '%s', only 'group' is supported", group)
+	}
+	d, err := time.ParseDuration(after)
+	if err != nil {
+		return err
+	}
+	if d <= 0 {
+		return fmt.Errorf("invalid escalation delay %s", d)
+	}
+	s.After = d
+	s.Group = found
+	return nil
+}
+
+// UnmarshalYAML parses a step from the same format than
+// UnmarshalFlag.
+func (s *EscalationStep) UnmarshalYAML(value *yaml.Node) error {
+	var step string
+	if err := value.Decode(&step); err != nil {
+		return err
+	}
+	return s.UnmarshalFlag(step)
+}
+
+// MarshalYAML formats a step like String.
+func (s EscalationStep) MarshalYAML() (interface{}, error) {
+	return s.String(), nil
+}
+
+type pendingEscalation struct {
+	update ZonedAlarmUpdate
+	fired  time.Time
+	next   int
+}
+
+type escalator struct {
+	steps   []EscalationStep
+	clock   Clock
+	pending map[string]*pendingEscalation
+}
+
+func newEscalator(steps []EscalationStep, clock Clock) *escalator {
+	steps = append([]EscalationStep(nil), steps...)
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].After < steps[j].After
+	})
+	return &escalator{
+		steps:   steps,
+		clock:   clock,
+		pending: make(map[string]*pendingEscalation),
+	}
+}
+
+// EscalateAlarmUpdates returns an UpdateFilter that forwards all
+// updates, and re-sends EMERGENCY and FAILURE alarms that are still
+// active and unacknowledged after each step of the policy.
+func EscalateAlarmUpdates(steps []EscalationStep, clock Clock) UpdateFilter {
+	e := newEscalator(steps, clock)
+	return e.filter
+}
+
+func (e *escalator) filter(outgoing chan<- ZonedAlarmUpdate, incoming <-chan ZonedAlarmUpdate) {
+	defer close(outgoing)
+
+	var timer <-chan time.Time
+
+	for {
+		select {
+		case u, ok := <-incoming:
+			if ok == false {
+				return
+			}
+			outgoing <- u
+			e.push(u, e.clock.Now())
+		case t := <-timer:
+			e.escalate(outgoing, t)
+		}
+		timer = nil
+		if next, ok := e.nextDeadline(); ok == true {
+			timer = e.clock.After(next.Sub(e.clock.Now()))
+		}
+	}
+}
+
+func (e *escalator) push(u ZonedAlarmUpdate, now time.Time) {
+	if u.Update == nil {
+		e.cleanUpZone(u.Zone)
+		return
+	}
+	id := u.ID()
+	if u.Acknowledgement != nil || u.Update.Status == api.AlarmStatus_OFF {
+		delete(e.pending, id)
+		return
+	}
+	if u.Escalation != nil || len(e.steps) == 0 || u.Update.Level < api.AlarmLevel_EMERGENCY {
+		return
+	}
+	if p, ok := e.pending[id]; ok == true && p.update.Update.Level >= u.Update.Level {
+		return
+	}
+	e.pending[id] = &pendingEscalation{update: u, fired: now}
+}
+
+func (e *escalator) cleanUpZone(zone string) {
+	zone = AppendSuffix(zone, "/")
+	for id := range e.pending {
+		if strings.HasPrefix(id, zone) == true {
+			delete(e.pending, id)
+		}
+	}
+}
+
+func (e *escalator) escalate(outgoing chan<- ZonedAlarmUpdate, now time.Time) {
+	for id, p := range e.pending {
+		for p.next < len(e.steps) && p.fired.Add(e.steps[p.next].After).After(now) == false {
+			step := e.steps[p.next]
+			outgoing <- ZonedAlarmUpdate{
+				Zone:       p.update.Zone,
+				Update:     p.update.Update.Clone(),
+				Escalation: &step,
+			}
+			p.next += 1
+		}
+		if p.next >= len(e.steps) {
+			delete(e.pending, id)
+		}
+	}
+}
+
+func (e *escalator) nextDeadline() (time.Time, bool) {
+	var res time.Time
+	found := false
+	for _, p := range e.pending {
+		deadline := p.fired.Add(e.steps[p.next].After)
+		if found == false || deadline.Before(res) {
+			res = deadline
+			found = true
+		}
+	}
+	return res, found
+}
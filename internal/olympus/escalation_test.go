@@ -0,0 +1,192 @@
+package olympus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	. "gopkg.in/check.v1"
+)
+
+type fakeClockWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+type fakeClock struct {
+	mx      sync.Mutex
+	now     time.Time
+	waiters []fakeClockWaiter
+	after   chan struct{}
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now, after: make(chan struct{}, 100)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, fakeClockWaiter{deadline: c.now.Add(d), ch: ch})
+	c.after <- struct{}{}
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	c.now = c.now.Add(d)
+	waiters := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.deadline.After(c.now) == true {
+			waiters = append(waiters, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = waiters
+}
+
+type EscalationSuite struct {
+	clock              *fakeClock
+	incoming, outgoing chan ZonedAlarmUpdate
+	done               chan struct{}
+}
+
+var _ = Suite(&EscalationSuite{})
+
+var testEscalationPolicy = []EscalationStep{
+	{After: 30 * time.Minute, Group: true},
+	{After: 10 * time.Minute},
+}
+
+func (s *EscalationSuite) SetUpTest(c *C) {
+	s.clock = newFakeClock(time.Now().Round(0))
+	s.incoming = make(chan ZonedAlarmUpdate)
+	s.outgoing = make(chan ZonedAlarmUpdate, 10)
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		EscalateAlarmUpdates(testEscalationPolicy, s.clock)(s.outgoing, s.incoming)
+	}()
+}
+
+func (s *EscalationSuite) TearDownTest(c *C) {
+	close(s.incoming)
+	<-s.done
+}
+
+func (s *EscalationSuite) send(c *C, u ZonedAlarmUpdate) {
+	s.incoming <- u
+	received := <-s.outgoing
+	c.Check(received.ID(), Equals, u.ID(), Commentf("update is not forwarded"))
+	c.Check(received.Escalation, IsNil)
+}
+
+// advance moves the clock once the escalator has set its next timer.
+func (s *EscalationSuite) advance(c *C, d time.Duration) {
+	select {
+	case <-s.clock.after:
+	case <-time.After(100 * time.Millisecond):
+		c.Fatalf("escalator did not set a timer")
+	}
+	s.clock.Advance(d)
+}
+
+func (s *EscalationSuite) expectNothing(c *C) {
+	select {
+	case u := <-s.outgoing:
+		c.Errorf("unexpected update %v", u)
+	case <-time.After(5 * time.Millisecond):
+	}
+}
+
+func (s *EscalationSuite) build(id string, level api.AlarmLevel, status api.AlarmStatus) ZonedAlarmUpdate {
+	return ZonedAlarmUpdate{
+		Zone: "foo.box",
+		Update: &api.AlarmUpdate{
+			Identification: id,
+			Level:          level,
+			Status:         status,
+			Time:           timestamppb.New(s.clock.Now()),
+		},
+	}
+}
+
+func (s *EscalationSuite) TestEscalatesUnacknowledgedEmergencies(c *C) {
+	s.send(c, s.build("climate.temperature", api.AlarmLevel_EMERGENCY, api.AlarmStatus_ON))
+	// warnings never escalate
+	s.send(c, s.build("climate.humidity", api.AlarmLevel_WARNING, api.AlarmStatus_ON))
+
+	s.advance(c, 9*time.Minute)
+	s.expectNothing(c)
+	s.advance(c, time.Minute)
+	u := <-s.outgoing
+	c.Check(u.ID(), Equals, "foo.box/climate.temperature")
+	c.Assert(u.Escalation, NotNil)
+	c.Check(*u.Escalation, Equals, EscalationStep{After: 10 * time.Minute})
+
+	s.advance(c, 20*time.Minute)
+	u = <-s.outgoing
+	c.Check(u.ID(), Equals, "foo.box/climate.temperature")
+	c.Assert(u.Escalation, NotNil)
+	c.Check(*u.Escalation, Equals, EscalationStep{After: 30 * time.Minute, Group: true})
+
+	// the policy is exhausted
+	s.expectNothing(c)
+}
+
+func (s *EscalationSuite) TestAcknowledgementAndResolutionStopEscalation(c *C) {
+	s.send(c, s.build("climate.temperature", api.AlarmLevel_EMERGENCY, api.AlarmStatus_ON))
+	s.send(c, s.build("tracking.disk", api.AlarmLevel_FAILURE, api.AlarmStatus_ON))
+	s.send(c, s.build("climate.humidity", api.AlarmLevel_EMERGENCY, api.AlarmStatus_ON))
+
+	ack := s.build("climate.temperature", api.AlarmLevel_EMERGENCY, api.AlarmStatus_ON)
+	ack.Acknowledgement = &api.AlarmAcknowledgement{By: "someone", Time: s.clock.Now()}
+	s.send(c, ack)
+	s.send(c, s.build("tracking.disk", api.AlarmLevel_FAILURE, api.AlarmStatus_OFF))
+
+	s.advance(c, 10*time.Minute)
+	u := <-s.outgoing
+	c.Check(u.ID(), Equals, "foo.box/climate.humidity")
+	c.Check(u.Escalation, NotNil)
+
+	// the zone went offline
+	s.send(c, ZonedAlarmUpdate{Zone: "foo.box"})
+	s.clock.Advance(time.Hour)
+	s.expectNothing(c)
+}
+
+func (s *EscalationSuite) TestParsesSteps(c *C) {
+	testdata := []struct {
+		Value    string
+		Expected EscalationStep
+		Error    string
+	}{
+		{"15m", EscalationStep{After: 15 * time.Minute}, ""},
+		{"1h:group", EscalationStep{After: time.Hour, Group: true}, ""},
+		{"1h:everyone", EscalationStep{}, "invalid escalation target 'everyone', only 'group' is supported"},
+		{"-1m", EscalationStep{}, "invalid escalation delay -1m0s"},
+		{"foo", EscalationStep{}, `time: invalid duration "foo"`},
+	}
+
+	for _, d := range testdata {
+		var step EscalationStep
+		err := step.UnmarshalFlag(d.Value)
+		if len(d.Error) > 0 {
+			c.Check(err, ErrorMatches, d.Error, Commentf("value: %s", d.Value))
+			continue
+		}
+		c.Check(err, IsNil, Commentf("value: %s", d.Value))
+		c.Check(step, Equals, d.Expected)
+		c.Check(step.String(), Equals, d.Expected.String())
+	}
+}
@@ -0,0 +1,102 @@
+package olympus
+
+import (
+	"sync"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"github.com/formicidae-tracker/olympus/pkg/tm"
+	"github.com/sirupsen/logrus"
+)
+
+// An EventHub fans out live zone events to many clients. Publish
+// never blocks: a client that does not keep up with the events is
+// disconnected, and is expected to reconnect and resynchronize.
+type EventHub interface {
+	Publish(event api.ZoneEvent)
+	// Subscribe returns a channel receiving all published events,
+	// and a function to unsubscribe. The channel is closed once
+	// unsubscribed, when the client lags by more than bufferSize
+	// events, or when the hub is closed.
+	Subscribe() (<-chan api.ZoneEvent, func())
+	// Subscribers returns the number of connected clients.
+	Subscribers() int
+	// Close disconnects all clients. Later subscriptions are closed
+	// immediately.
+	Close()
+}
+
+type eventHub struct {
+	mx         sync.Mutex
+	bufferSize int
+	clients    map[chan api.ZoneEvent]struct{}
+	closed     bool
+	log        *logrus.Entry
+}
+
+// NewEventHub creates an EventHub buffering at most bufferSize
+// events per client.
+func NewEventHub(bufferSize int) EventHub {
+	return &eventHub{
+		bufferSize: bufferSize,
+		clients:    make(map[chan api.ZoneEvent]struct{}),
+		log:        tm.NewLogger("events"),
+	}
+}
+
+func (h *eventHub) Publish(event api.ZoneEvent) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	for ch := range h.clients {
+		select {
+		case ch <- event:
+		default:
+			h.log.WithField("event", event.Type).Warn("disconnecting slow client")
+			h.remove(ch)
+		}
+	}
+}
+
+func (h *eventHub) Subscribe() (<-chan api.ZoneEvent, func()) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	ch := make(chan api.ZoneEvent, h.bufferSize)
+	if h.closed == true {
+		close(ch)
+		return ch, func() {}
+	}
+	h.clients[ch] = struct{}{}
+
+	return ch, func() {
+		h.mx.Lock()
+		defer h.mx.Unlock()
+		h.remove(ch)
+	}
+}
+
+// remove closes a client channel, if not already done. It must be
+// called with the lock held.
+func (h *eventHub) remove(ch chan api.ZoneEvent) {
+	if _, ok := h.clients[ch]; ok == false {
+		return
+	}
+	delete(h.clients, ch)
+	close(ch)
+}
+
+func (h *eventHub) Subscribers() int {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	return len(h.clients)
+}
+
+func (h *eventHub) Close() {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	h.closed = true
+	for ch := range h.clients {
+		h.remove(ch)
+	}
+}
@@ -0,0 +1,64 @@
+package olympus
+
+import (
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	. "gopkg.in/check.v1"
+)
+
+type EventHubSuite struct{}
+
+var _ = Suite(&EventHubSuite{})
+
+func (s *EventHubSuite) TestFanOut(c *C) {
+	hub := NewEventHub(2)
+	a, unsubscribeA := hub.Subscribe()
+	b, unsubscribeB := hub.Subscribe()
+	defer unsubscribeB()
+	c.Check(hub.Subscribers(), Equals, 2)
+
+	hub.Publish(api.ZoneEvent{Type: api.ClimateEventType})
+	c.Check((<-a).Type, Equals, api.ClimateEventType)
+	c.Check((<-b).Type, Equals, api.ClimateEventType)
+
+	unsubscribeA()
+	// unsubscribing twice is safe.
+	unsubscribeA()
+	_, ok := <-a
+	c.Check(ok, Equals, false)
+	c.Check(hub.Subscribers(), Equals, 1)
+}
+
+func (s *EventHubSuite) TestDisconnectsSlowClients(c *C) {
+	hub := NewEventHub(2)
+	slow, unsubscribeSlow := hub.Subscribe()
+	defer unsubscribeSlow()
+	fast, unsubscribeFast := hub.Subscribe()
+	defer unsubscribeFast()
+
+	for i := 0; i < 3; i++ {
+		hub.Publish(api.ZoneEvent{Type: api.AlarmsEventType})
+		c.Check((<-fast).Type, Equals, api.AlarmsEventType)
+	}
+
+	// the slow client gets its buffered events, then is closed.
+	received := 0
+	for range slow {
+		received++
+	}
+	c.Check(received, Equals, 2)
+	c.Check(hub.Subscribers(), Equals, 1)
+}
+
+func (s *EventHubSuite) TestClose(c *C) {
+	hub := NewEventHub(2)
+	events, unsubscribe := hub.Subscribe()
+	hub.Close()
+	_, ok := <-events
+	c.Check(ok, Equals, false)
+	unsubscribe()
+
+	events, _ = hub.Subscribe()
+	_, ok = <-events
+	c.Check(ok, Equals, false)
+	c.Check(hub.Subscribers(), Equals, 0)
+}
@@ -0,0 +1,81 @@
+package olympus
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+type ExportClimateCommand struct {
+	ConfigOptions
+
+	Format string `short:"f" long:"format" description:"export format" choice:"csv" choice:"ndjson" default:"csv"`
+	From   string `long:"from" description:"export only data after this RFC3339 time"`
+	To     string `long:"to" description:"export only data before this RFC3339 time"`
+	Output string `short:"o" long:"output" description:"file to export to, default to stdout"`
+
+	Args struct {
+		Zone string `positional-arg-name:"zone" description:"zone identifier, i.e. 'host.zone'"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func parseCommandTime(value, name string) (time.Time, error) {
+	if len(value) == 0 {
+		return time.Time{}, nil
+	}
+	res, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --%s: %w", name, err)
+	}
+	return res, nil
+}
+
+func (c *ExportClimateCommand) Execute([]string) error {
+	if err := c.apply(); err != nil {
+		return err
+	}
+	format, err := ParseClimateExportFormat(c.Format)
+	if err != nil {
+		return err
+	}
+	from, err := parseCommandTime(c.From, "from")
+	if err != nil {
+		return err
+	}
+	to, err := parseCommandTime(c.To, "to")
+	if err != nil {
+		return err
+	}
+
+	// The store is only read, so retention does not apply.
+	store := NewClimateStore("climate-reports", 0)
+	zones, err := store.Zones()
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, z := range zones {
+		found = found || z == c.Args.Zone
+	}
+	if found == false {
+		return ZoneNotFoundError(c.Args.Zone)
+	}
+
+	output := NopCloser(os.Stdout)
+	if len(c.Output) > 0 {
+		output, err = os.Create(c.Output)
+		if err != nil {
+			return err
+		}
+	}
+	defer output.Close()
+
+	return ExportClimateReports(output, store, c.Args.Zone, from, to, format)
+}
+
+func init() {
+	parser.AddCommand("export-climate",
+		"exports raw climate data of a zone.",
+		"exports all raw climate data of a zone stored in the data_home of the configuration as CSV or NDJSON.",
+		&ExportClimateCommand{})
+}
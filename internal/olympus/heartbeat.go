@@ -0,0 +1,250 @@
+package olympus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// HeartbeatMissedIdentification identifies the alarm raised when a
+// virtual zone stops sending heartbeats.
+const HeartbeatMissedIdentification = "heartbeat.missed"
+
+// A heartbeatMonitor watches the heartbeats of a virtual zone. It is
+// not thread safe.
+type heartbeatMonitor struct {
+	interval    time.Duration
+	since, last time.Time
+	missed      bool
+}
+
+func newHeartbeatMonitor(interval time.Duration, now time.Time) *heartbeatMonitor {
+	return &heartbeatMonitor{interval: interval, since: now, last: now}
+}
+
+// deadline is the time after which heartbeats are missed.
+func (m *heartbeatMonitor) deadline() time.Time {
+	return m.last.Add(time.Duration(HeartbeatMissedIntervals) * m.interval)
+}
+
+// beat records a heartbeat. It returns the update clearing the
+// missed heartbeat alarm, if it was raised.
+func (m *heartbeatMonitor) beat(now time.Time) *api.AlarmUpdate {
+	m.last = now
+	if m.missed == false {
+		return nil
+	}
+	m.missed = false
+	return &api.AlarmUpdate{
+		Identification: HeartbeatMissedIdentification,
+		Level:          api.AlarmLevel_EMERGENCY,
+		Status:         api.AlarmStatus_OFF,
+		Time:           timestamppb.New(now),
+		Description:    "heartbeats resumed",
+	}
+}
+
+// check returns the update raising the missed heartbeat alarm, if
+// no heartbeat was received in time.
+func (m *heartbeatMonitor) check(now time.Time) *api.AlarmUpdate {
+	if m.missed == true || now.Before(m.deadline()) == true {
+		return nil
+	}
+	m.missed = true
+	return &api.AlarmUpdate{
+		Identification: HeartbeatMissedIdentification,
+		Level:          api.AlarmLevel_EMERGENCY,
+		Status:         api.AlarmStatus_ON,
+		Time:           timestamppb.New(now),
+		Description:    fmt.Sprintf("no heartbeat since %s", m.last.Format(time.RFC3339)),
+	}
+}
+
+func (m *heartbeatMonitor) info() *api.HeartbeatInfo {
+	return &api.HeartbeatInfo{
+		Since:         m.since,
+		Interval:      m.interval,
+		LastHeartbeat: m.last,
+		Missed:        m.missed,
+	}
+}
+
+// restoreHeartbeatZones registers the persisted virtual zones. Their
+// heartbeats are expected from now on, so a missed heartbeat alarm
+// still active from a previous run is cleared.
+func (o *Olympus) restoreHeartbeatZones(now time.Time) {
+	for zoneIdentifier, interval := range o.heartbeats.Map {
+		host, zone, err := splitZone(zoneIdentifier)
+		if err != nil {
+			continue
+		}
+		s := o.subscriptions[zoneIdentifier]
+		if s == nil {
+			s = &subscription{
+				host:        host,
+				name:        zone,
+				alarmLogger: NewPersistentAlarmLogger(zoneIdentifier, o.alarmStore),
+			}
+			o.subscriptions[zoneIdentifier] = s
+		}
+		s.heartbeat = newHeartbeatMonitor(interval, now)
+		s.alarmLogger.ClearDomain("heartbeat", now)
+	}
+}
+
+// Heartbeat records a heartbeat of a virtual zone, registering it if
+// needed. A zero interval unregisters the zone.
+func (o *Olympus) Heartbeat(ctx context.Context, host, zone string, interval time.Duration) (err error) {
+	zoneIdentifier := ZoneIdentifier(host, zone)
+	if err := checkClientIdentity(ctx, host); err != nil {
+		return err
+	}
+	if interval < 0 {
+		return fmt.Errorf("olympus: invalid heartbeat interval %s", interval)
+	}
+	if interval == 0 {
+		return o.unregisterHeartbeat(ctx, host, zone)
+	}
+
+	now := time.Now()
+	o.mx.Lock()
+	if o.subscriptions == nil {
+		o.mx.Unlock()
+		return ClosedOlympusServerError{}
+	}
+	s := o.subscriptions[zoneIdentifier]
+	if s == nil {
+		s = &subscription{
+			host:        host,
+			name:        zone,
+			alarmLogger: NewPersistentAlarmLogger(zoneIdentifier, o.alarmStore),
+		}
+		o.subscriptions[zoneIdentifier] = s
+	}
+	registered := s.heartbeat == nil
+	if registered == true {
+		s.heartbeat = newHeartbeatMonitor(interval, now)
+		s.alarmLogger.ClearDomain("heartbeat", now)
+	}
+	update := s.heartbeat.beat(now)
+	if o.heartbeats.Map[zoneIdentifier] != interval {
+		s.heartbeat.interval = interval
+		o.heartbeats.Map[zoneIdentifier] = interval
+		err = o.heartbeats.SaveKey(zoneIdentifier)
+	}
+	if update != nil {
+		o.pushHeartbeatAlarm(s, update)
+	}
+	o.mx.Unlock()
+
+	if registered == true || update != nil {
+		o.log.WithContext(ctx).WithFields(logrus.Fields{
+			"zone":     zoneIdentifier,
+			"interval": interval,
+		}).Info("heartbeats received")
+		o.serviceLogger.Log(ctx, zoneIdentifier+".heartbeat", true, true)
+	}
+	if update != nil {
+		o.publishAlarms(host, zone, []*api.AlarmUpdate{update})
+	}
+	if registered == true || update != nil {
+		o.publishZoneSummary(host, zone)
+	}
+	return err
+}
+
+func (o *Olympus) unregisterHeartbeat(ctx context.Context, host, zone string) error {
+	zoneIdentifier := ZoneIdentifier(host, zone)
+	o.mx.Lock()
+	if o.subscriptions == nil {
+		o.mx.Unlock()
+		return ClosedOlympusServerError{}
+	}
+	s := o.subscriptions[zoneIdentifier]
+	if s == nil || s.heartbeat == nil {
+		o.mx.Unlock()
+		return ZoneNotFoundError(zoneIdentifier)
+	}
+	// clears a missed heartbeat alarm.
+	update := s.heartbeat.beat(time.Now())
+	s.heartbeat = nil
+	delete(o.heartbeats.Map, zoneIdentifier)
+	err := o.heartbeats.DeleteKey(zoneIdentifier)
+	if update != nil {
+		o.pushHeartbeatAlarm(s, update)
+	}
+	if s.climate == nil && s.tracking == nil && s.external == false {
+		o.removeSubscription(zoneIdentifier)
+	}
+	o.mx.Unlock()
+
+	if update != nil {
+		o.publishAlarms(host, zone, []*api.AlarmUpdate{update})
+	}
+	o.log.WithContext(ctx).WithField("zone", zoneIdentifier).Info("heartbeats unregistered")
+	o.serviceLogger.Log(ctx, zoneIdentifier+".heartbeat", false, true)
+	o.publishZoneSummary(host, zone)
+	return err
+}
+
+// pushHeartbeatAlarm logs and notifies a heartbeat alarm update. As
+// Close closes o.unfilteredAlarms, it must be called with o.mx held
+// after checking o.subscriptions, or from a goroutine Close waits for.
+func (o *Olympus) pushHeartbeatAlarm(s *subscription, update *api.AlarmUpdate) {
+	s.alarmLogger.PushAlarms([]*api.AlarmUpdate{update}, "heartbeat")
+	o.unfilteredAlarms <- ZonedAlarmUpdate{Zone: ZoneIdentifier(s.host, s.name), Update: update}
+}
+
+func (o *Olympus) watchHeartbeats(ctx context.Context) {
+	ticker := time.NewTicker(HeartbeatCheckPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			o.checkHeartbeats(ctx, now)
+		}
+	}
+}
+
+// checkHeartbeats raises the missed heartbeat alarm of all virtual
+// zones which stopped sending heartbeats.
+func (o *Olympus) checkHeartbeats(ctx context.Context, now time.Time) {
+	type missed struct {
+		s      *subscription
+		update *api.AlarmUpdate
+	}
+	var changed []missed
+
+	o.mx.Lock()
+	if o.subscriptions == nil {
+		o.mx.Unlock()
+		return
+	}
+	for _, s := range o.subscriptions {
+		if s.heartbeat == nil {
+			continue
+		}
+		if update := s.heartbeat.check(now); update != nil {
+			changed = append(changed, missed{s: s, update: update})
+		}
+	}
+	o.mx.Unlock()
+
+	// pushing outside of the lock is safe, as watchHeartbeats is
+	// tracked by subscriptionWg, which Close waits for before closing
+	// o.unfilteredAlarms.
+	for _, c := range changed {
+		zoneIdentifier := ZoneIdentifier(c.s.host, c.s.name)
+		o.log.WithContext(ctx).WithField("zone", zoneIdentifier).Warn("heartbeats missed")
+		o.serviceLogger.Log(ctx, zoneIdentifier+".heartbeat", false, false)
+		o.pushHeartbeatAlarm(c.s, c.update)
+		o.publishAlarms(c.s.host, c.s.name, []*api.AlarmUpdate{c.update})
+		o.publishZoneSummary(c.s.host, c.s.name)
+	}
+}
@@ -0,0 +1,156 @@
+package olympus
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"github.com/formicidae-tracker/olympus/pkg/tm"
+	"github.com/sirupsen/logrus"
+)
+
+type MaintenanceWindowNotFoundError string
+
+func (e MaintenanceWindowNotFoundError) Error() string {
+	return fmt.Sprintf("olympus: unknown maintenance window '%s'", string(e))
+}
+
+type InvalidMaintenanceWindowError string
+
+func (e InvalidMaintenanceWindowError) Error() string {
+	return fmt.Sprintf("olympus: invalid maintenance window: %s", string(e))
+}
+
+// A MaintenanceSchedule holds the maintenance windows during which
+// alarm notifications are silenced.
+type MaintenanceSchedule interface {
+	// Add schedules a new window and returns it with its ID set.
+	Add(window api.MaintenanceWindow) (api.MaintenanceWindow, error)
+	// Remove removes a window, it may return a
+	// MaintenanceWindowNotFoundError.
+	Remove(id string) error
+	// Windows lists all windows that are not over, sorted by start
+	// time.
+	Windows() []api.MaintenanceWindow
+	// Silenced returns true if an alarm of a zone is silenced at t.
+	Silenced(zone, identification string, t time.Time) bool
+	// Active returns the window covering the whole zone at t or, if
+	// none, any window of the zone active at t. It returns nil if
+	// the zone is not in maintenance.
+	Active(zone string, t time.Time) *api.MaintenanceWindow
+}
+
+type maintenanceSchedule struct {
+	mx      sync.RWMutex
+	windows *PersistentMap[api.MaintenanceWindow]
+	log     *logrus.Entry
+}
+
+// NewMaintenanceSchedule creates a MaintenanceSchedule persisted in
+// the data directory.
+func NewMaintenanceSchedule(name string) MaintenanceSchedule {
+	return &maintenanceSchedule{
+		windows: NewPersistentMap[api.MaintenanceWindow](name),
+		log:     tm.NewLogger("maintenance"),
+	}
+}
+
+func (s *maintenanceSchedule) Add(window api.MaintenanceWindow) (api.MaintenanceWindow, error) {
+	if len(window.Zone) == 0 {
+		return window, InvalidMaintenanceWindowError("missing zone")
+	}
+	if window.End.After(window.Start) == false {
+		return window, InvalidMaintenanceWindowError("end must be after start")
+	}
+	if window.End.Before(time.Now()) == true {
+		return window, InvalidMaintenanceWindowError("window is already over")
+	}
+
+	var err error
+	window.ID, err = newRandomID(8)
+	if err != nil {
+		return window, err
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.prune(time.Now())
+	s.windows.Map[window.ID] = window
+	return window, s.windows.SaveKey(window.ID)
+}
+
+func (s *maintenanceSchedule) Remove(id string) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if _, ok := s.windows.Map[id]; ok == false {
+		return MaintenanceWindowNotFoundError(id)
+	}
+	return s.windows.DeleteKey(id)
+}
+
+// prune removes all windows that are over. It must be called with
+// the lock held.
+func (s *maintenanceSchedule) prune(now time.Time) {
+	for id, w := range s.windows.Map {
+		if w.End.After(now) == true {
+			continue
+		}
+		if err := s.windows.DeleteKey(id); err != nil {
+			s.log.WithError(err).WithField("id", id).Warn("could not remove maintenance window")
+		}
+	}
+}
+
+func (s *maintenanceSchedule) Windows() []api.MaintenanceWindow {
+	now := time.Now()
+
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	res := make([]api.MaintenanceWindow, 0, len(s.windows.Map))
+	for _, w := range s.windows.Map {
+		if w.End.After(now) == true {
+			res = append(res, w)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Start.Equal(res[j].Start) {
+			return res[i].ID < res[j].ID
+		}
+		return res[i].Start.Before(res[j].Start)
+	})
+	return res
+}
+
+func (s *maintenanceSchedule) Silenced(zone, identification string, t time.Time) bool {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	for _, w := range s.windows.Map {
+		if w.Silences(zone, identification, t) == true {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *maintenanceSchedule) Active(zone string, t time.Time) *api.MaintenanceWindow {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	var res *api.MaintenanceWindow
+	for _, w := range s.windows.Map {
+		if w.Zone != zone || w.Active(t) == false {
+			continue
+		}
+		if res == nil || (len(w.AlarmPrefix) == 0 && len(res.AlarmPrefix) > 0) {
+			window := w
+			res = &window
+		}
+	}
+	return res
+}
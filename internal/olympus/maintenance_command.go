@@ -0,0 +1,215 @@
+package olympus
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+)
+
+type MaintenanceCommand struct{}
+
+type MaintenanceClientOptions struct {
+	URL      string `short:"u" long:"url" description:"URL of the olympus HTTP server" env:"OLYMPUS_URL" default:"http://localhost:3000"`
+	User     string `long:"user" description:"user to authenticate as" env:"OLYMPUS_USER"`
+	Password string `long:"password" description:"password of the user" env:"OLYMPUS_PASSWORD"`
+}
+
+// get performs an authenticated GET request.
+func (o MaintenanceClientOptions) get(path string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", o.URL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	o.authenticate(req)
+	return http.DefaultClient.Do(req)
+}
+
+func (o MaintenanceClientOptions) authenticate(req *http.Request) {
+	if len(o.User) > 0 {
+		req.SetBasicAuth(o.User, o.Password)
+	}
+}
+
+type ListMaintenanceCommand struct {
+	MaintenanceClientOptions
+}
+
+type AddMaintenanceCommand struct {
+	MaintenanceClientOptions
+
+	Zone     string        `short:"z" long:"zone" description:"zone identifier, i.e. 'host.zone'" required:"yes"`
+	Alarm    string        `short:"a" long:"alarm" description:"only silence alarms starting with this identification prefix, i.e. 'climate.temperature'"`
+	From     string        `long:"from" description:"RFC3339 start time of the window, default to now"`
+	To       string        `long:"to" description:"RFC3339 end time of the window"`
+	Duration time.Duration `short:"d" long:"duration" description:"duration of the window, if --to is not set" default:"1h"`
+	Reason   string        `short:"m" long:"reason" description:"reason for the maintenance"`
+}
+
+type RemoveMaintenanceCommand struct {
+	MaintenanceClientOptions
+
+	Args struct {
+		ID string `positional-arg-name:"id" description:"maintenance window ID"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+// xsrfToken fetches a CSRF token from the maintenance listing, which
+// is needed by any mutating request.
+func (o MaintenanceClientOptions) xsrfToken() (*http.Cookie, error) {
+	resp, err := o.get("/api/maintenance")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+	for _, c := range resp.Cookies() {
+		if c.Name == "XSRF-TOKEN" {
+			return c, nil
+		}
+	}
+	return nil, errors.New("server did not send a XSRF-TOKEN cookie")
+}
+
+func (o MaintenanceClientOptions) do(method, path string, body interface{}, res interface{}) error {
+	token, err := o.xsrfToken()
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, o.URL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-XSRF-TOKEN", token.Value)
+	req.AddCookie(&http.Cookie{Name: token.Name, Value: token.Value})
+	o.authenticate(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+	if res == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(res)
+}
+
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	msg, _ := io.ReadAll(resp.Body)
+	return fmt.Errorf("%s %s: %s: %s",
+		resp.Request.Method, resp.Request.URL,
+		resp.Status, strings.TrimSpace(string(msg)))
+}
+
+func printMaintenanceWindows(w io.Writer, windows []api.MaintenanceWindow) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tZONE\tALARMS\tSTART\tEND\tREASON")
+	for _, m := range windows {
+		prefix := m.AlarmPrefix
+		if len(prefix) == 0 {
+			prefix = "*"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			m.ID, m.Zone, prefix,
+			m.Start.Local().Format(time.RFC3339),
+			m.End.Local().Format(time.RFC3339),
+			m.Reason)
+	}
+	return tw.Flush()
+}
+
+func (c *ListMaintenanceCommand) Execute([]string) error {
+	resp, err := c.get("/api/maintenance")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+	var windows []api.MaintenanceWindow
+	if err := json.NewDecoder(resp.Body).Decode(&windows); err != nil {
+		return err
+	}
+	return printMaintenanceWindows(os.Stdout, windows)
+}
+
+func (c *AddMaintenanceCommand) window(now time.Time) (api.MaintenanceWindow, error) {
+	res := api.MaintenanceWindow{
+		Zone:        c.Zone,
+		AlarmPrefix: c.Alarm,
+		Reason:      c.Reason,
+	}
+	var err error
+	res.Start, err = parseCommandTime(c.From, "from")
+	if err != nil {
+		return res, err
+	}
+	if res.Start.IsZero() {
+		res.Start = now
+	}
+	res.End, err = parseCommandTime(c.To, "to")
+	if err != nil {
+		return res, err
+	}
+	if res.End.IsZero() {
+		res.End = res.Start.Add(c.Duration)
+	}
+	return res, nil
+}
+
+func (c *AddMaintenanceCommand) Execute([]string) error {
+	window, err := c.window(time.Now())
+	if err != nil {
+		return err
+	}
+	if err := c.do("POST", "/api/maintenance", window, &window); err != nil {
+		return err
+	}
+	return printMaintenanceWindows(os.Stdout, []api.MaintenanceWindow{window})
+}
+
+func (c *RemoveMaintenanceCommand) Execute([]string) error {
+	return c.do("DELETE", "/api/maintenance/"+url.PathEscape(c.Args.ID), nil, nil)
+}
+
+func init() {
+	cmd, err := parser.AddCommand("maintenance",
+		"manages maintenance windows.",
+		"manages maintenance windows of a running olympus service. Alarm notifications are silenced during a window, but alarms are still logged.",
+		&MaintenanceCommand{})
+	if err != nil {
+		panic(err.Error())
+	}
+	cmd.AddCommand("list", "lists maintenance windows", "lists all current and future maintenance windows", &ListMaintenanceCommand{})
+	cmd.AddCommand("add", "adds a maintenance window", "silences the alarm notifications of a zone during a time range", &AddMaintenanceCommand{})
+	cmd.AddCommand("remove", "removes a maintenance window", "removes a maintenance window by its ID", &RemoveMaintenanceCommand{})
+}
@@ -0,0 +1,96 @@
+package olympus
+
+import (
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	. "gopkg.in/check.v1"
+)
+
+type MaintenanceSuite struct {
+	datapath string
+	now      time.Time
+	s        MaintenanceSchedule
+}
+
+var _ = Suite(&MaintenanceSuite{})
+
+func (s *MaintenanceSuite) SetUpSuite(c *C) {
+	s.datapath = _datapath
+}
+
+func (s *MaintenanceSuite) TearDownSuite(c *C) {
+	_datapath = s.datapath
+}
+
+func (s *MaintenanceSuite) SetUpTest(c *C) {
+	_datapath = c.MkDir()
+	s.now = time.Now().Round(0)
+	s.s = NewMaintenanceSchedule("maintenance")
+}
+
+func (s *MaintenanceSuite) TestValidatesWindows(c *C) {
+	testdata := []struct {
+		Window api.MaintenanceWindow
+		Error  string
+	}{
+		{
+			api.MaintenanceWindow{Start: s.now, End: s.now.Add(time.Hour)},
+			"olympus: invalid maintenance window: missing zone",
+		},
+		{
+			api.MaintenanceWindow{Zone: "foo.box", Start: s.now, End: s.now},
+			"olympus: invalid maintenance window: end must be after start",
+		},
+		{
+			api.MaintenanceWindow{Zone: "foo.box", Start: s.now.Add(-2 * time.Hour), End: s.now.Add(-time.Hour)},
+			"olympus: invalid maintenance window: window is already over",
+		},
+	}
+	for _, d := range testdata {
+		_, err := s.s.Add(d.Window)
+		c.Check(err, ErrorMatches, d.Error)
+	}
+	c.Check(s.s.Windows(), HasLen, 0)
+}
+
+func (s *MaintenanceSuite) TestSilencesAlarms(c *C) {
+	zoneWide, err := s.s.Add(api.MaintenanceWindow{
+		Zone:  "foo.box",
+		Start: s.now.Add(time.Hour),
+		End:   s.now.Add(2 * time.Hour),
+	})
+	c.Assert(err, IsNil)
+	c.Check(zoneWide.ID, Not(Equals), "")
+	_, err = s.s.Add(api.MaintenanceWindow{
+		Zone:        "foo.box",
+		AlarmPrefix: "climate.temperature",
+		Start:       s.now,
+		End:         s.now.Add(90 * time.Minute),
+	})
+	c.Assert(err, IsNil)
+
+	c.Check(s.s.Silenced("foo.box", "climate.temperature.out-of-bound", s.now), Equals, true)
+	c.Check(s.s.Silenced("foo.box", "climate.humidity.out-of-bound", s.now), Equals, false)
+	c.Check(s.s.Silenced("bar.box", "climate.temperature.out-of-bound", s.now), Equals, false)
+	c.Check(s.s.Silenced("foo.box", "climate.humidity.out-of-bound", s.now.Add(time.Hour)), Equals, true)
+	c.Check(s.s.Silenced("foo.box", "climate.humidity.out-of-bound", s.now.Add(2*time.Hour)), Equals, false)
+
+	active := s.s.Active("foo.box", s.now)
+	c.Assert(active, NotNil)
+	c.Check(active.AlarmPrefix, Equals, "climate.temperature")
+	// prefers the zone wide window
+	c.Check(s.s.Active("foo.box", s.now.Add(80*time.Minute)), DeepEquals, &zoneWide)
+	c.Check(s.s.Active("bar.box", s.now), IsNil)
+
+	restored := NewMaintenanceSchedule("maintenance")
+	windows := restored.Windows()
+	c.Assert(windows, HasLen, 2)
+	c.Check(windows[1].ID, Equals, zoneWide.ID)
+	c.Check(windows[1].Start.Equal(zoneWide.Start), Equals, true)
+	c.Check(windows[1].End.Equal(zoneWide.End), Equals, true)
+
+	c.Check(restored.Remove(zoneWide.ID), IsNil)
+	c.Check(restored.Remove(zoneWide.ID), Equals, MaintenanceWindowNotFoundError(zoneWide.ID))
+	c.Check(NewMaintenanceSchedule("maintenance").Windows(), HasLen, 1)
+}
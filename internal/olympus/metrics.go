@@ -0,0 +1,212 @@
+package olympus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"github.com/gorilla/mux"
+)
+
+type metricSample struct {
+	labels string
+	value  float64
+}
+
+// A metricFamily is a set of samples of a single metric, written in
+// the Prometheus text exposition format.
+type metricFamily struct {
+	name, help, kind string
+	samples          []metricSample
+}
+
+func newGauge(name, help string) *metricFamily {
+	return &metricFamily{name: name, help: help, kind: "gauge"}
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// add adds a sample with labels given as name and value pairs.
+func (f *metricFamily) add(value float64, labels ...string) {
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`,
+			labels[i], labelValueEscaper.Replace(labels[i+1])))
+	}
+	f.samples = append(f.samples, metricSample{
+		labels: strings.Join(pairs, ","),
+		value:  value,
+	})
+}
+
+// addOptional adds a sample only if value is not nil.
+func (f *metricFamily) addOptional(value *float32, labels ...string) {
+	if value == nil {
+		return
+	}
+	f.add(float64(*value), labels...)
+}
+
+func (f *metricFamily) write(w io.Writer) error {
+	if len(f.samples) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, f.kind); err != nil {
+		return err
+	}
+	for _, s := range f.samples {
+		value := strconv.FormatFloat(s.value, 'g', -1, 64)
+		if _, err := fmt.Fprintf(w, "%s{%s} %s\n", f.name, s.labels, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type zoneMetrics struct {
+	temperature, temperatureAux, humidity         *metricFamily
+	temperatureBound, humidityBound               *metricFamily
+	targetTemperature, targetHumidity, targetWind *metricFamily
+	targetVisibleLight, targetUVLight             *metricFamily
+	activeAlarms                                  *metricFamily
+	diskFree, diskTotal, diskBytesPerSecond       *metricFamily
+	serviceUp                                     *metricFamily
+}
+
+func newZoneMetrics() *zoneMetrics {
+	return &zoneMetrics{
+		temperature:        newGauge("olympus_zone_temperature_celsius", "Current temperature of the zone."),
+		temperatureAux:     newGauge("olympus_zone_aux_temperature_celsius", "Current temperature of the auxiliary sensors of the zone."),
+		humidity:           newGauge("olympus_zone_humidity_percent", "Current relative humidity of the zone."),
+		temperatureBound:   newGauge("olympus_zone_temperature_bound_celsius", "Temperature bounds of the zone."),
+		humidityBound:      newGauge("olympus_zone_humidity_bound_percent", "Relative humidity bounds of the zone."),
+		targetTemperature:  newGauge("olympus_zone_target_temperature_celsius", "Current temperature target of the zone."),
+		targetHumidity:     newGauge("olympus_zone_target_humidity_percent", "Current relative humidity target of the zone."),
+		targetWind:         newGauge("olympus_zone_target_wind_percent", "Current wind target of the zone."),
+		targetVisibleLight: newGauge("olympus_zone_target_visible_light_percent", "Current visible light target of the zone."),
+		targetUVLight:      newGauge("olympus_zone_target_uv_light_percent", "Current UV light target of the zone."),
+		activeAlarms:       newGauge("olympus_zone_active_alarms", "Number of active alarms of the zone by level."),
+		diskFree:           newGauge("olympus_tracking_disk_free_bytes", "Free space on the tracking disk."),
+		diskTotal:          newGauge("olympus_tracking_disk_total_bytes", "Total space on the tracking disk."),
+		diskBytesPerSecond: newGauge("olympus_tracking_disk_write_bytes_per_second", "Rate at which the tracking disk is filled."),
+		serviceUp:          newGauge("olympus_service_up", "Whether a service of a zone is running (1) or stopped (0)."),
+	}
+}
+
+func (m *zoneMetrics) families() []*metricFamily {
+	return []*metricFamily{
+		m.temperature, m.temperatureAux, m.humidity,
+		m.temperatureBound, m.humidityBound,
+		m.targetTemperature, m.targetHumidity, m.targetWind,
+		m.targetVisibleLight, m.targetUVLight,
+		m.activeAlarms,
+		m.diskFree, m.diskTotal, m.diskBytesPerSecond,
+		m.serviceUp,
+	}
+}
+
+func (m *zoneMetrics) addClimate(host, zone string, report *api.ZoneClimateReport) {
+	m.temperature.addOptional(report.Temperature, "host", host, "zone", zone)
+	for i, t := range report.TemperatureAux {
+		m.temperatureAux.add(float64(t), "host", host, "zone", zone, "sensor", auxSensorName(report, i+1))
+	}
+	m.humidity.addOptional(report.Humidity, "host", host, "zone", zone)
+
+	m.temperatureBound.addOptional(report.TemperatureBounds.Minimum, "host", host, "zone", zone, "bound", "minimum")
+	m.temperatureBound.addOptional(report.TemperatureBounds.Maximum, "host", host, "zone", zone, "bound", "maximum")
+	m.humidityBound.addOptional(report.HumidityBounds.Minimum, "host", host, "zone", zone, "bound", "minimum")
+	m.humidityBound.addOptional(report.HumidityBounds.Maximum, "host", host, "zone", zone, "bound", "maximum")
+
+	if report.Current == nil {
+		return
+	}
+	m.targetTemperature.addOptional(report.Current.Temperature, "host", host, "zone", zone)
+	m.targetHumidity.addOptional(report.Current.Humidity, "host", host, "zone", zone)
+	m.targetWind.addOptional(report.Current.Wind, "host", host, "zone", zone)
+	m.targetVisibleLight.addOptional(report.Current.VisibleLight, "host", host, "zone", zone)
+	m.targetUVLight.addOptional(report.Current.UvLight, "host", host, "zone", zone)
+}
+
+// auxSensorName returns the declared name of the temperature sensor
+// at index, or the index itself if it has no name.
+func auxSensorName(report *api.ZoneClimateReport, index int) string {
+	if index < len(report.TemperatureSensors) && len(report.TemperatureSensors[index].Name) > 0 {
+		return report.TemperatureSensors[index].Name
+	}
+	return strconv.Itoa(index)
+}
+
+func (m *zoneMetrics) addTracking(host, zone string, info *api.TrackingInfo) {
+	m.diskFree.add(float64(info.FreeBytes), "host", host, "zone", zone)
+	m.diskTotal.add(float64(info.TotalBytes), "host", host, "zone", zone)
+	m.diskBytesPerSecond.add(float64(info.BytesPerSecond), "host", host, "zone", zone)
+}
+
+func (m *zoneMetrics) addAlarms(host, zone string, logger AlarmLogger) {
+	failures, emergencies, warnings := logger.ActiveAlarmsCount()
+	m.activeAlarms.add(float64(failures), "host", host, "zone", zone, "level", "failure")
+	m.activeAlarms.add(float64(emergencies), "host", host, "zone", zone, "level", "emergency")
+	m.activeAlarms.add(float64(warnings), "host", host, "zone", zone, "level", "warning")
+}
+
+func (m *zoneMetrics) addServices(services []string, up bool) {
+	value := 0.0
+	if up == true {
+		value = 1.0
+	}
+	for _, identifier := range services {
+		host, zone, service := splitServiceIdentifier(identifier)
+		m.serviceUp.add(value, "host", host, "zone", zone, "service", service)
+	}
+}
+
+// collectMetrics gathers the current state of all zones.
+func (o *Olympus) collectMetrics() []*metricFamily {
+	res := newZoneMetrics()
+
+	o.mx.RLock()
+	identifiers := make([]string, 0, len(o.subscriptions))
+	for identifier := range o.subscriptions {
+		identifiers = append(identifiers, identifier)
+	}
+	sort.Strings(identifiers)
+	for _, identifier := range identifiers {
+		s := o.subscriptions[identifier]
+		if s.climate != nil {
+			res.addClimate(s.host, s.name, s.climate.object.GetClimateReport())
+		}
+		if s.tracking != nil {
+			res.addTracking(s.host, s.name, s.tracking.object.TrackingInfo())
+		}
+		if s.alarmLogger != nil {
+			res.addAlarms(s.host, s.name, s.alarmLogger)
+		}
+	}
+	o.mx.RUnlock()
+
+	res.addServices(o.serviceLogger.OnServices(), true)
+	res.addServices(o.serviceLogger.OffServices(), false)
+
+	return res.families()
+}
+
+func (o *Olympus) setMetricsRoutes(router *mux.Router) {
+	router.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+
+		buffered := bufio.NewWriter(w)
+		for _, f := range o.collectMetrics() {
+			if err := f.write(buffered); err != nil {
+				o.log.WithError(err).Error("could not write metrics")
+				return
+			}
+		}
+		buffered.Flush()
+	}).Methods("GET")
+}
@@ -7,9 +7,9 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/SherClockHolmes/webpush-go"
 	"github.com/formicidae-tracker/olympus/pkg/api"
@@ -19,8 +19,12 @@ import (
 	"golang.org/x/text/language"
 )
 
+// NotificationFor is a batch of updates to send either to a web push
+// Subscription, an Email address or a Webhook.
 type NotificationFor struct {
 	Subscription *webpush.Subscription
+	Email        string
+	Webhook      *api.Webhook
 	Updates      []ZonedAlarmUpdate
 }
 
@@ -28,6 +32,15 @@ type NotificationSender interface {
 	Send(NotificationFor) error
 }
 
+// closeNotificationSender waits for the pending deliveries of a
+// NotificationSender delivering asynchronously, i.e. implementing
+// io.Closer.
+func closeNotificationSender(s NotificationSender) {
+	if c, ok := s.(io.Closer); ok == true {
+		c.Close()
+	}
+}
+
 type logNotification struct {
 	Logs []NotificationFor
 }
@@ -105,12 +118,40 @@ func getBadgeURL(level api.AlarmLevel) string {
 	return "/assets/badge.png"
 }
 
+// formatAlarmDuration formats a duration for humans, i.e. "12 min".
+func formatAlarmDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < time.Minute {
+		return "less than a minute"
+	}
+	if d < time.Hour {
+		return fmt.Sprintf("%d min", int(d.Minutes()))
+	}
+	hours := int(d.Hours())
+	minutes := int((d - time.Duration(hours)*time.Hour).Minutes())
+	if minutes == 0 {
+		return fmt.Sprintf("%d h", hours)
+	}
+	return fmt.Sprintf("%d h %d min", hours, minutes)
+}
+
+func buildSingleTitle(update ZonedAlarmUpdate) string {
+	level := cases.Title(language.English).String(update.Update.Level.String())
+	if update.Resolution != nil {
+		return fmt.Sprintf("%s on %s resolved after %s",
+			level, update.Zone, formatAlarmDuration(update.Resolution.Duration()))
+	}
+	if update.Escalation != nil {
+		return fmt.Sprintf("Unacknowledged %s on %s for %s",
+			level, update.Zone, update.Escalation.After)
+	}
+	return fmt.Sprintf("One %s on %s", level, update.Zone)
+}
+
 func NewSingleWebPushNotification(update ZonedAlarmUpdate) WebPushNotification {
 	return WebPushNotification{
-		Title: fmt.Sprintf("One %s on %s",
-			cases.Title(language.English).String(update.Update.Level.String()),
-			update.Zone),
-		Body: update.Update.Description,
+		Title: buildSingleTitle(update),
+		Body:  update.Update.Description,
 		Data: WebPushData{
 			OnActionClick: map[string]WebPushTargetAction{
 				"default": {
@@ -126,14 +167,14 @@ func NewSingleWebPushNotification(update ZonedAlarmUpdate) WebPushNotification {
 }
 
 func NewMultiWebPushNotification(updates []ZonedAlarmUpdate) WebPushNotification {
-	zones, emergencies, warnings := collectInfos(updates)
+	zones, emergencies, warnings, resolved := collectInfos(updates)
 	level := api.AlarmLevel_EMERGENCY
 	if emergencies == 0 {
 		level = api.AlarmLevel_WARNING
 	}
 	actions, data := buildMultiActions(zones)
 	return WebPushNotification{
-		Title:   buildMultiTitle(emergencies, warnings),
+		Title:   buildMultiTitle(emergencies, warnings, resolved),
 		Body:    buildMultiBody(zones),
 		Actions: actions,
 		Data:    data,
@@ -189,12 +230,15 @@ func buildMultiActions(zones []string) ([]WebPushAction, WebPushData) {
 	return actions, data
 }
 
-func collectInfos(updates []ZonedAlarmUpdate) (zones []string, emergencies int, warnings int) {
+func collectInfos(updates []ZonedAlarmUpdate) (zones []string, emergencies int, warnings int, resolved int) {
 	zonesSet := map[string]bool{}
 	warnings = 0
 	emergencies = 0
+	resolved = 0
 	for _, u := range updates {
-		if u.Update.Level == api.AlarmLevel_WARNING {
+		if u.Resolution != nil {
+			resolved++
+		} else if u.Update.Level == api.AlarmLevel_WARNING {
 			warnings++
 		} else {
 			emergencies++
@@ -211,8 +255,21 @@ func collectInfos(updates []ZonedAlarmUpdate) (zones []string, emergencies int,
 	return
 }
 
-func buildMultiTitle(emergencies, warnings int) string {
+func buildMultiTitle(emergencies, warnings, resolved int) string {
+	if resolved == 0 {
+		return buildNewAlarmsTitle(emergencies, warnings)
+	}
+	if emergencies == 0 && warnings == 0 {
+		return fmt.Sprintf("%d Alarms Resolved", resolved)
+	}
+	return fmt.Sprintf("%s, %d Resolved", buildNewAlarmsTitle(emergencies, warnings), resolved)
+}
+
+func buildNewAlarmsTitle(emergencies, warnings int) string {
 	if emergencies == 0 {
+		if warnings == 1 {
+			return "1 New Warning"
+		}
 		return fmt.Sprintf("%d New Warnings", warnings)
 	}
 	if emergencies == 1 {
@@ -256,25 +313,14 @@ func BuildNotificationPayload(updates []ZonedAlarmUpdate) ([]byte, error) {
 }
 
 func NewNotificationSender() (NotificationSender, error) {
-	private := os.Getenv("OLYMPUS_VAPID_PRIVATE")
-	public := os.Getenv("OLYMPUS_VAPID_PUBLIC")
-	subscriber := os.Getenv("OLYMPUS_PUSH_SUBSCRIBER")
-
-	if len(private) == 0 || len(public) == 0 || len(subscriber) == 0 {
-
-		return discardNotification{}, errors.New("missing OLYMPUS_VAPID_PUBLIC,OLYMPUS_VAPID_PRIVATE or OLYMPUS_PUSH_SUBSCRIBER")
+	if WebPush.Enabled() == false {
+		return discardNotification{}, errors.New("missing web push public key, private key or subscriber")
 	}
 
 	res := &webpushSender{
-		subscriber: subscriber,
-		public:     public,
-		private:    private,
-	}
-
-	logger := logrus.New()
-
-	if len(os.Getenv("OLYMPUS_DEBUG_WEBPUSH")) == 0 {
-		logger.SetOutput(io.Discard)
+		subscriber: WebPush.Subscriber,
+		public:     WebPush.PublicKey,
+		private:    WebPush.PrivateKey,
 	}
 
 	res.log = tm.NewLogger("webpush")
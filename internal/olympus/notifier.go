@@ -2,6 +2,11 @@ package olympus
 
 import (
 	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -34,6 +39,14 @@ type Notifier interface {
 	RegisterPushSubscription(*webpush.Subscription) error
 	UpdatePushSubscription(*api.NotificationSettingsUpdate) error
 
+	RegisterEmailSubscription(*api.EmailSubscription) error
+	UnregisterEmailSubscription(address string) error
+
+	RegisterWebhook(*api.Webhook) (*api.Webhook, error)
+	UpdateWebhookSettings(id string, settings api.NotificationSettings) error
+	UnregisterWebhook(id string) error
+	Webhooks() []api.Webhook
+
 	Loop()
 }
 
@@ -41,11 +54,23 @@ type zoneRegistration struct {
 	potentialEndpoints map[string]bool
 }
 
+// A NotificationSubscription is either a web push subscription, an
+// email address or a webhook.
 type NotificationSubscription struct {
-	Push     *webpush.Subscription
+	Push     *webpush.Subscription `json:",omitempty"`
+	Email    string                `json:",omitempty"`
+	Webhook  *api.Webhook          `json:",omitempty"`
 	Settings api.NotificationSettings
 }
 
+func emailKey(address string) string {
+	return "mailto:" + address
+}
+
+func webhookKey(id string) string {
+	return "webhook:" + id
+}
+
 type notifier struct {
 	mx                   sync.RWMutex
 	incoming             chan ZonedAlarmUpdate
@@ -59,6 +84,18 @@ type notifier struct {
 
 	outgoing map[string]chan<- ZonedAlarmUpdate
 
+	// queuesMx protects queues, which lists the updates waiting to
+	// be handled for each alarm ID. Updates of the same alarm are
+	// handled in order by a single goroutine, tracked by handlers.
+	queuesMx sync.Mutex
+	queues   map[string][]ZonedAlarmUpdate
+	handlers sync.WaitGroup
+
+	// notifiedMx protects notified, which lists, for each alarm ID,
+	// the subscriptions it was sent to.
+	notifiedMx sync.Mutex
+	notified   map[string]map[string]bool
+
 	batchPeriod time.Duration
 	log         *logrus.Entry
 }
@@ -69,12 +106,14 @@ func NewNotifier(batchPeriod time.Duration) Notifier {
 		incoming:             make(chan ZonedAlarmUpdate, 100),
 		subscriptions:        NewPersistentMap[*NotificationSubscription]("push-notifications"),
 		outgoing:             make(map[string]chan<- ZonedAlarmUpdate),
+		notified:             make(map[string]map[string]bool),
+		queues:               make(map[string][]ZonedAlarmUpdate),
 		outgoingNotification: make(chan NotificationFor, 100),
 		batchPeriod:          batchPeriod,
 		log:                  tm.NewLogger("notifications"),
 	}
-	for _, sub := range res.subscriptions.Map {
-		res.ensureOutgoing(sub.Push)
+	for key, sub := range res.subscriptions.Map {
+		res.ensureOutgoing(key, sub)
 	}
 	return res
 }
@@ -105,11 +144,12 @@ func (n *notifier) RegisterPushSubscription(s *webpush.Subscription) (err error)
 	n.mx.Lock()
 	defer n.mx.Unlock()
 
-	n.subscriptions.Map[s.Endpoint] = &NotificationSubscription{
+	sub := &NotificationSubscription{
 		Push: s,
 	}
+	n.subscriptions.Map[s.Endpoint] = sub
 
-	n.ensureOutgoing(s)
+	n.ensureOutgoing(s.Endpoint, sub)
 
 	return n.subscriptions.SaveKey(s.Endpoint)
 }
@@ -144,8 +184,176 @@ func (n *notifier) UpdatePushSubscription(update *api.NotificationSettingsUpdate
 	return n.subscriptions.SaveKey(update.Endpoint)
 }
 
+func (n *notifier) RegisterEmailSubscription(s *api.EmailSubscription) (err error) {
+	defer func() {
+		entry := n.log.WithField("email", s.Email)
+		if err != nil {
+			entry.WithField("error", err).Errorf("could not register email")
+		} else {
+			entry.Infof("new email subscription")
+		}
+	}()
+
+	address, err := mail.ParseAddress(s.Email)
+	if err != nil {
+		return fmt.Errorf("invalid email address: %w", err)
+	}
+
+	n.mx.Lock()
+	defer n.mx.Unlock()
+
+	key := emailKey(address.Address)
+	sub, ok := n.subscriptions.Map[key]
+	if ok == false {
+		sub = &NotificationSubscription{Email: address.Address}
+		n.subscriptions.Map[key] = sub
+		n.ensureOutgoing(key, sub)
+	}
+	sub.Settings = s.Settings
+
+	for zone, reg := range n.zones {
+		reg.potentialEndpoints[key] = MayBeSubscribedTo(zone, s.Settings)
+	}
+
+	return n.subscriptions.SaveKey(key)
+}
+
+func (n *notifier) UnregisterEmailSubscription(email string) error {
+	address, err := mail.ParseAddress(email)
+	if err != nil {
+		return fmt.Errorf("invalid email address: %w", err)
+	}
+	key := emailKey(address.Address)
+
+	n.mx.Lock()
+	defer n.mx.Unlock()
+
+	if _, ok := n.subscriptions.Map[key]; ok == false {
+		return UnknownEmailError
+	}
+
+	n.log.WithField("email", address.Address).Infof("removed email subscription")
+
+	return n.unregister(key)
+}
+
+// unregister removes a subscription. It must be called with the lock
+// held.
+func (n *notifier) unregister(key string) error {
+	for _, reg := range n.zones {
+		delete(reg.potentialEndpoints, key)
+	}
+	close(n.outgoing[key])
+	delete(n.outgoing, key)
+
+	return n.subscriptions.DeleteKey(key)
+}
+
+func (n *notifier) RegisterWebhook(w *api.Webhook) (res *api.Webhook, err error) {
+	defer func() {
+		entry := n.log.WithField("url", w.URL)
+		if err != nil {
+			entry.WithField("error", err).Errorf("could not register webhook")
+		} else {
+			entry.WithField("id", res.ID).Infof("new webhook")
+		}
+	}()
+
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return nil, fmt.Errorf("invalid webhook URL '%s': only absolute http(s) URLs are supported", w.URL)
+	}
+
+	webhook := &api.Webhook{
+		URL:      w.URL,
+		Secret:   w.Secret,
+		Settings: w.Settings,
+	}
+	webhook.ID, err = newRandomID(8)
+	if err != nil {
+		return nil, err
+	}
+	if len(webhook.Secret) == 0 {
+		webhook.Secret, err = newRandomID(32)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	n.mx.Lock()
+	defer n.mx.Unlock()
+
+	key := webhookKey(webhook.ID)
+	sub := &NotificationSubscription{Webhook: webhook, Settings: webhook.Settings}
+	n.subscriptions.Map[key] = sub
+	n.ensureOutgoing(key, sub)
+
+	for zone, reg := range n.zones {
+		reg.potentialEndpoints[key] = MayBeSubscribedTo(zone, sub.Settings)
+	}
+
+	res = &api.Webhook{}
+	*res = *webhook
+	return res, n.subscriptions.SaveKey(key)
+}
+
+func (n *notifier) UpdateWebhookSettings(id string, settings api.NotificationSettings) error {
+	n.mx.Lock()
+	defer n.mx.Unlock()
+
+	key := webhookKey(id)
+	sub, ok := n.subscriptions.Map[key]
+	if ok == false {
+		return UnknownWebhookError
+	}
+
+	sub.Settings = settings
+	for zone, reg := range n.zones {
+		reg.potentialEndpoints[key] = MayBeSubscribedTo(zone, settings)
+	}
+
+	return n.subscriptions.SaveKey(key)
+}
+
+func (n *notifier) UnregisterWebhook(id string) error {
+	n.mx.Lock()
+	defer n.mx.Unlock()
+
+	key := webhookKey(id)
+	if _, ok := n.subscriptions.Map[key]; ok == false {
+		return UnknownWebhookError
+	}
+
+	n.log.WithField("id", id).Infof("removed webhook")
+
+	return n.unregister(key)
+}
+
+func (n *notifier) Webhooks() []api.Webhook {
+	n.mx.RLock()
+	defer n.mx.RUnlock()
+
+	res := make([]api.Webhook, 0)
+	for _, sub := range n.subscriptions.Map {
+		if sub.Webhook == nil {
+			continue
+		}
+		res = append(res, api.Webhook{
+			ID:       sub.Webhook.ID,
+			URL:      sub.Webhook.URL,
+			Settings: sub.Settings,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res
+}
+
 func (n *notifier) Loop() {
 	defer func() {
+		n.handlers.Wait()
 
 		for _, ch := range n.outgoing {
 			close(ch)
@@ -161,11 +369,41 @@ func (n *notifier) Loop() {
 			if ok == false {
 				return
 			}
-			go n.handle(update)
+			n.dispatch(update)
 		}
 	}
 }
 
+// dispatch queues an update for handling, after all previous updates
+// of the same alarm, so a resolution never overtakes its alarm.
+func (n *notifier) dispatch(update ZonedAlarmUpdate) {
+	id := update.ID()
+	n.queuesMx.Lock()
+	queue, running := n.queues[id]
+	n.queues[id] = append(queue, update)
+	n.queuesMx.Unlock()
+	if running == true {
+		return
+	}
+
+	n.handlers.Add(1)
+	go func() {
+		defer n.handlers.Done()
+		for {
+			n.queuesMx.Lock()
+			queue := n.queues[id]
+			if len(queue) == 0 {
+				delete(n.queues, id)
+				n.queuesMx.Unlock()
+				return
+			}
+			n.queues[id] = queue[1:]
+			n.queuesMx.Unlock()
+			n.handle(queue[0])
+		}
+	}()
+}
+
 func (n *notifier) Register(zone string) zoneRegistration {
 	n.mx.Lock()
 	defer n.mx.Unlock()
@@ -196,8 +434,28 @@ func (n *notifier) getOrRegister(lock sync.Locker, zone string) zoneRegistration
 }
 
 func (n *notifier) handle(update ZonedAlarmUpdate) {
+	if update.Update == nil {
+		n.forgetZone(update.Zone)
+		return
+	}
+	if update.Resolution != nil {
+		n.handleResolution(update)
+		return
+	}
+	if update.Acknowledgement != nil ||
+		update.Update.Status == api.AlarmStatus_OFF {
+		// only used by the previous stages of the pipeline.
+		return
+	}
+
 	n.mx.RLock()
 	defer n.mx.RUnlock()
+
+	if update.Escalation != nil && update.Escalation.Group == true {
+		n.handleGroupEscalation(update)
+		return
+	}
+
 	reg := n.getOrRegister(n.mx.RLocker(), update.Zone)
 
 	for endpoint, maySend := range reg.potentialEndpoints {
@@ -205,13 +463,72 @@ func (n *notifier) handle(update ZonedAlarmUpdate) {
 			IsSubscribedTo(update.Zone,
 				update.Update.Level,
 				n.subscriptions.Map[endpoint].Settings) {
-			n.outgoing[endpoint] <- update
+			n.send(endpoint, update)
+		}
+	}
+}
+
+// send sends an update to a subscription and remembers it for its
+// resolution. It must be called with the lock held.
+func (n *notifier) send(endpoint string, update ZonedAlarmUpdate) {
+	n.notifiedMx.Lock()
+	id := update.ID()
+	if _, ok := n.notified[id]; ok == false {
+		n.notified[id] = make(map[string]bool)
+	}
+	n.notified[id][endpoint] = true
+	n.notifiedMx.Unlock()
+
+	n.outgoing[endpoint] <- update
+}
+
+// handleGroupEscalation sends an update to all subscriptions in the
+// escalation group, regardless of the zones they subscribed to. It
+// must be called with the lock held.
+func (n *notifier) handleGroupEscalation(update ZonedAlarmUpdate) {
+	for endpoint, sub := range n.subscriptions.Map {
+		if sub.Settings.NotifyOnEscalation == true {
+			n.send(endpoint, update)
 		}
 	}
 }
 
-func (n *notifier) ensureOutgoing(sub *webpush.Subscription) {
-	_, ok := n.outgoing[sub.Endpoint]
+// handleResolution notifies the subscriptions with NotifyOnResolve
+// that an alarm previously sent to them is resolved.
+func (n *notifier) handleResolution(update ZonedAlarmUpdate) {
+	n.notifiedMx.Lock()
+	endpoints := n.notified[update.ID()]
+	delete(n.notified, update.ID())
+	n.notifiedMx.Unlock()
+
+	n.mx.RLock()
+	defer n.mx.RUnlock()
+
+	for endpoint := range endpoints {
+		sub, ok := n.subscriptions.Map[endpoint]
+		if ok == false || sub.Settings.NotifyOnResolve == false {
+			continue
+		}
+		n.outgoing[endpoint] <- update
+	}
+}
+
+// forgetZone drops the notified alarms of a zone, as they will not
+// be resolved.
+func (n *notifier) forgetZone(zone string) {
+	n.notifiedMx.Lock()
+	defer n.notifiedMx.Unlock()
+
+	zone = AppendSuffix(zone, "/")
+	for id := range n.notified {
+		if strings.HasPrefix(id, zone) == true {
+			delete(n.notified, id)
+		}
+	}
+}
+
+func (n *notifier) ensureOutgoing(key string, sub *NotificationSubscription) {
+	_, ok := n.outgoing[key]
 	if ok == true {
 		return
 	}
@@ -225,12 +542,17 @@ func (n *notifier) ensureOutgoing(sub *webpush.Subscription) {
 		BatchAlarmUpdate(n.batchPeriod)(filtered, unfiltered)
 	}()
 
-	go func(sub *webpush.Subscription) {
+	go func(sub *NotificationSubscription) {
 		defer n.wg.Done()
 		for u := range filtered {
-			n.outgoingNotification <- NotificationFor{Subscription: sub, Updates: u}
+			n.outgoingNotification <- NotificationFor{
+				Subscription: sub.Push,
+				Email:        sub.Email,
+				Webhook:      sub.Webhook,
+				Updates:      u,
+			}
 		}
 	}(sub)
 
-	n.outgoing[sub.Endpoint] = unfiltered
+	n.outgoing[key] = unfiltered
 }
@@ -1,6 +1,7 @@
 package olympus
 
 import (
+	"fmt"
 	"path"
 	"strings"
 	"time"
@@ -215,3 +216,261 @@ func (s *NotifierSuite) TestSubscriptionPersistence(c *C) {
 	}
 
 }
+
+func (s *NotifierSuite) TestEscalationGroup(c *C) {
+	go func() {
+		s.notifier.Loop()
+	}()
+	for _, endpoint := range []string{"a", "b", "c"} {
+		c.Check(s.notifier.RegisterPushSubscription(&webpush.Subscription{Endpoint: endpoint,
+			Keys: webpush.Keys{Auth: "a", P256dh: "a"}}), IsNil)
+	}
+	c.Check(s.notifier.UpdatePushSubscription(&api.NotificationSettingsUpdate{
+		Endpoint: "a",
+		Settings: api.NotificationSettings{
+			Subscriptions: []string{"foo"},
+		},
+	}), IsNil)
+	c.Check(s.notifier.UpdatePushSubscription(&api.NotificationSettingsUpdate{
+		Endpoint: "b",
+		Settings: api.NotificationSettings{
+			NotifyOnEscalation: true,
+		},
+	}), IsNil)
+
+	escalate := func(d alarmData, group bool) ZonedAlarmUpdate {
+		res := d.ToAlarmUpdate()
+		res.Escalation = &EscalationStep{After: time.Minute, Group: group}
+		return res
+	}
+	resolved := alarmData{"", "foo/resolved", api.AlarmLevel_EMERGENCY}.ToAlarmUpdate()
+	resolved.Update.Status = api.AlarmStatus_OFF
+	acknowledged := alarmData{"", "foo/acknowledged", api.AlarmLevel_EMERGENCY}.ToAlarmUpdate()
+	acknowledged.Acknowledgement = &api.AlarmAcknowledgement{By: "someone"}
+
+	alarms := []ZonedAlarmUpdate{
+		escalate(alarmData{"", "foo/critical", api.AlarmLevel_EMERGENCY}, false),
+		escalate(alarmData{"", "bar/critical", api.AlarmLevel_EMERGENCY}, true),
+		resolved,
+		acknowledged,
+		{Zone: "foo"},
+	}
+
+	expected := map[string]bool{
+		"a/foo/critical": true,
+		"b/bar/critical": true,
+	}
+
+	go func() {
+		for _, a := range alarms {
+			s.notifier.Incoming() <- a
+		}
+		time.Sleep(5 * time.Millisecond)
+		close(s.notifier.Incoming())
+	}()
+
+	for r := range s.notifier.Outgoing() {
+		ID := path.Join(r.Subscription.Endpoint, r.Updates[0].ID())
+		c.Check(expected[ID], Equals, true, Commentf("for %s", ID))
+		delete(expected, ID)
+	}
+
+	for e := range expected {
+		c.Errorf("Missing %s", e)
+	}
+}
+
+func (s *NotifierSuite) TestEmailSubscriptions(c *C) {
+	c.Check(s.notifier.RegisterEmailSubscription(&api.EmailSubscription{Email: "not an email"}),
+		ErrorMatches, "invalid email address: .*")
+	c.Check(s.notifier.RegisterEmailSubscription(&api.EmailSubscription{
+		Email: "Someone <someone@example.com>",
+		Settings: api.NotificationSettings{
+			Subscriptions: []string{"foo"},
+		},
+	}), IsNil)
+	c.Check(s.notifier.RegisterEmailSubscription(&api.EmailSubscription{
+		Email: "other@example.com",
+		Settings: api.NotificationSettings{
+			SubscribeToAll: true,
+		},
+	}), IsNil)
+	c.Check(s.notifier.UnregisterEmailSubscription("other@example.com"), IsNil)
+	c.Check(s.notifier.UnregisterEmailSubscription("other@example.com"), Equals, UnknownEmailError)
+
+	// restores from disk
+	notifier := NewNotifier(0)
+	go func() {
+		notifier.Loop()
+	}()
+
+	go func() {
+		for _, a := range []alarmData{
+			{"", "foo/critical", api.AlarmLevel_EMERGENCY},
+			{"", "foo/warning", api.AlarmLevel_WARNING},
+			{"", "bar/critical", api.AlarmLevel_EMERGENCY},
+		} {
+			notifier.Incoming() <- a.ToAlarmUpdate()
+		}
+		time.Sleep(5 * time.Millisecond)
+		close(notifier.Incoming())
+	}()
+
+	received := []NotificationFor{}
+	for r := range notifier.Outgoing() {
+		received = append(received, r)
+	}
+	c.Assert(received, HasLen, 1)
+	c.Check(received[0].Email, Equals, "someone@example.com")
+	c.Check(received[0].Subscription, IsNil)
+	c.Assert(received[0].Updates, HasLen, 1)
+	c.Check(received[0].Updates[0].ID(), Equals, "foo/critical")
+}
+
+func (s *NotifierSuite) TestWebhooks(c *C) {
+	_, err := s.notifier.RegisterWebhook(&api.Webhook{URL: "ftp://example.com"})
+	c.Check(err, ErrorMatches, "invalid webhook URL 'ftp://example.com': .*")
+
+	webhook, err := s.notifier.RegisterWebhook(&api.Webhook{
+		URL: "https://example.com/hook",
+		Settings: api.NotificationSettings{
+			Subscriptions: []string{"foo"},
+		},
+	})
+	c.Assert(err, IsNil)
+	c.Check(webhook.ID, Not(Equals), "")
+	c.Check(webhook.Secret, HasLen, 64)
+
+	other, err := s.notifier.RegisterWebhook(&api.Webhook{URL: "http://example.com/other", Secret: "s3cr3t"})
+	c.Assert(err, IsNil)
+	c.Check(other.Secret, Equals, "s3cr3t")
+	c.Check(s.notifier.UpdateWebhookSettings(other.ID,
+		api.NotificationSettings{SubscribeToAll: true}), IsNil)
+	c.Check(s.notifier.UpdateWebhookSettings("unknown", api.NotificationSettings{}),
+		Equals, UnknownWebhookError)
+
+	webhooks := s.notifier.Webhooks()
+	c.Assert(webhooks, HasLen, 2)
+	for _, w := range webhooks {
+		c.Check(w.Secret, Equals, "", Commentf("secret of %s is not redacted", w.ID))
+	}
+
+	c.Check(s.notifier.UnregisterWebhook(other.ID), IsNil)
+	c.Check(s.notifier.UnregisterWebhook(other.ID), Equals, UnknownWebhookError)
+
+	// restores from disk
+	notifier := NewNotifier(0)
+	c.Check(notifier.Webhooks(), HasLen, 1)
+	go func() {
+		notifier.Loop()
+	}()
+
+	go func() {
+		for _, a := range []alarmData{
+			{"", "foo/critical", api.AlarmLevel_EMERGENCY},
+			{"", "bar/critical", api.AlarmLevel_EMERGENCY},
+		} {
+			notifier.Incoming() <- a.ToAlarmUpdate()
+		}
+		time.Sleep(5 * time.Millisecond)
+		close(notifier.Incoming())
+	}()
+
+	received := []NotificationFor{}
+	for r := range notifier.Outgoing() {
+		received = append(received, r)
+	}
+	c.Assert(received, HasLen, 1)
+	c.Assert(received[0].Webhook, NotNil)
+	c.Check(*received[0].Webhook, DeepEquals, *webhook)
+	c.Assert(received[0].Updates, HasLen, 1)
+	c.Check(received[0].Updates[0].ID(), Equals, "foo/critical")
+}
+
+func (s *NotifierSuite) TestNotifyOnResolve(c *C) {
+	for _, sub := range []*api.EmailSubscription{
+		{Email: "resolve@example.com", Settings: api.NotificationSettings{
+			SubscribeToAll:  true,
+			NotifyOnResolve: true,
+		}},
+		{Email: "fire@example.com", Settings: api.NotificationSettings{
+			SubscribeToAll: true,
+		}},
+		{Email: "other@example.com", Settings: api.NotificationSettings{
+			Subscriptions:   []string{"bar"},
+			NotifyOnResolve: true,
+		}},
+	} {
+		c.Assert(s.notifier.RegisterEmailSubscription(sub), IsNil)
+	}
+
+	go func() {
+		s.notifier.Loop()
+	}()
+
+	now := time.Now()
+	resolve := func(u ZonedAlarmUpdate) ZonedAlarmUpdate {
+		u.Update.Status = api.AlarmStatus_OFF
+		u.Resolution = &AlarmResolution{FiredAt: now.Add(-time.Minute), ResolvedAt: now}
+		return u
+	}
+
+	go func() {
+		s.notifier.Incoming() <- alarmData{"", "foo/critical", api.AlarmLevel_EMERGENCY}.ToAlarmUpdate()
+		time.Sleep(5 * time.Millisecond)
+		s.notifier.Incoming() <- resolve(alarmData{"", "foo/critical", api.AlarmLevel_EMERGENCY}.ToAlarmUpdate())
+		// never notified, so never resolved.
+		s.notifier.Incoming() <- resolve(alarmData{"", "bar/critical", api.AlarmLevel_EMERGENCY}.ToAlarmUpdate())
+		time.Sleep(5 * time.Millisecond)
+		close(s.notifier.Incoming())
+	}()
+
+	received := map[string][]ZonedAlarmUpdate{}
+	for r := range s.notifier.Outgoing() {
+		received[r.Email] = append(received[r.Email], r.Updates...)
+	}
+	c.Assert(received["resolve@example.com"], HasLen, 2)
+	c.Check(received["resolve@example.com"][0].Resolution, IsNil)
+	c.Check(received["resolve@example.com"][1].Resolution, NotNil)
+	c.Check(received["resolve@example.com"][1].ID(), Equals, "foo/critical")
+	c.Assert(received["fire@example.com"], HasLen, 1)
+	c.Check(received["fire@example.com"][0].Resolution, IsNil)
+	c.Check(received["other@example.com"], HasLen, 0)
+}
+
+func (s *NotifierSuite) TestResolutionFollowsItsAlarm(c *C) {
+	c.Assert(s.notifier.RegisterEmailSubscription(&api.EmailSubscription{
+		Email: "resolve@example.com",
+		Settings: api.NotificationSettings{
+			SubscribeToAll:  true,
+			NotifyOnResolve: true,
+		},
+	}), IsNil)
+
+	go func() {
+		s.notifier.Loop()
+	}()
+
+	now := time.Now()
+	go func() {
+		for i := 0; i < 20; i++ {
+			zone := fmt.Sprintf("zone%d", i)
+			s.notifier.Incoming() <- alarmData{"", zone + "/critical", api.AlarmLevel_EMERGENCY}.ToAlarmUpdate()
+			resolved := alarmData{"", zone + "/critical", api.AlarmLevel_EMERGENCY}.ToAlarmUpdate()
+			resolved.Update.Status = api.AlarmStatus_OFF
+			resolved.Resolution = &AlarmResolution{FiredAt: now.Add(-time.Minute), ResolvedAt: now}
+			s.notifier.Incoming() <- resolved
+		}
+		close(s.notifier.Incoming())
+	}()
+
+	resolutions := 0
+	for r := range s.notifier.Outgoing() {
+		for _, u := range r.Updates {
+			if u.Resolution != nil {
+				resolutions += 1
+			}
+		}
+	}
+	c.Check(resolutions, Equals, 20)
+}
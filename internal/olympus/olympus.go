@@ -2,13 +2,18 @@ package olympus
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"regexp"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,10 +22,16 @@ import (
 	"github.com/formicidae-tracker/olympus/pkg/tm"
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var UnknownEndpointError = errors.New("unknown PushSubscription endpoint")
 
+var UnknownEmailError = errors.New("unknown email subscription")
+
+var UnknownWebhookError = errors.New("unknown webhook")
+
 type UnexpectedStreamServerError struct {
 	Got      string
 	Expected string
@@ -48,6 +59,28 @@ func (z NoTrackingRunningError) Error() string {
 	return fmt.Sprintf("olympus: no tracking running in zone '%s'", string(z))
 }
 
+type AlarmNotFoundError string
+
+func (a AlarmNotFoundError) Error() string {
+	return fmt.Sprintf("olympus: unknown alarm '%s'", string(a))
+}
+
+type AlarmNotActiveError string
+
+func (a AlarmNotActiveError) Error() string {
+	return fmt.Sprintf("olympus: alarm '%s' is not active", string(a))
+}
+
+type AlarmAlreadyAcknowledgedError struct {
+	Identification  string
+	Acknowledgement api.AlarmAcknowledgement
+}
+
+func (a AlarmAlreadyAcknowledgedError) Error() string {
+	return fmt.Sprintf("olympus: alarm '%s' was already acknowledged by '%s' at %s",
+		a.Identification, a.Acknowledgement.By, a.Acknowledgement.Time.Format(time.RFC3339))
+}
+
 type AlreadyExistError string
 
 func (a AlreadyExistError) Error() string {
@@ -102,10 +135,22 @@ type Olympus struct {
 	subscriptions       map[string]*subscription
 
 	serviceLogger ServiceLogger
+	climateStore  ClimateStore
+	targetStore   TargetStore
+	sensorStore   SensorStore
+	backlogTimes  *backlogTimes
+	heartbeats    *PersistentMap[time.Duration]
+	alarmStore    AlarmStore
+	alarmSources  AlarmSourceStore
+	maintenance   MaintenanceSchedule
+	events        EventHub
 
 	unfilteredAlarms   chan ZonedAlarmUpdate
 	notifier           Notifier
 	notificationSender NotificationSender
+	webhookDeliveries  WebhookDeliveryLog
+	adminToken         string
+	authenticator      *Authenticator
 	serverPublicKey    string
 	serverSecret       []byte
 
@@ -119,6 +164,16 @@ type GrpcSubscription[T any] struct {
 	updates     chan<- ZonedAlarmUpdate
 }
 
+// PushServerAlarms logs and notifies alarm updates generated by
+// olympus for this subscription.
+func (s GrpcSubscription[T]) PushServerAlarms(updates []*api.AlarmUpdate, domain string) {
+	if len(updates) == 0 {
+		return
+	}
+	s.alarmLogger.PushAlarms(updates, domain)
+	s.NotifyAlarms(updates)
+}
+
 func (s GrpcSubscription[T]) NotifyAlarms(updates []*api.AlarmUpdate) {
 	for _, u := range updates {
 		s.updates <- ZonedAlarmUpdate{Zone: s.zone, Update: u}
@@ -130,53 +185,89 @@ type subscription struct {
 	climate     *GrpcSubscription[ClimateLogger]
 	tracking    *GrpcSubscription[TrackingLogger]
 	alarmLogger AlarmLogger
+	heartbeat   *heartbeatMonitor
+	// external is true if alarm sources can send alarms for this
+	// zone, it is then never removed.
+	external bool
 }
 
 func NewOlympus() (*Olympus, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	minimumOn := 1 * time.Minute
-	batchPeriod := 5 * time.Minute
-
-	debugWebpush := os.Getenv("OLYMPUS_DEBUG_WEBPUSH")
-	if len(debugWebpush) > 0 {
-		minimumOn = 1 * time.Second
-
-		var err error
-		batchPeriod, err = time.ParseDuration(debugWebpush)
-		if err != nil {
-			batchPeriod = 5 * time.Second
-		}
-	}
-
+	events := NewEventHub(EventClientBufferSize)
 	res := &Olympus{
 		log:                 tm.NewLogger("olympus"),
 		subscriptionContext: ctx,
 		cancelSubscription:  cancel,
 		subscriptions:       make(map[string]*subscription),
-		serviceLogger:       NewServiceLogger(),
+		events:              events,
+		serviceLogger:       NewPublishingServiceLogger(NewServiceLogger(), events),
+		climateStore:        NewClimateStore("climate-reports", ClimateReportRetention),
+		targetStore:         NewTargetStore("climate-targets", ClimateReportRetention),
+		sensorStore:         NewSensorStore("climate-sensors"),
+		backlogTimes:        newBacklogTimes("climate-backlogs"),
+		heartbeats:          NewPersistentMap[time.Duration]("heartbeats"),
+		alarmStore:          NewAlarmStore("alarms", AlarmRetention),
+		alarmSources:        NewAlarmSourceStore("alarm-sources"),
+		maintenance:         NewMaintenanceSchedule("maintenance"),
 		unfilteredAlarms:    make(chan ZonedAlarmUpdate, 100),
-		notifier:            NewNotifier(batchPeriod),
-		serverPublicKey:     os.Getenv("OLYMPUS_VAPID_PUBLIC"),
+		notifier:            NewNotifier(NotificationBatchPeriod),
+		webhookDeliveries:   NewWebhookDeliveryLog(WebhookDeliveryLogSize),
+		adminToken:          AdminToken,
+		serverPublicKey:     WebPush.PublicKey,
 	}
 	var err error
 
 	res.buildCSRFHandler()
+	res.buildAuthenticator()
+	res.registerExternalZones()
+	res.restoreHeartbeatZones(time.Now())
+
+	if err := res.climateStore.Prune(); err != nil {
+		res.log.WithError(err).Warn("could not prune old climate data")
+	}
 
 	res.hostname, err = os.Hostname()
 	if err != nil {
 		return nil, err
 	}
 
-	res.notificationSender, err = NewNotificationSender()
+	pushSender, err := NewNotificationSender()
 	if err != nil {
 		res.log.WithField("error", err).Warnf("push notifications will be disabled")
 	}
+	emailSender, err := NewEmailNotificationSender()
+	if err != nil {
+		res.log.WithField("error", err).Warnf("email notifications will be disabled")
+	}
+	res.notificationSender = NewNotificationDispatcher(pushSender, emailSender,
+		NewWebhookNotificationSender(res.webhookDeliveries))
+
+	filtered := make(chan ZonedAlarmUpdate, 100)
+
+	if ClimateStalePeriod > 0 {
+		res.subscriptionWg.Add(1)
+		go func() {
+			defer res.subscriptionWg.Done()
+			res.watchStaleClimates(ctx)
+		}()
+	}
+
+	res.subscriptionWg.Add(1)
+	go func() {
+		defer res.subscriptionWg.Done()
+		res.watchHeartbeats(ctx)
+	}()
+
+	res.notificationWg.Add(4)
+	go func() {
+		defer res.notificationWg.Done()
+		FilterAlarmUpdates(AlarmMinimumOn, res.maintenance)(filtered, res.unfilteredAlarms)
+	}()
 
-	res.notificationWg.Add(3)
 	go func() {
 		defer res.notificationWg.Done()
-		FilterAlarmUpdates(minimumOn)(res.notifier.Incoming(), res.unfilteredAlarms)
+		EscalateAlarmUpdates(EscalationPolicy, SystemClock)(res.notifier.Incoming(), filtered)
 	}()
 
 	go func() {
@@ -191,19 +282,71 @@ func NewOlympus() (*Olympus, error) {
 				res.log.WithField("error", err).Errorf("could not send notification")
 			}
 		}
+		closeNotificationSender(res.notificationSender)
 	}()
 
 	return res, nil
 }
 
+func (o *Olympus) watchStaleClimates(ctx context.Context) {
+	ticker := time.NewTicker(ClimateStaleCheckPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			o.checkStaleClimates(now)
+		}
+	}
+}
+
+// checkStaleClimates raises or clears the stale data alarm of all
+// climate zones.
+func (o *Olympus) checkStaleClimates(now time.Time) {
+	type staleAlarms struct {
+		host, zone string
+		climate    *GrpcSubscription[ClimateLogger]
+		alarms     []*api.AlarmUpdate
+	}
+	var changed []staleAlarms
+
+	o.mx.RLock()
+	if o.subscriptions == nil {
+		o.mx.RUnlock()
+		return
+	}
+	for _, s := range o.subscriptions {
+		if s.climate == nil {
+			continue
+		}
+		alarms := s.climate.object.CheckStale(now, ClimateStalePeriod)
+		if len(alarms) == 0 {
+			continue
+		}
+		changed = append(changed, staleAlarms{
+			host:    s.host,
+			zone:    s.name,
+			climate: s.climate,
+			alarms:  alarms,
+		})
+	}
+	o.mx.RUnlock()
+
+	for _, c := range changed {
+		c.climate.PushServerAlarms(c.alarms, "climate")
+		o.publishAlarms(c.host, c.zone, c.alarms)
+		o.publishZoneSummary(c.host, c.zone)
+	}
+}
+
 func getOlympusSecret() ([]byte, error) {
-	secret64 := os.Getenv("OLYMPUS_SECRET")
-	if len(secret64) == 0 {
-		return nil, errors.New("OLYMPUS_SECRET environment variable is not set")
+	if len(OlympusSecret) == 0 {
+		return nil, errors.New("no secret is configured")
 	}
-	secret, err := base64.URLEncoding.DecodeString(secret64)
+	secret, err := base64.URLEncoding.DecodeString(OlympusSecret)
 	if err != nil {
-		return nil, errors.New("could not decode OLYMPUS_SECRET")
+		return nil, errors.New("could not decode the secret")
 	}
 	return secret, nil
 }
@@ -220,6 +363,16 @@ func (o *Olympus) buildCSRFHandler() {
 	}
 }
 
+func (o *Olympus) buildAuthenticator() {
+	// sessions are disabled without a secret, but basic
+	// authentication still works.
+	secret, _ := getOlympusSecret()
+	o.authenticator = NewAuthenticator(NewUserStore("users"), secret, o.adminToken)
+	if o.authenticator.Enabled() == false {
+		o.log.Warn("no user account is defined, the web API is not authenticated")
+	}
+}
+
 func (o *Olympus) Close() (err error) {
 	o.mx.Lock()
 	defer o.mx.Unlock()
@@ -245,6 +398,8 @@ func (o *Olympus) Close() (err error) {
 		err = fmt.Errorf("%s", rerr)
 	}()
 
+	o.events.Close()
+
 	close(o.unfilteredAlarms)
 	o.notificationWg.Wait()
 
@@ -273,27 +428,10 @@ func (o *Olympus) GetZones() []api.ZoneReportSummary {
 	}
 
 	res := make([]api.ZoneReportSummary, 0, len(o.subscriptions))
+	now := time.Now()
 
 	for _, s := range o.subscriptions {
-		sum := api.ZoneReportSummary{
-			Host: s.host,
-			Name: s.name,
-		}
-		if s.climate != nil {
-			sum.Climate = s.climate.object.GetClimateReport()
-		}
-
-		if s.tracking != nil {
-			sum.Tracking = s.tracking.object.TrackingInfo()
-		}
-
-		if s.alarmLogger != nil {
-			var failures int
-			failures, sum.ActiveEmergencies, sum.ActiveWarnings = s.alarmLogger.ActiveAlarmsCount()
-			sum.ActiveEmergencies += failures
-		}
-
-		res = append(res, sum)
+		res = append(res, o.zoneSummary(s, now))
 	}
 
 	sort.Slice(res, func(i, j int) bool {
@@ -306,6 +444,34 @@ func (o *Olympus) GetZones() []api.ZoneReportSummary {
 	return res
 }
 
+// zoneSummary summarizes a zone. It must be called with the lock
+// held.
+func (o *Olympus) zoneSummary(s *subscription, now time.Time) api.ZoneReportSummary {
+	res := api.ZoneReportSummary{
+		Host:        s.host,
+		Name:        s.name,
+		Maintenance: o.maintenance.Active(ZoneIdentifier(s.host, s.name), now),
+	}
+	if s.climate != nil {
+		res.Climate = s.climate.object.GetClimateReport()
+	}
+
+	if s.tracking != nil {
+		res.Tracking = s.tracking.object.TrackingInfo()
+	}
+
+	if s.heartbeat != nil {
+		res.Heartbeat = s.heartbeat.info()
+	}
+
+	if s.alarmLogger != nil {
+		var failures int
+		failures, res.ActiveEmergencies, res.ActiveWarnings = s.alarmLogger.ActiveAlarmsCount()
+		res.ActiveEmergencies += failures
+	}
+	return res
+}
+
 func (o *Olympus) getClimateLogger(host, zone string) (ClimateLogger, error) {
 	o.mx.RLock()
 	defer o.mx.RUnlock()
@@ -352,10 +518,14 @@ func (o *Olympus) getAlarmLogger(host, zone string) (AlarmLogger, error) {
 	zoneIdentifier := ZoneIdentifier(host, zone)
 
 	s, ok := o.subscriptions[zoneIdentifier]
-	if ok == false || s.alarmLogger == nil {
-		return nil, ZoneNotFoundError(zoneIdentifier)
+	if ok == true && s.alarmLogger != nil {
+		return s.alarmLogger, nil
 	}
-	return s.alarmLogger, nil
+	// the zone is offline, but we may still have its history.
+	if _, ok := o.alarmStore.Load(zoneIdentifier); ok == true {
+		return NewPersistentAlarmLogger(zoneIdentifier, o.alarmStore), nil
+	}
+	return nil, ZoneNotFoundError(zoneIdentifier)
 }
 
 // GetClimateTimeSeries returns the time series for a zone within a
@@ -366,7 +536,103 @@ func (o *Olympus) GetClimateTimeSerie(host, zone, window string) (api.ClimateTim
 	if err != nil {
 		return api.ClimateTimeSeries{}, err
 	}
-	return z.GetClimateTimeSeries(window), nil
+	res := z.GetClimateTimeSeries(window)
+	o.addTargetSeries(&res, ZoneIdentifier(host, zone),
+		res.Reference.Add(-climateWindow(window)), res.Reference)
+	return res, nil
+}
+
+// GetClimateTimeSeriesRange returns the downsampled time series of
+// at most samples points for a zone in [from,to], using the persisted
+// climate reports. The zone does not need to be currently
+// registered. It may return a ZoneNotFoundError.
+func (o *Olympus) GetClimateTimeSeriesRange(host, zone string, from, to time.Time, samples int) (api.ClimateTimeSeries, error) {
+	zoneIdentifier := ZoneIdentifier(host, zone)
+	reports, err := o.climateStore.Query(zoneIdentifier, from, to)
+	if err != nil {
+		return api.ClimateTimeSeries{}, err
+	}
+	if len(reports) == 0 && o.ZoneIsRegistered(host, zone) == false {
+		return api.ClimateTimeSeries{}, ZoneNotFoundError(zoneIdentifier)
+	}
+	res := DownsampleClimateReports(reports, samples, to)
+	res.TemperatureSensors = api.NewSensorDescriptions(o.sensorStore.Get(zoneIdentifier))
+	o.addTargetSeries(&res, zoneIdentifier, from, to)
+	return res, nil
+}
+
+// addTargetSeries adds the setpoints of a zone within [from,to] to
+// series.
+func (o *Olympus) addTargetSeries(series *api.ClimateTimeSeries, zoneIdentifier string, from, to time.Time) {
+	unit, ok := unitDuration(series.Units)
+	if ok == false {
+		// no data
+		return
+	}
+	changes := o.targetStore.Query(zoneIdentifier, from, to)
+	series.TemperatureTarget, series.HumidityTarget = buildTargetSeries(changes,
+		from, to, series.Reference, unit)
+}
+
+// GetClimateTargets returns the climate target changes of a zone in
+// effect within [from,to]. It may return a ZoneNotFoundError.
+func (o *Olympus) GetClimateTargets(host, zone string, from, to time.Time) ([]api.TargetChange, error) {
+	zoneIdentifier := ZoneIdentifier(host, zone)
+	res := o.targetStore.Query(zoneIdentifier, from, to)
+	if len(res) == 0 && o.ZoneIsRegistered(host, zone) == false {
+		return nil, ZoneNotFoundError(zoneIdentifier)
+	}
+	if res == nil {
+		res = []api.TargetChange{}
+	}
+	return res, nil
+}
+
+// saveClimateTarget records a target change of a zone.
+func (o *Olympus) saveClimateTarget(ctx context.Context, zoneIdentifier string, now time.Time, target *api.ClimateTarget) {
+	change := api.TargetChange{
+		Time:       now,
+		Current:    target.Current,
+		CurrentEnd: target.CurrentEnd,
+	}
+	if target.NextTime != nil {
+		change.End = new(time.Time)
+		*change.End = target.NextTime.AsTime()
+	}
+	if err := o.targetStore.Append(zoneIdentifier, change); err != nil {
+		o.log.WithContext(ctx).WithFields(logrus.Fields{
+			"zone":  zoneIdentifier,
+			"error": err,
+		}).Error("could not save climate target")
+	}
+}
+
+// hasClimateData returns nil if a zone has running climate or
+// persisted climate data, otherwise a ZoneNotFoundError.
+func (o *Olympus) hasClimateData(host, zone string) error {
+	zoneIdentifier := ZoneIdentifier(host, zone)
+	if _, err := o.getClimateLogger(host, zone); err == nil {
+		return nil
+	}
+	zones, err := o.climateStore.Zones()
+	if err != nil {
+		return err
+	}
+	idx := sort.SearchStrings(zones, zoneIdentifier)
+	if idx < len(zones) && zones[idx] == zoneIdentifier {
+		return nil
+	}
+	return ZoneNotFoundError(zoneIdentifier)
+}
+
+// ExportClimateReports streams all persisted climate reports of a
+// zone within [from,to]. It may return a ZoneNotFoundError before
+// writing anything.
+func (o *Olympus) ExportClimateReports(w io.Writer, host, zone string, from, to time.Time, format ClimateExportFormat) error {
+	if err := o.hasClimateData(host, zone); err != nil {
+		return err
+	}
+	return ExportClimateReports(w, o.climateStore, ZoneIdentifier(host, zone), from, to, format)
 }
 
 func (o *Olympus) GetZoneReport(host, zone string) (*api.ZoneReport, error) {
@@ -389,10 +655,21 @@ func (o *Olympus) GetZoneReport(host, zone string) (*api.ZoneReport, error) {
 	if errAlarm == nil {
 		res.Alarms = a.GetReports()
 	}
+	res.Heartbeat = o.getHeartbeatInfo(host, zone)
 
 	return res, nil
 }
 
+func (o *Olympus) getHeartbeatInfo(host, zone string) *api.HeartbeatInfo {
+	o.mx.RLock()
+	defer o.mx.RUnlock()
+	s, ok := o.subscriptions[ZoneIdentifier(host, zone)]
+	if ok == false || s.heartbeat == nil {
+		return nil
+	}
+	return s.heartbeat.info()
+}
+
 func (o *Olympus) GetAlarmReports(host, zone string) ([]api.AlarmReport, error) {
 	a, err := o.getAlarmLogger(host, zone)
 	if err != nil {
@@ -401,6 +678,79 @@ func (o *Olympus) GetAlarmReports(host, zone string) ([]api.AlarmReport, error)
 	return a.GetReports(), nil
 }
 
+// AcknowledgeAlarm acknowledges the current event of an active alarm,
+// no further notification will be sent for it until it turns OFF. It
+// may return a ZoneNotFoundError, AlarmNotFoundError,
+// AlarmNotActiveError or AlarmAlreadyAcknowledgedError.
+func (o *Olympus) AcknowledgeAlarm(ctx context.Context, host, zone, identification, by string) (*api.AlarmAcknowledgement, error) {
+	a, err := o.getAlarmLogger(host, zone)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	ack, err := a.Acknowledge(identification, by, now)
+	if err != nil {
+		return nil, err
+	}
+
+	zoneIdentifier := ZoneIdentifier(host, zone)
+	o.log.WithContext(ctx).WithFields(logrus.Fields{
+		"zone":  zoneIdentifier,
+		"alarm": identification,
+		"by":    by,
+	}).Info("alarm acknowledged")
+
+	o.mx.RLock()
+	defer o.mx.RUnlock()
+	if o.subscriptions == nil {
+		return ack, nil
+	}
+	o.unfilteredAlarms <- ZonedAlarmUpdate{
+		Zone: zoneIdentifier,
+		Update: &api.AlarmUpdate{
+			Identification: identification,
+			Status:         api.AlarmStatus_ON,
+			Time:           timestamppb.New(now),
+		},
+		Acknowledgement: ack,
+	}
+	return ack, nil
+}
+
+// GetMaintenanceWindows lists all current and future maintenance
+// windows.
+func (o *Olympus) GetMaintenanceWindows() []api.MaintenanceWindow {
+	return o.maintenance.Windows()
+}
+
+// AddMaintenanceWindow schedules a maintenance window. Notifications
+// for the matching alarms are dropped during the window, but alarms
+// are still logged.
+func (o *Olympus) AddMaintenanceWindow(ctx context.Context, window api.MaintenanceWindow) (api.MaintenanceWindow, error) {
+	res, err := o.maintenance.Add(window)
+	if err != nil {
+		return res, err
+	}
+	o.log.WithContext(ctx).WithFields(logrus.Fields{
+		"zone":   res.Zone,
+		"prefix": res.AlarmPrefix,
+		"start":  res.Start,
+		"end":    res.End,
+		"reason": res.Reason,
+	}).Info("maintenance window scheduled")
+	return res, nil
+}
+
+// RemoveMaintenanceWindow removes a maintenance window. It may return
+// a MaintenanceWindowNotFoundError.
+func (o *Olympus) RemoveMaintenanceWindow(ctx context.Context, id string) error {
+	if err := o.maintenance.Remove(id); err != nil {
+		return err
+	}
+	o.log.WithContext(ctx).WithField("id", id).Info("maintenance window removed")
+	return nil
+}
+
 func (o *Olympus) RegisterClimate(ctx context.Context, declaration *api.ClimateDeclaration) (csub *GrpcSubscription[ClimateLogger], err error) {
 	zoneIdentifier := ZoneIdentifier(declaration.Host, declaration.Name)
 
@@ -417,6 +767,24 @@ func (o *Olympus) RegisterClimate(ctx context.Context, declaration *api.ClimateD
 		}
 	}()
 
+	if err := checkClientIdentity(ctx, declaration.Host); err != nil {
+		return nil, err
+	}
+
+	// rejects duplicates before restoring the reports from disk.
+	o.mx.RLock()
+	registered := o.subscriptions != nil && o.subscriptions[zoneIdentifier] != nil &&
+		o.subscriptions[zoneIdentifier].climate != nil
+	o.mx.RUnlock()
+	if registered == true {
+		return nil, AlreadyExistError("zone '" + zoneIdentifier + "'")
+	}
+
+	declaration = o.resolveTemperatureSensors(ctx, zoneIdentifier, declaration)
+	// restoring may take some time, we do it before locking.
+	logger := NewClimateLogger(declaration)
+	o.restoreClimateReports(ctx, zoneIdentifier, logger)
+
 	o.mx.Lock()
 	defer o.mx.Unlock()
 	if o.subscriptions == nil {
@@ -429,7 +797,7 @@ func (o *Olympus) RegisterClimate(ctx context.Context, declaration *api.ClimateD
 	}
 
 	if ok == false {
-		alarmLogger := NewAlarmLogger()
+		alarmLogger := NewPersistentAlarmLogger(zoneIdentifier, o.alarmStore)
 		sub = &subscription{
 			host:        declaration.Host,
 			name:        declaration.Name,
@@ -441,17 +809,63 @@ func (o *Olympus) RegisterClimate(ctx context.Context, declaration *api.ClimateD
 
 	sub.climate = &GrpcSubscription[ClimateLogger]{
 		zone:        zoneIdentifier,
-		object:      NewClimateLogger(declaration),
+		object:      logger,
 		alarmLogger: sub.alarmLogger,
 		updates:     o.unfilteredAlarms,
 	}
 
 	sub.alarmLogger.ClearDomain("climate", declaration.Since.AsTime())
+	sub.alarmLogger.ClearDomain("olympus", declaration.Since.AsTime())
 	go o.serviceLogger.Log(ctx, zoneIdentifier+".climate", true, true)
 
 	return sub.climate, nil
 }
 
+// resolveTemperatureSensors persists the temperature sensors of a
+// declaration, or returns a copy of it with the persisted ones if it
+// does not declare any.
+func (o *Olympus) resolveTemperatureSensors(ctx context.Context, zoneIdentifier string, declaration *api.ClimateDeclaration) *api.ClimateDeclaration {
+	if len(declaration.TemperatureSensors) > 0 {
+		if err := o.sensorStore.Set(zoneIdentifier, declaration.TemperatureSensors); err != nil {
+			o.log.WithContext(ctx).WithFields(logrus.Fields{
+				"zone":  zoneIdentifier,
+				"error": err,
+			}).Error("could not save temperature sensors")
+		}
+		return declaration
+	}
+	sensors := o.sensorStore.Get(zoneIdentifier)
+	if len(sensors) == 0 {
+		return declaration
+	}
+	res := proto.Clone(declaration).(*api.ClimateDeclaration)
+	res.TemperatureSensors = sensors
+	return res
+}
+
+// restoreClimateReports pushes the persisted reports of the last
+// week to a newly created ClimateLogger.
+func (o *Olympus) restoreClimateReports(ctx context.Context, zoneIdentifier string, logger ClimateLogger) {
+	reports, err := o.climateStore.Query(zoneIdentifier, time.Now().Add(-7*24*time.Hour), time.Time{})
+	if err != nil {
+		o.log.WithContext(ctx).WithFields(logrus.Fields{
+			"zone":  zoneIdentifier,
+			"error": err,
+		}).Error("could not restore climate reports")
+	}
+	logger.PushReports(reports)
+}
+
+// saveClimateReports persists reports for a zone.
+func (o *Olympus) saveClimateReports(ctx context.Context, zoneIdentifier string, reports []*api.ClimateReport) {
+	if err := o.climateStore.Append(zoneIdentifier, reports); err != nil {
+		o.log.WithContext(ctx).WithFields(logrus.Fields{
+			"zone":  zoneIdentifier,
+			"error": err,
+		}).Error("could not save climate reports")
+	}
+}
+
 func (o *Olympus) UnregisterClimate(ctx context.Context, host, name string, graceful bool) (err error) {
 	zoneIdentifier := ZoneIdentifier(host, name)
 
@@ -485,7 +899,7 @@ func (o *Olympus) UnregisterClimate(ctx context.Context, host, name string, grac
 		o.subscriptionWg.Done()
 	}()
 
-	if s.tracking == nil {
+	if s.tracking == nil && s.heartbeat == nil && s.external == false {
 		o.removeSubscription(zoneIdentifier)
 	}
 
@@ -508,6 +922,10 @@ func (o *Olympus) RegisterTracking(ctx context.Context, declaration *api.Trackin
 		}
 	}()
 
+	if err := checkClientIdentity(ctx, declaration.Hostname); err != nil {
+		return nil, err
+	}
+
 	rx, err := regexp.Compile(o.hostname + `\.(local|lan)`)
 	if err != nil {
 		return nil, fmt.Errorf("Internal server error preparing regexp: %w", err)
@@ -533,7 +951,7 @@ func (o *Olympus) RegisterTracking(ctx context.Context, declaration *api.Trackin
 	}
 
 	if ok == false {
-		alarmLogger := NewAlarmLogger()
+		alarmLogger := NewPersistentAlarmLogger(zoneIdentifier, o.alarmStore)
 		sub = &subscription{
 			host:        declaration.Hostname,
 			name:        "box",
@@ -588,7 +1006,7 @@ func (o *Olympus) UnregisterTracker(ctx context.Context, host string, graceful b
 		o.subscriptionWg.Done()
 	}()
 
-	if s.climate == nil {
+	if s.climate == nil && s.heartbeat == nil && s.external == false {
 		o.removeSubscription(zoneIdentifier)
 	}
 
@@ -602,22 +1020,98 @@ func (o *Olympus) removeSubscription(zoneIdentifier string) {
 	o.unfilteredAlarms <- ZonedAlarmUpdate{Zone: zoneIdentifier, Update: nil}
 }
 
-func (o *Olympus) NotifyAlarm(ctx context.Context, zone string, update *api.AlarmUpdate) {
+// registerExternalZones registers the zones of the alarm sources,
+// so they are listed even if they never sent any alarm.
+func (o *Olympus) registerExternalZones() {
+	for _, source := range o.alarmSources.List() {
+		for _, zoneIdentifier := range source.Zones {
+			host, zone, err := splitZone(zoneIdentifier)
+			if err != nil {
+				o.log.WithError(err).WithField("source", source.Name).Warn("invalid alarm source zone")
+				continue
+			}
+			if _, ok := o.subscriptions[zoneIdentifier]; ok == true {
+				continue
+			}
+			o.subscriptions[zoneIdentifier] = &subscription{
+				host:        host,
+				name:        zone,
+				alarmLogger: NewPersistentAlarmLogger(zoneIdentifier, o.alarmStore),
+				external:    true,
+			}
+		}
+	}
+}
+
+// SendZoneAlarm logs and notifies an alarm update sent by the alarm
+// source of token for a zone. It may return an
+// InvalidAlarmTokenError or a ZoneNotAllowedError.
+func (o *Olympus) SendZoneAlarm(ctx context.Context, token, host, zone string, update *api.AlarmUpdate) (err error) {
+	zoneIdentifier := ZoneIdentifier(host, zone)
+	source, err := o.alarmSources.Authenticate(token)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		entry := o.log.WithContext(ctx).WithFields(logrus.Fields{
+			"zone":   zoneIdentifier,
+			"source": source.Name,
+			"update": update,
+		})
+		if err != nil {
+			entry.WithField("error", err).Error("could not update external alarm")
+		} else {
+			entry.Info("external alarm update")
+		}
+	}()
+	if source.Allows(zoneIdentifier) == false {
+		return ZoneNotAllowedError{Source: source.Name, Zone: zoneIdentifier}
+	}
+	if update.Time == nil {
+		update.Time = timestamppb.Now()
+	}
+
 	o.mx.Lock()
-	defer o.mx.Unlock()
-	o.log.WithContext(ctx).WithFields(logrus.Fields{
-		"zone":   zone,
-		"update": update,
-	}).Info("manual zone alarm update")
-	o.notifier.Incoming() <- ZonedAlarmUpdate{Zone: zone, Update: update}
+	if o.subscriptions == nil {
+		o.mx.Unlock()
+		return ClosedOlympusServerError{}
+	}
+	// the source may have been added after startup.
+	s := o.subscriptions[zoneIdentifier]
+	if s == nil {
+		s = &subscription{
+			host:        host,
+			name:        zone,
+			alarmLogger: NewPersistentAlarmLogger(zoneIdentifier, o.alarmStore),
+		}
+		o.subscriptions[zoneIdentifier] = s
+	}
+	s.external = true
+	updates := []*api.AlarmUpdate{update}
+	s.alarmLogger.PushAlarms(updates, "external")
+	o.unfilteredAlarms <- ZonedAlarmUpdate{Zone: zoneIdentifier, Update: update}
+	o.mx.Unlock()
+
+	o.publishAlarms(host, zone, updates)
+	o.publishZoneSummary(host, zone)
+	return nil
 }
 
 func (o *Olympus) setRoutes(router *mux.Router) {
 	o.setFetchRoutes(router)
+	o.setEventRoutes(router)
+	o.setMetricsRoutes(router)
 	if o.csrfHandler != nil {
 		o.setNotificationRoutes(router)
+		o.setAlarmRoutes(router)
+	} else {
+		o.log.Printf("No CSRF handler set, notifications, alarm acknowledgement and maintenance routes are disabled")
+	}
+	o.authenticator.setRoutes(router)
+	if len(o.adminToken) > 0 || o.authenticator.Enabled() == true {
+		o.setWebhookRoutes(router)
 	} else {
-		o.log.Printf("No CSRF handler set, notifications routes are disabled")
+		o.log.Printf("neither OLYMPUS_ADMIN_TOKEN nor user accounts are set, webhook routes are disabled")
 	}
 }
 
@@ -634,7 +1128,44 @@ func (o *Olympus) setFetchRoutes(router *mux.Router) {
 
 	router.HandleFunc("/api/host/{hname}/zone/{zname}/climate", func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
-		res, err := o.GetClimateTimeSerie(vars["hname"], vars["zname"], r.URL.Query().Get("window"))
+		query := r.URL.Query()
+		if query.Has("from") || query.Has("to") {
+			from, to, samples, err := parseClimateRange(query, time.Now())
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			res, err := o.GetClimateTimeSeriesRange(vars["hname"], vars["zname"], from, to, samples)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			JSONify(w, &res)
+			return
+		}
+
+		res, err := o.GetClimateTimeSerie(vars["hname"], vars["zname"], query.Get("window"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		JSONify(w, &res)
+	}).Methods("GET")
+
+	router.HandleFunc("/api/host/{hname}/zone/{zname}/climate/targets", func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		query := r.URL.Query()
+		from, err := parseOptionalTime(query, "from")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		to, err := parseOptionalTime(query, "to")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		res, err := o.GetClimateTargets(vars["hname"], vars["zname"], from, to)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusNotFound)
 			return
@@ -642,6 +1173,44 @@ func (o *Olympus) setFetchRoutes(router *mux.Router) {
 		JSONify(w, &res)
 	}).Methods("GET")
 
+	router.HandleFunc("/api/host/{hname}/zone/{zname}/climate/export", func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		query := r.URL.Query()
+		format, err := ParseClimateExportFormat(query.Get("format"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		from, err := parseOptionalTime(query, "from")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		to, err := parseOptionalTime(query, "to")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		zoneIdentifier := ZoneIdentifier(vars["hname"], vars["zname"])
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Set("Content-Disposition",
+			fmt.Sprintf(`attachment; filename="%s.%s"`, zoneIdentifier, format))
+
+		err = o.ExportClimateReports(w, vars["hname"], vars["zname"], from, to, format)
+		if _, ok := err.(ZoneNotFoundError); ok == true {
+			w.Header().Del("Content-Disposition")
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			// headers are already sent, we can only log it.
+			o.log.WithContext(r.Context()).WithFields(logrus.Fields{
+				"zone":  zoneIdentifier,
+				"error": err,
+			}).Error("could not export climate reports")
+		}
+	}).Methods("GET")
+
 	router.HandleFunc("/api/host/{hname}/zone/{zname}/alarms", func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 		res, err := o.GetAlarmReports(vars["hname"], vars["zname"])
@@ -672,6 +1241,143 @@ func (o *Olympus) setFetchRoutes(router *mux.Router) {
 	}).Methods("GET")
 }
 
+// parseOptionalTime parses an optional RFC3339 query
+// parameter. It returns a zero time if the parameter is missing.
+func parseOptionalTime(query url.Values, key string) (time.Time, error) {
+	if query.Has(key) == false {
+		return time.Time{}, nil
+	}
+	res, err := time.Parse(time.RFC3339, query.Get(key))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid '%s' parameter: %w", key, err)
+	}
+	return res, nil
+}
+
+// parseClimateRange parses the from, to and samples query
+// parameters. from and to are RFC3339 times, if one of them is
+// missing, to defaults to now, and from to ten minutes before to.
+func parseClimateRange(query url.Values, now time.Time) (from, to time.Time, samples int, err error) {
+	to = now
+	if query.Has("to") {
+		to, err = time.Parse(time.RFC3339, query.Get("to"))
+		if err != nil {
+			return from, to, 0, fmt.Errorf("invalid 'to' parameter: %w", err)
+		}
+	}
+	from = to.Add(-10 * time.Minute)
+	if query.Has("from") {
+		from, err = time.Parse(time.RFC3339, query.Get("from"))
+		if err != nil {
+			return from, to, 0, fmt.Errorf("invalid 'from' parameter: %w", err)
+		}
+	}
+	if to.After(from) == false {
+		return from, to, 0, errors.New("'from' must be before 'to'")
+	}
+	if to.Sub(from) > MaxClimateRange {
+		return from, to, 0, fmt.Errorf("range must not be longer than %s", MaxClimateRange)
+	}
+
+	samples = DefaultClimateSamples
+	if query.Has("samples") {
+		samples, err = strconv.Atoi(query.Get("samples"))
+		if err != nil {
+			return from, to, 0, fmt.Errorf("invalid 'samples' parameter: %w", err)
+		}
+		if samples < 3 || samples > MaxClimateSamples {
+			return from, to, 0, fmt.Errorf("'samples' must be in [3,%d]", MaxClimateSamples)
+		}
+	}
+	return from, to, samples, nil
+}
+
+func (o *Olympus) setAlarmRoutes(router *mux.Router) {
+	router.Handle("/api/host/{hname}/zone/{zname}/alarms/{id}/ack",
+		o.csrfHandler.CheckCSRFCookie(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add("Cache-Control", "no-store")
+				vars := mux.Vars(r)
+
+				req, err := Golangify[api.AlarmAcknowledgementRequest](r)
+				if err == nil {
+					req.By, err = acknowledgedBy(r.Context(), req.By)
+					if err != nil {
+						http.Error(w, err.Error(), http.StatusForbidden)
+						return
+					}
+				}
+				if err != nil || len(req.By) == 0 {
+					http.Error(w, "invalid request", http.StatusBadRequest)
+					return
+				}
+
+				res, err := o.AcknowledgeAlarm(r.Context(),
+					vars["hname"], vars["zname"], vars["id"], req.By)
+				if err != nil {
+					http.Error(w, err.Error(), acknowledgeErrorStatus(err))
+					return
+				}
+				JSONify(w, res)
+			}))).Methods("POST")
+
+	router.Handle("/api/maintenance",
+		o.csrfHandler.SetCSRFCookie(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add("Cache-Control", "no-store")
+				res := o.GetMaintenanceWindows()
+				JSONify(w, &res)
+			}))).Methods("GET")
+
+	router.Handle("/api/maintenance",
+		o.csrfHandler.CheckCSRFCookie(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add("Cache-Control", "no-store")
+				window, err := Golangify[api.MaintenanceWindow](r)
+				if err != nil {
+					http.Error(w, "invalid request", http.StatusBadRequest)
+					return
+				}
+				res, err := o.AddMaintenanceWindow(r.Context(), *window)
+				if err != nil {
+					if _, ok := err.(InvalidMaintenanceWindowError); ok == true {
+						http.Error(w, err.Error(), http.StatusBadRequest)
+					} else {
+						http.Error(w, err.Error(), http.StatusInternalServerError)
+					}
+					return
+				}
+				JSONify(w, &res)
+			}))).Methods("POST")
+
+	router.Handle("/api/maintenance/{id}",
+		o.csrfHandler.CheckCSRFCookie(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add("Cache-Control", "no-store")
+				err := o.RemoveMaintenanceWindow(r.Context(), mux.Vars(r)["id"])
+				if err != nil {
+					if _, ok := err.(MaintenanceWindowNotFoundError); ok == true {
+						http.Error(w, err.Error(), http.StatusNotFound)
+					} else {
+						http.Error(w, err.Error(), http.StatusInternalServerError)
+					}
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))).Methods("DELETE")
+}
+
+func acknowledgeErrorStatus(err error) int {
+	switch err.(type) {
+	case ZoneNotFoundError, AlarmNotFoundError:
+		return http.StatusNotFound
+	case AlarmNotActiveError, AlarmAlreadyAcknowledgedError:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func (o *Olympus) setNotificationRoutes(router *mux.Router) {
 	router.Handle("/api/notifications/key",
 		o.csrfHandler.SetCSRFCookie(
@@ -733,5 +1439,144 @@ func (o *Olympus) setNotificationRoutes(router *mux.Router) {
 
 	}).Methods("POST")
 
+	subrouter.HandleFunc("/email", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Cache-Control", "no-store")
+
+		sub, err := Golangify[api.EmailSubscription](r)
+		if err != nil {
+			o.log.Printf("invalid email subscription: %s", err)
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+
+		if err := o.notifier.RegisterEmailSubscription(sub); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}).Methods("POST")
+
+	subrouter.HandleFunc("/email/{address}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Cache-Control", "no-store")
+
+		err := o.notifier.UnregisterEmailSubscription(mux.Vars(r)["address"])
+		if err == UnknownEmailError {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}).Methods("DELETE")
+
 	subrouter.Use(o.csrfHandler.CheckCSRFCookie)
 }
+
+// GetWebhookDeliveries returns the latest deliveries of a webhook,
+// most recent first. It may return UnknownWebhookError.
+func (o *Olympus) GetWebhookDeliveries(id string) ([]api.WebhookDelivery, error) {
+	for _, w := range o.notifier.Webhooks() {
+		if w.ID == id {
+			return o.webhookDeliveries.Deliveries(id), nil
+		}
+	}
+	return nil, UnknownWebhookError
+}
+
+// requireAdminToken only lets through requests with a
+// 'Authorization: Bearer <OLYMPUS_ADMIN_TOKEN>' header, or
+// authenticated with RoleAdmin.
+func (o *Olympus) requireAdminToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, role := AuthenticatedUser(r.Context()); role >= RoleAdmin {
+			next.ServeHTTP(w, r)
+			return
+		}
+		header := r.Header.Get("Authorization")
+		token := strings.TrimPrefix(header, "Bearer ")
+		if token == header || len(o.adminToken) == 0 || subtle.ConstantTimeCompare([]byte(token), []byte(o.adminToken)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="olympus"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (o *Olympus) setWebhookRoutes(router *mux.Router) {
+	subrouter := router.PathPrefix("/api/webhooks").Subrouter()
+
+	subrouter.HandleFunc("", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Cache-Control", "no-store")
+		res := o.notifier.Webhooks()
+		JSONify(w, &res)
+	}).Methods("GET")
+
+	subrouter.HandleFunc("", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Cache-Control", "no-store")
+
+		webhook, err := Golangify[api.Webhook](r)
+		if err != nil {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+
+		// the secret is only returned once, on creation.
+		res, err := o.notifier.RegisterWebhook(webhook)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		JSONify(w, res)
+	}).Methods("POST")
+
+	subrouter.HandleFunc("/{id}/settings", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Cache-Control", "no-store")
+
+		settings, err := Golangify[api.NotificationSettings](r)
+		if err != nil {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+
+		err = o.notifier.UpdateWebhookSettings(mux.Vars(r)["id"], *settings)
+		if err == UnknownWebhookError {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}).Methods("POST")
+
+	subrouter.HandleFunc("/{id}/deliveries", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Cache-Control", "no-store")
+
+		res, err := o.GetWebhookDeliveries(mux.Vars(r)["id"])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		JSONify(w, &res)
+	}).Methods("GET")
+
+	subrouter.HandleFunc("/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Cache-Control", "no-store")
+
+		err := o.notifier.UnregisterWebhook(mux.Vars(r)["id"])
+		if err == UnknownWebhookError {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}).Methods("DELETE")
+
+	subrouter.Use(o.requireAdminToken)
+}
@@ -0,0 +1,206 @@
+package olympus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"github.com/gorilla/mux"
+)
+
+type publishingServiceLogger struct {
+	ServiceLogger
+	events EventHub
+}
+
+// NewPublishingServiceLogger wraps a ServiceLogger to publish every
+// service registration and unregistration to events.
+func NewPublishingServiceLogger(logger ServiceLogger, events EventHub) ServiceLogger {
+	return publishingServiceLogger{ServiceLogger: logger, events: events}
+}
+
+func (l publishingServiceLogger) Log(ctx context.Context, identifier string, on, graceful bool) {
+	l.ServiceLogger.Log(ctx, identifier, on, graceful)
+
+	host, zone, _ := splitServiceIdentifier(identifier)
+	l.events.Publish(api.ZoneEvent{
+		Type: api.ServiceEventType,
+		Host: host,
+		Zone: zone,
+		Time: time.Now(),
+		Service: &api.ServiceStatus{
+			Identifier: identifier,
+			On:         on,
+			Graceful:   graceful,
+		},
+	})
+}
+
+// publishZoneSummary publishes the current summary of a zone, or a
+// nil summary if the zone is not registered anymore. It must not be
+// called with the lock held.
+func (o *Olympus) publishZoneSummary(host, zone string) {
+	if o.events.Subscribers() == 0 {
+		return
+	}
+
+	now := time.Now()
+	event := api.ZoneEvent{
+		Type: api.ZoneSummaryEventType,
+		Host: host,
+		Zone: zone,
+		Time: now,
+	}
+
+	o.mx.RLock()
+	if o.subscriptions != nil {
+		if s, ok := o.subscriptions[ZoneIdentifier(host, zone)]; ok == true {
+			summary := o.zoneSummary(s, now)
+			event.Summary = &summary
+		}
+	}
+	o.mx.RUnlock()
+
+	o.events.Publish(event)
+}
+
+func (o *Olympus) publishClimate(host, zone string, reports []*api.ClimateReport) {
+	records := make([]api.ClimateRecord, 0, len(reports))
+	for _, r := range reports {
+		records = append(records, api.NewClimateRecord(r))
+	}
+	o.events.Publish(api.ZoneEvent{
+		Type:    api.ClimateEventType,
+		Host:    host,
+		Zone:    zone,
+		Time:    time.Now(),
+		Climate: records,
+	})
+}
+
+func (o *Olympus) publishTarget(host, zone string, report *api.ZoneClimateReport) {
+	o.events.Publish(api.ZoneEvent{
+		Type:   api.TargetEventType,
+		Host:   host,
+		Zone:   zone,
+		Time:   time.Now(),
+		Target: report,
+	})
+}
+
+func (o *Olympus) publishAlarms(host, zone string, updates []*api.AlarmUpdate) {
+	transitions := make([]api.ZoneAlarmTransition, 0, len(updates))
+	for _, u := range updates {
+		transitions = append(transitions, api.ZoneAlarmTransition{
+			Identification: u.Identification,
+			Level:          u.Level,
+			On:             u.Status == api.AlarmStatus_ON,
+			Description:    u.Description,
+			Time:           u.Time.AsTime(),
+		})
+	}
+	o.events.Publish(api.ZoneEvent{
+		Type:   api.AlarmsEventType,
+		Host:   host,
+		Zone:   zone,
+		Time:   time.Now(),
+		Alarms: transitions,
+	})
+}
+
+// eventFilter returns a filter on the host and zone query parameters
+// of a request. Zone snapshots are filtered in place.
+func eventFilter(r *http.Request) func(*api.ZoneEvent) bool {
+	host := r.URL.Query().Get("host")
+	zone := r.URL.Query().Get("zone")
+	matches := func(h, z string) bool {
+		return (len(host) == 0 || h == host) && (len(zone) == 0 || z == zone)
+	}
+
+	return func(e *api.ZoneEvent) bool {
+		if e.Type != api.ZonesEventType {
+			return matches(e.Host, e.Zone)
+		}
+		zones := make([]api.ZoneReportSummary, 0, len(e.Zones))
+		for _, z := range e.Zones {
+			if matches(z.Host, z.Name) == true {
+				zones = append(zones, z)
+			}
+		}
+		e.Zones = zones
+		return true
+	}
+}
+
+func writeEvent(w http.ResponseWriter, e api.ZoneEvent) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+	return err
+}
+
+// serveEvents streams events as Server-Sent Events. The stream starts
+// with a snapshot of all zones, and ends if the client lags behind:
+// browsers will reconnect and get a fresh snapshot.
+func (o *Olympus) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if ok == false {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	events, unsubscribe := o.events.Subscribe()
+	defer unsubscribe()
+
+	filter := eventFilter(r)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	snapshot := api.ZoneEvent{
+		Type:  api.ZonesEventType,
+		Time:  time.Now(),
+		Zones: o.GetZones(),
+	}
+	filter(&snapshot)
+	if err := writeEvent(w, snapshot); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(EventKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case e, ok := <-events:
+			if ok == false {
+				return
+			}
+			if filter(&e) == false {
+				continue
+			}
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func (o *Olympus) setEventRoutes(router *mux.Router) {
+	router.HandleFunc("/api/events", o.serveEvents).Methods("GET")
+}
@@ -2,11 +2,15 @@ package olympus
 
 import (
 	"context"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/formicidae-tracker/olympus/pkg/api"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -26,6 +30,14 @@ func mapError(err error) error {
 		return status.Error(codes.NotFound, err.Error())
 	case ClosedOlympusServerError:
 		return status.Error(codes.Internal, err.Error())
+	case ClientIdentityError:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case ZoneNotAllowedError:
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	switch err {
+	case InvalidAlarmTokenError:
+		return status.Error(codes.Unauthenticated, err.Error())
 	default:
 		return err
 	}
@@ -41,6 +53,8 @@ func (o *OlympusGRPCWrapper) Log(ctx context.Context) *logrus.Entry {
 
 func (o *OlympusGRPCWrapper) Climate(stream api.Olympus_ClimateServer) (err error) {
 	var subscription *GrpcSubscription[ClimateLogger] = nil
+	// backlog pages resend reports which may already be stored.
+	var backlog *climateBacklog
 	ctx := api.WithTelemetry(o.SubscriptionContext(), "fort.olympus.Olympus/Climate")
 
 	defer func() {
@@ -53,14 +67,16 @@ func (o *OlympusGRPCWrapper) Climate(stream api.Olympus_ClimateServer) (err erro
 		}
 
 		graceful := err == nil
-		(*Olympus)(o).UnregisterClimate(ctx, subscription.object.Host(), subscription.object.ZoneName(), graceful)
+		host, zone := subscription.object.Host(), subscription.object.ZoneName()
+		(*Olympus)(o).UnregisterClimate(ctx, host, zone, graceful)
+		(*Olympus)(o).publishZoneSummary(host, zone)
 	}()
 
 	ack := &api.ClimateDownStream{}
 	handler := func(mCtx context.Context, m *api.ClimateUpStream) (*api.ClimateDownStream, error) {
 		var confirmation *api.ClimateDownStream
 		if subscription == nil {
-			ctx = mCtx
+			ctx = withPeer(mCtx, stream.Context())
 
 			if m.Declaration == nil {
 				return nil, status.Error(codes.InvalidArgument, "first message of stream must contain ZoneDeclaration")
@@ -71,6 +87,7 @@ func (o *OlympusGRPCWrapper) Climate(stream api.Olympus_ClimateServer) (err erro
 			if err != nil {
 				return nil, mapError(err)
 			}
+			backlog = newClimateBacklog(subscription.zone, (*Olympus)(o).climateStore)
 
 			confirmation = &api.ClimateDownStream{
 				RegistrationConfirmation: &api.ClimateRegistrationConfirmation{
@@ -79,17 +96,53 @@ func (o *OlympusGRPCWrapper) Climate(stream api.Olympus_ClimateServer) (err erro
 			}
 		}
 
+		host, zone := subscription.object.Host(), subscription.object.ZoneName()
+		changed := confirmation != nil
+
 		if m.Target != nil {
 			subscription.object.PushTarget(m.Target)
+			(*Olympus)(o).saveClimateTarget(ctx, subscription.zone, time.Now(), m.Target)
+			(*Olympus)(o).publishTarget(host, zone, subscription.object.GetClimateReport())
+			changed = true
 		}
 		if len(m.Alarms) > 0 {
 			subscription.alarmLogger.PushAlarms(m.Alarms, "climate")
 			if m.Backlog == false {
 				subscription.NotifyAlarms(m.Alarms)
+				(*Olympus)(o).publishAlarms(host, zone, m.Alarms)
+			}
+			changed = true
+		}
+		if m.Backlog == true && len(m.Reports) > 0 {
+			reports, err := backlog.filter(m.Reports)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
 			}
+			m.Reports = reports
 		}
 		if len(m.Reports) > 0 {
 			subscription.object.PushReports(m.Reports)
+			(*Olympus)(o).saveClimateReports(ctx, subscription.zone, m.Reports)
+			if m.Backlog == false {
+				(*Olympus)(o).publishClimate(host, zone, m.Reports)
+				alarms := subscription.object.TrackTarget(m.Reports, TargetTrackingAlarm)
+				if len(alarms) > 0 {
+					subscription.PushServerAlarms(alarms, "olympus")
+					(*Olympus)(o).publishAlarms(host, zone, alarms)
+				}
+			}
+			if m.Backlog == false && ServerBoundCheck != nil {
+				alarms := subscription.object.CheckBounds(m.Reports, *ServerBoundCheck)
+				if len(alarms) > 0 {
+					subscription.PushServerAlarms(alarms, "olympus")
+					(*Olympus)(o).publishAlarms(host, zone, alarms)
+				}
+			}
+			changed = true
+		}
+
+		if changed == true {
+			(*Olympus)(o).publishZoneSummary(host, zone)
 		}
 
 		if confirmation != nil {
@@ -119,12 +172,14 @@ func (o *OlympusGRPCWrapper) Tracking(stream api.Olympus_TrackingServer) (err er
 
 		graceful := err == nil
 		(*Olympus)(o).UnregisterTracker(ctx, hostname, graceful)
+		(*Olympus)(o).publishZoneSummary(hostname, "box")
 	}()
 	ack := &api.TrackingDownStream{}
 
 	handler := func(mCtx context.Context, m *api.TrackingUpStream) (*api.TrackingDownStream, error) {
+		changed := subscription == nil
 		if subscription == nil {
-			ctx = mCtx
+			ctx = withPeer(mCtx, stream.Context())
 			if m.Declaration == nil {
 				return nil, status.Error(codes.InvalidArgument, "first message of stream must contain TrackingDeclaration")
 			}
@@ -139,10 +194,21 @@ func (o *OlympusGRPCWrapper) Tracking(stream api.Olympus_TrackingServer) (err er
 		if len(m.Alarms) > 0 {
 			subscription.alarmLogger.PushAlarms(m.Alarms, "tracking")
 			subscription.NotifyAlarms(m.Alarms)
+			(*Olympus)(o).publishAlarms(hostname, "box", m.Alarms)
+			changed = true
 		}
 
 		if m.DiskStatus != nil {
-			subscription.object.PushDiskStatus(m.DiskStatus)
+			alarms := subscription.object.PushDiskStatus(m.DiskStatus)
+			if len(alarms) > 0 {
+				subscription.PushServerAlarms(alarms, "tracking")
+				(*Olympus)(o).publishAlarms(hostname, "box", alarms)
+			}
+			changed = true
+		}
+
+		if changed == true {
+			(*Olympus)(o).publishZoneSummary(hostname, "box")
 		}
 
 		return ack, nil
@@ -152,7 +218,90 @@ func (o *OlympusGRPCWrapper) Tracking(stream api.Olympus_TrackingServer) (err er
 		ctx, stream, handler)
 }
 
-func (o *OlympusGRPCWrapper) SendAlarm(ctx context.Context, update *api.AlarmUpdate) (*empty.Empty, error) {
-	go (*Olympus)(o).NotifyAlarm(ctx, "fake.zone", update)
+func (o *OlympusGRPCWrapper) GetClimateBacklogStatus(ctx context.Context, zone *api.ClimateZone) (*api.ClimateBacklogStatus, error) {
+	if err := checkClientIdentity(ctx, zone.Host); err != nil {
+		return nil, mapError(err)
+	}
+	return (*Olympus)(o).GetClimateBacklogStatus(zone.Host, zone.Name), nil
+}
+
+func (o *OlympusGRPCWrapper) ClimateBacklog(stream api.Olympus_ClimateBacklogServer) (err error) {
+	ctx := stream.Context()
+	var zone *api.ClimateZone
+	var backlog *climateBacklog
+	summary := &api.ClimateBacklogSummary{}
+
+	defer func() {
+		if err == nil || backlog == nil {
+			return
+		}
+		o.Log(ctx).WithFields(logrus.Fields{
+			"zone":     backlog.zone,
+			"received": summary.Received,
+			"stored":   summary.Stored,
+		}).WithError(err).Error("climate backlog stream error")
+	}()
+
+	for {
+		var m *api.ClimateBacklogUpStream
+		m, err = stream.Recv()
+		if err == io.EOF {
+			if zone != nil {
+				summary.LastTime = (*Olympus)(o).GetClimateBacklogStatus(zone.Host, zone.Name).LastTime
+			}
+			return stream.SendAndClose(summary)
+		}
+		if err != nil {
+			return err
+		}
+		if backlog == nil {
+			if m.Zone == nil {
+				return status.Error(codes.InvalidArgument, "first message of stream must contain ClimateZone")
+			}
+			if err = checkClientIdentity(ctx, m.Zone.Host); err != nil {
+				return mapError(err)
+			}
+			zone = m.Zone
+			backlog = newClimateBacklog(ZoneIdentifier(zone.Host, zone.Name),
+				(*Olympus)(o).climateStore)
+		}
+		err = (*Olympus)(o).storeClimateBacklog(ctx, backlog, m.Reports, summary)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+}
+
+// alarmToken returns the alarm source token of the incoming metadata.
+func alarmToken(ctx context.Context) string {
+	values := metadata.ValueFromIncomingContext(ctx, api.AlarmTokenMetadata)
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(values[0], "Bearer ")
+}
+
+func (o *OlympusGRPCWrapper) SendAlarm(ctx context.Context, m *api.ZoneAlarmUpdate) (*empty.Empty, error) {
+	if m.Zone == nil || m.Update == nil || len(m.Update.Identification) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "zone and an identified update are required")
+	}
+	err := (*Olympus)(o).SendZoneAlarm(ctx, alarmToken(ctx), m.Zone.Host, m.Zone.Name, m.Update)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &empty.Empty{}, nil
+}
+
+func (o *OlympusGRPCWrapper) Heartbeat(ctx context.Context, m *api.ZoneHeartbeat) (*empty.Empty, error) {
+	if len(m.Host) == 0 || len(m.Name) == 0 || m.Interval == nil {
+		return nil, status.Error(codes.InvalidArgument, "host, name and interval are required")
+	}
+	interval := m.Interval.AsDuration()
+	if interval < 0 {
+		return nil, status.Error(codes.InvalidArgument, "negative interval")
+	}
+	if err := (*Olympus)(o).Heartbeat(ctx, m.Host, m.Name, interval); err != nil {
+		return nil, mapError(err)
+	}
 	return &empty.Empty{}, nil
 }
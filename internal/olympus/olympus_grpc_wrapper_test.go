@@ -2,6 +2,7 @@ package olympus
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"time"
 
@@ -177,14 +178,19 @@ func (s *GRPCSuite) TestEndToEnd(c *C) {
 
 	report, err := s.o.GetZoneReport("somehost", "box")
 	if c.Check(err, IsNil) == true {
+		// the single live report deviates by 0.2°C from the target.
+		c.Check(report.Climate.TrackingErrors, HasLen, 4)
+		report.Climate.TrackingErrors = nil
 		c.Check(report, DeepEquals, &api.ZoneReport{
 			Host: "somehost",
 			Name: "box",
 			Climate: &api.ZoneClimateReport{
 				Since:             start.AsTime(),
 				Temperature:       &lastReports.Temperatures[0],
+				TemperatureAux:    lastReports.Temperatures[1:],
 				Humidity:          lastReports.Humidity,
 				Current:           target.Current.Clone(),
+				LastReportTime:    newInitialized(lastReports.Time.AsTime()),
 				TemperatureBounds: api.Bounds{},
 				HumidityBounds:    api.Bounds{},
 			},
@@ -221,6 +227,37 @@ func (s *GRPCSuite) TestDoubleZoneRegistrationError(c *C) {
 	c.Check(err, ErrorMatches, `rpc error: code = AlreadyExists desc = zone 'somehost.box' is already registered`)
 }
 
+func (s *GRPCSuite) TestDeduplicatesBacklogPages(c *C) {
+	stream, cleanUp, err := connectZone(c)
+	defer cleanUp()
+	c.Assert(err, IsNil)
+
+	name := fmt.Sprintf("backlog-%d", time.Now().UnixNano())
+	start := time.Now().Add(-time.Hour).Round(time.Second)
+	reports := make([]*api.ClimateReport, 4)
+	for i := range reports {
+		reports[i] = &api.ClimateReport{
+			Time:         timestamppb.New(start.Add(time.Duration(i) * time.Second)),
+			Temperatures: []float32{22.0},
+		}
+	}
+
+	messages := []*api.ClimateUpStream{
+		{Declaration: &api.ClimateDeclaration{Host: "somehost", Name: name}},
+		{Reports: reports[:3], Backlog: true},
+		{Reports: reports, Backlog: true},
+	}
+	for _, m := range messages {
+		c.Assert(stream.Send(m), IsNil)
+		_, err := stream.Recv()
+		c.Assert(err, IsNil)
+	}
+
+	stored, err := s.o.climateStore.Query("somehost."+name, start.Add(-time.Minute), time.Time{})
+	c.Assert(err, IsNil)
+	c.Check(stored, HasLen, 4)
+}
+
 func (s *GRPCSuite) TestLackOfClimateDeclarationError(c *C) {
 	stream, cleanUp, err := connectZone(c)
 	defer cleanUp()
@@ -231,3 +268,59 @@ func (s *GRPCSuite) TestLackOfClimateDeclarationError(c *C) {
 	c.Check(m, IsNil)
 	c.Check(err, ErrorMatches, `rpc error: code = InvalidArgument desc = first message of stream must contain ZoneDeclaration`)
 }
+
+func (s *GRPCSuite) TestPublishesEvents(c *C) {
+	events, unsubscribe := s.o.events.Subscribe()
+	defer unsubscribe()
+
+	stream, cleanUp, err := connectZone(c)
+	defer cleanUp()
+	c.Assert(err, IsNil)
+
+	c.Check(stream.Send(&api.ClimateUpStream{
+		Declaration: &api.ClimateDeclaration{Host: "somehost", Name: "box"},
+		Reports: []*api.ClimateReport{{
+			Time:         timestamppb.Now(),
+			Humidity:     newInitialized[float32](55.0),
+			Temperatures: []float32{22.0},
+		}},
+		Target: &api.ClimateTarget{
+			Current: &api.ClimateState{Temperature: newInitialized[float32](23.0)},
+		},
+		Alarms: []*api.AlarmUpdate{{
+			Identification: "climate.temperature",
+			Level:          api.AlarmLevel_WARNING,
+			Status:         api.AlarmStatus_ON,
+			Time:           timestamppb.Now(),
+		}},
+	}), IsNil)
+	_, err = stream.Recv()
+	c.Check(err, IsNil)
+
+	received := map[string]api.ZoneEvent{}
+	timeout := time.After(time.Second)
+	for len(received) < 5 {
+		select {
+		case e := <-events:
+			c.Check(e.Host, Equals, "somehost")
+			c.Check(e.Zone, Equals, "box")
+			received[e.Type] = e
+		case <-timeout:
+			c.Fatalf("missing events, got: %v", received)
+		}
+	}
+
+	c.Check(received[api.ServiceEventType].Service, DeepEquals, &api.ServiceStatus{
+		Identifier: "somehost.box.climate",
+		On:         true,
+		Graceful:   true,
+	})
+	c.Assert(received[api.ZoneSummaryEventType].Summary, NotNil)
+	c.Check(received[api.ZoneSummaryEventType].Summary.ActiveWarnings, Equals, 1)
+	c.Assert(received[api.TargetEventType].Target, NotNil)
+	c.Check(*received[api.TargetEventType].Target.Current.Temperature, Equals, float32(23.0))
+	c.Assert(received[api.ClimateEventType].Climate, HasLen, 1)
+	c.Check(*received[api.ClimateEventType].Climate[0].Humidity, Equals, float32(55.0))
+	c.Assert(received[api.AlarmsEventType].Alarms, HasLen, 1)
+	c.Check(received[api.AlarmsEventType].Alarms[0].On, Equals, true)
+}
@@ -1,19 +1,26 @@
 package olympus
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/formicidae-tracker/olympus/pkg/api"
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	. "gopkg.in/check.v1"
 )
@@ -227,9 +234,13 @@ func (s *OlympusSuite) TestRoute(c *C) {
 		{"GET", "/api/host/somehost/zone/box", ""},
 		{"GET", "/api/host/somehost/zone/box/climate?window=1d", ""},
 		{"GET", "/api/host/somehost/zone/box/alarms", ""},
+		{"GET", "/api/host/somehost/zone/box/climate?from=2023-01-01T00:00:00Z&to=2023-01-04T00:00:00Z", ""},
+		{"GET", "/api/host/somehost/zone/box/climate/export?format=ndjson", ""},
 		{"GET", "/api/host/somehosts/zone/box", "olympus: unknown zone 'somehosts.box'\n"},
 		{"GET", "/api/host/somehosts/zone/box/climate", "olympus: unknown zone 'somehosts.box'\n"},
 		{"GET", "/api/host/somehosts/zone/box/alarms", "olympus: unknown zone 'somehosts.box'\n"},
+		{"GET", "/api/host/somehosts/zone/box/climate?from=2023-01-01T00:00:00Z&to=2023-01-04T00:00:00Z", "olympus: unknown zone 'somehosts.box'\n"},
+		{"GET", "/api/host/somehosts/zone/box/climate/export", "olympus: unknown zone 'somehosts.box'\n"},
 	}
 
 	router := mux.NewRouter()
@@ -301,3 +312,581 @@ func (s *OlympusSuite) TestUnregisterClimate(c *C) {
 	c.Check(report.Tracking, Not(IsNil))
 
 }
+
+func (s *OlympusSuite) TestClimateIsRestoredOnRegistration(c *C) {
+	start := time.Now().Round(0).Add(-time.Minute)
+	reports := make([]*api.ClimateReport, 60)
+	for i := range reports {
+		reports[i] = &api.ClimateReport{
+			Time:         timestamppb.New(start.Add(time.Duration(i) * time.Second)),
+			Humidity:     newInitialized[float32](55.0),
+			Temperatures: []float32{21},
+		}
+	}
+	ctx := context.Background()
+	s.o.saveClimateReports(ctx, "somehost.box", reports)
+
+	c.Assert(s.o.UnregisterClimate(ctx, "somehost", "box", true), IsNil)
+	var err error
+	s.somehostBox, err = s.o.RegisterClimate(ctx, s.somehostClimateDefinition)
+	c.Assert(err, IsNil)
+
+	series, err := s.o.GetClimateTimeSerie("somehost", "box", "10m")
+	c.Check(err, IsNil)
+	c.Check(series.Humidity, HasLen, 60)
+	c.Check(series.Temperature, HasLen, 60)
+}
+
+func (s *OlympusSuite) TestTemperatureSensorsArePersisted(c *C) {
+	ctx := context.Background()
+	declaration := proto.Clone(s.somehostClimateDefinition).(*api.ClimateDeclaration)
+	declaration.TemperatureSensors = []*api.TemperatureSensor{{Name: "air"}, {Name: "nest"}}
+
+	c.Assert(s.o.UnregisterClimate(ctx, "somehost", "box", true), IsNil)
+	var err error
+	s.somehostBox, err = s.o.RegisterClimate(ctx, declaration)
+	c.Assert(err, IsNil)
+	c.Assert(s.o.UnregisterClimate(ctx, "somehost", "box", true), IsNil)
+
+	series, err := s.o.GetClimateTimeSeriesRange("somehost", "box", time.Time{}, time.Now(), 100)
+	c.Assert(err, IsNil)
+	c.Assert(series.TemperatureSensors, HasLen, 2)
+	c.Check(series.TemperatureSensors[1].Name, Equals, "nest")
+
+	// clients not declaring sensors reuse the persisted ones.
+	s.somehostBox, err = s.o.RegisterClimate(ctx, s.somehostClimateDefinition)
+	c.Assert(err, IsNil)
+	c.Check(s.somehostClimateDefinition.TemperatureSensors, HasLen, 0)
+	report, err := s.o.GetZoneReport("somehost", "box")
+	c.Assert(err, IsNil)
+	c.Assert(report.Climate, NotNil)
+	c.Assert(report.Climate.TemperatureSensors, HasLen, 2)
+	c.Check(report.Climate.TemperatureSensors[0].Name, Equals, "air")
+}
+
+func (s *OlympusSuite) TestStoresClimateBacklog(c *C) {
+	ctx := context.Background()
+	start := time.Now().Round(0).Add(-time.Hour).UTC()
+	report := func(i int) *api.ClimateReport {
+		return &api.ClimateReport{
+			Time:         timestamppb.New(start.Add(time.Duration(i) * time.Second)),
+			Humidity:     newInitialized[float32](55.0),
+			Temperatures: []float32{21},
+		}
+	}
+	s.o.saveClimateReports(ctx, "somehost.box", []*api.ClimateReport{report(0), report(1)})
+	c.Check(s.o.GetClimateBacklogStatus("somehost", "box").LastTime, IsNil)
+
+	backlog := newClimateBacklog("somehost.box", s.o.climateStore)
+	summary := &api.ClimateBacklogSummary{}
+	c.Assert(s.o.storeClimateBacklog(ctx, backlog,
+		[]*api.ClimateReport{report(1), report(2), report(3)}, summary), IsNil)
+	// duplicates within the upload are also dropped
+	c.Assert(s.o.storeClimateBacklog(ctx, backlog,
+		[]*api.ClimateReport{report(3), report(4)}, summary), IsNil)
+	c.Check(summary.Received, Equals, int64(5))
+	c.Check(summary.Stored, Equals, int64(3))
+	c.Assert(summary.LastTime, NotNil)
+	c.Check(summary.LastTime.AsTime().Equal(start.Add(4*time.Second)), Equals, true)
+
+	status := s.o.GetClimateBacklogStatus("somehost", "box")
+	c.Assert(status.LastTime, NotNil)
+	c.Check(status.LastTime.AsTime().Equal(start.Add(4*time.Second)), Equals, true)
+
+	reports, err := s.o.climateStore.Query("somehost.box", time.Time{}, time.Time{})
+	c.Assert(err, IsNil)
+	c.Check(reports, HasLen, 5)
+
+	series, err := s.o.GetClimateTimeSerie("somehost", "box", "10m")
+	c.Assert(err, IsNil)
+	c.Check(series.Temperature, HasLen, 3)
+}
+
+func (s *OlympusSuite) TestSendsExternalZoneAlarms(c *C) {
+	// sources are managed while running by the alarm-source command.
+	commandStore := NewAlarmSourceStore("alarm-sources")
+	token, err := commandStore.Add("freezer", []string{"lab.freezer"})
+	c.Assert(err, IsNil)
+	c.Check(s.o.ZoneIsRegistered("lab", "freezer"), Equals, false)
+
+	wrapper := (*OlympusGRPCWrapper)(s.o)
+	send := func(token, zone string, status api.AlarmStatus) error {
+		ctx := metadata.NewIncomingContext(context.Background(),
+			metadata.Pairs(api.AlarmTokenMetadata, "Bearer "+token))
+		_, err := wrapper.SendAlarm(ctx, &api.ZoneAlarmUpdate{
+			Zone: &api.ClimateZone{Host: "lab", Name: zone},
+			Update: &api.AlarmUpdate{
+				Identification: "freezer.temperature",
+				Level:          api.AlarmLevel_EMERGENCY,
+				Status:         status,
+				Description:    "freezer is above -70°C",
+			},
+		})
+		return err
+	}
+	c.Check(send("wrong", "freezer", api.AlarmStatus_ON), ErrorMatches, ".*code = Unauthenticated.*")
+	c.Check(send(token, "fridge", api.AlarmStatus_ON), ErrorMatches, ".*code = PermissionDenied.*")
+	c.Assert(send(token, "freezer", api.AlarmStatus_ON), IsNil)
+	c.Check(s.o.ZoneIsRegistered("lab", "freezer"), Equals, true)
+
+	var summary *api.ZoneReportSummary
+	zones := s.o.GetZones()
+	for i, z := range zones {
+		if z.Host == "lab" && z.Name == "freezer" {
+			summary = &zones[i]
+		}
+	}
+	c.Assert(summary, NotNil)
+	c.Check(summary.Climate, IsNil)
+	c.Check(summary.Tracking, IsNil)
+	c.Check(summary.ActiveEmergencies, Equals, 1)
+
+	c.Assert(send(token, "freezer", api.AlarmStatus_OFF), IsNil)
+	reports, err := s.o.GetAlarmReports("lab", "freezer")
+	c.Assert(err, IsNil)
+	c.Assert(reports, HasLen, 1)
+	c.Check(reports[0].Events, HasLen, 1)
+	c.Check(reports[0].Events[0].End, NotNil)
+
+	c.Assert(commandStore.Remove("freezer"), IsNil)
+	c.Check(send(token, "freezer", api.AlarmStatus_ON), ErrorMatches, ".*code = Unauthenticated.*")
+}
+
+func (s *OlympusSuite) TestExternalZonesAreRegisteredOnStartup(c *C) {
+	_, err := NewAlarmSourceStore("alarm-sources").Add("freezer", []string{"lab.freezer"})
+	c.Assert(err, IsNil)
+	s.o.mx.Lock()
+	s.o.registerExternalZones()
+	s.o.mx.Unlock()
+	c.Check(s.o.ZoneIsRegistered("lab", "freezer"), Equals, true)
+}
+
+func (s *OlympusSuite) TestMonitorsHeartbeats(c *C) {
+	ctx := context.Background()
+	c.Check(s.o.Heartbeat(ctx, "lab", "incubator", -time.Second), NotNil)
+	c.Assert(s.o.Heartbeat(ctx, "lab", "incubator", time.Minute), IsNil)
+
+	report, err := s.o.GetZoneReport("lab", "incubator")
+	c.Assert(err, IsNil)
+	c.Assert(report.Heartbeat, NotNil)
+	c.Check(report.Heartbeat.Interval, Equals, time.Minute)
+	c.Check(report.Heartbeat.Missed, Equals, false)
+
+	activeEmergencies := func() int {
+		for _, z := range s.o.GetZones() {
+			if z.Host == "lab" && z.Name == "incubator" {
+				c.Check(z.Heartbeat, NotNil)
+				return z.ActiveEmergencies
+			}
+		}
+		c.Fatalf("zone lab.incubator is not listed")
+		return 0
+	}
+	serviceOn := func() bool {
+		for _, l := range s.o.GetServiceLogs() {
+			if l.Zone == "lab.incubator.heartbeat" {
+				return l.On()
+			}
+		}
+		return false
+	}
+	c.Check(serviceOn(), Equals, true)
+
+	stopHeartbeats := func(d time.Duration) {
+		s.o.mx.Lock()
+		defer s.o.mx.Unlock()
+		s.o.subscriptions["lab.incubator"].heartbeat.last = time.Now().Add(-d)
+	}
+	stopHeartbeats(time.Minute)
+	s.o.checkHeartbeats(ctx, time.Now())
+	c.Check(activeEmergencies(), Equals, 0)
+	stopHeartbeats(3 * time.Minute)
+	s.o.checkHeartbeats(ctx, time.Now())
+	c.Check(activeEmergencies(), Equals, 1)
+	c.Check(serviceOn(), Equals, false)
+	alarms, err := s.o.GetAlarmReports("lab", "incubator")
+	c.Assert(err, IsNil)
+	c.Assert(alarms, HasLen, 1)
+	c.Check(alarms[0].Identification, Equals, HeartbeatMissedIdentification)
+	c.Check(alarms[0].Level, Equals, api.AlarmLevel_EMERGENCY)
+
+	c.Assert(s.o.Heartbeat(ctx, "lab", "incubator", time.Minute), IsNil)
+	c.Check(activeEmergencies(), Equals, 0)
+	c.Check(serviceOn(), Equals, true)
+
+	// virtual zones are restored on restart
+	restored := NewPersistentMap[time.Duration]("heartbeats")
+	c.Check(restored.Map["lab.incubator"], Equals, time.Minute)
+
+	c.Assert(s.o.Heartbeat(ctx, "lab", "incubator", 0), IsNil)
+	c.Check(s.o.ZoneIsRegistered("lab", "incubator"), Equals, false)
+	c.Check(serviceOn(), Equals, false)
+	c.Check(s.o.Heartbeat(ctx, "lab", "incubator", 0), Equals, ZoneNotFoundError("lab.incubator"))
+	restored = NewPersistentMap[time.Duration]("heartbeats")
+	c.Check(restored.Map, HasLen, 0)
+}
+
+func (s *OlympusSuite) TestClimateTimeSeriesRange(c *C) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	reports := make([]*api.ClimateReport, 3*24*60)
+	for i := range reports {
+		reports[i] = &api.ClimateReport{
+			Time:         timestamppb.New(start.Add(time.Duration(i) * time.Minute)),
+			Humidity:     newInitialized[float32](55.0),
+			Temperatures: []float32{21, 22},
+		}
+	}
+	s.o.saveClimateReports(context.Background(), "somehost.box", reports)
+	s.o.saveClimateTarget(context.Background(), "somehost.box", start.Add(12*time.Hour),
+		&api.ClimateTarget{Current: &api.ClimateState{Temperature: newInitialized[float32](23.0)}})
+
+	end := start.Add(3 * 24 * time.Hour)
+	series, err := s.o.GetClimateTimeSeriesRange("somehost", "box", start, end, 100)
+	c.Assert(err, IsNil)
+	c.Check(series.Reference.Equal(end), Equals, true)
+	c.Check(series.Units, Equals, "d")
+	c.Check(series.Humidity, HasLen, 100)
+	c.Check(series.Temperature, HasLen, 100)
+	c.Check(series.TemperatureAux, HasLen, 1)
+	c.Check(series.TemperatureTarget, DeepEquals, api.PointSeries{{X: -2.5, Y: 23}, {X: 0, Y: 23}})
+	c.Check(series.HumidityTarget, HasLen, 0)
+
+	targets, err := s.o.GetClimateTargets("somehost", "box", time.Time{}, time.Time{})
+	c.Check(err, IsNil)
+	c.Check(targets, HasLen, 1)
+	_, err = s.o.GetClimateTargets("fifou", "bar", time.Time{}, time.Time{})
+	c.Check(err, ErrorMatches, "olympus: unknown zone 'fifou.bar'")
+
+	series, err = s.o.GetClimateTimeSeriesRange("somehost", "box",
+		start.Add(24*time.Hour), start.Add(24*time.Hour+30*time.Minute), 100)
+	c.Assert(err, IsNil)
+	c.Check(series.Units, Equals, "m")
+	c.Check(series.Humidity, HasLen, 31)
+
+	series, err = s.o.GetClimateTimeSeriesRange("another", "box", start, end, 100)
+	c.Check(err, IsNil)
+	c.Check(series.Humidity, HasLen, 0)
+
+	_, err = s.o.GetClimateTimeSeriesRange("fifou", "bar", start, end, 100)
+	c.Check(err, ErrorMatches, "olympus: unknown zone 'fifou.bar'")
+}
+
+func (s *OlympusSuite) TestParseClimateRange(c *C) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	testdata := []struct {
+		Query   string
+		From    time.Time
+		To      time.Time
+		Samples int
+		Error   string
+	}{
+		{"to=2023-01-01T10:00:00Z", now.Add(-2*time.Hour - 10*time.Minute), now.Add(-2 * time.Hour), DefaultClimateSamples, ""},
+		{"from=2023-01-01T10:00:00Z&samples=42", now.Add(-2 * time.Hour), now, 42, ""},
+		{"from=2023-01-01T10:00:00Z&to=2023-01-01T09:00:00Z", time.Time{}, time.Time{}, 0, "'from' must be before 'to'"},
+		{"from=yesterday", time.Time{}, time.Time{}, 0, "invalid 'from' parameter: .*"},
+		{"from=2000-01-01T00:00:00Z", time.Time{}, time.Time{}, 0, "range must not be longer than 744h0m0s"},
+		{"from=2023-01-01T10:00:00Z&samples=1", time.Time{}, time.Time{}, 0, `'samples' must be in \[3,5000\]`},
+	}
+
+	for _, d := range testdata {
+		comment := Commentf("query: %s", d.Query)
+		query, err := url.ParseQuery(d.Query)
+		c.Assert(err, IsNil, comment)
+		from, to, samples, err := parseClimateRange(query, now)
+		if len(d.Error) > 0 {
+			c.Check(err, ErrorMatches, d.Error, comment)
+			continue
+		}
+		if c.Check(err, IsNil, comment) == false {
+			continue
+		}
+		c.Check(from.Equal(d.From), Equals, true, comment)
+		c.Check(to.Equal(d.To), Equals, true, comment)
+		c.Check(samples, Equals, d.Samples, comment)
+	}
+}
+
+func (s *OlympusSuite) TestAlarmsAreAvailableForOfflineZones(c *C) {
+	ctx := context.Background()
+	s.anotherBox.alarmLogger.PushAlarms([]*api.AlarmUpdate{
+		{
+			Identification: "temperature.out-of-bound",
+			Level:          api.AlarmLevel_EMERGENCY,
+			Status:         api.AlarmStatus_ON,
+			Time:           timestamppb.New(time.Now().Add(-time.Minute)),
+		},
+	}, "climate")
+
+	c.Assert(s.o.UnregisterClimate(ctx, "another", "box", true), IsNil)
+	defer func() {
+		var err error
+		s.anotherBox, err = s.o.RegisterClimate(ctx, &api.ClimateDeclaration{
+			Host:  "another",
+			Name:  "box",
+			Since: timestamppb.Now(),
+		})
+		c.Check(err, IsNil)
+		reports, err := s.o.GetAlarmReports("another", "box")
+		c.Check(err, IsNil)
+		if c.Check(reports, HasLen, 1) == true {
+			// cleared by the new registration
+			c.Check(reports[0].Events[0].End, NotNil)
+		}
+	}()
+
+	reports, err := s.o.GetAlarmReports("another", "box")
+	c.Assert(err, IsNil)
+	c.Assert(reports, HasLen, 1)
+	c.Check(reports[0].Identification, Equals, "climate.temperature.out-of-bound")
+	c.Check(reports[0].Events, HasLen, 1)
+
+	_, err = s.o.GetAlarmReports("another", "tunnel")
+	c.Check(err, IsNil)
+	_, err = s.o.GetAlarmReports("fifou", "bar")
+	c.Check(err, ErrorMatches, "olympus: unknown zone 'fifou.bar'")
+}
+
+func (s *OlympusSuite) TestAcknowledgeAlarm(c *C) {
+	ctx := context.Background()
+	s.somehostBox.alarmLogger.PushAlarms([]*api.AlarmUpdate{
+		{
+			Identification: "temperature.out-of-bound",
+			Level:          api.AlarmLevel_EMERGENCY,
+			Status:         api.AlarmStatus_ON,
+			Time:           timestamppb.New(time.Now().Add(-time.Minute)),
+		},
+	}, "climate")
+
+	ack, err := s.o.AcknowledgeAlarm(ctx, "somehost", "box", "climate.temperature.out-of-bound", "someone")
+	c.Assert(err, IsNil)
+	c.Check(ack.By, Equals, "someone")
+
+	reports, err := s.o.GetAlarmReports("somehost", "box")
+	c.Assert(err, IsNil)
+	c.Assert(reports, HasLen, 1)
+	c.Assert(reports[0].Acknowledgement, NotNil)
+	c.Check(reports[0].Acknowledgement.By, Equals, "someone")
+
+	_, err = s.o.AcknowledgeAlarm(ctx, "somehost", "box", "climate.temperature.out-of-bound", "someone")
+	c.Check(acknowledgeErrorStatus(err), Equals, http.StatusConflict)
+	_, err = s.o.AcknowledgeAlarm(ctx, "somehost", "box", "climate.humidity.out-of-bound", "someone")
+	c.Check(acknowledgeErrorStatus(err), Equals, http.StatusNotFound)
+	_, err = s.o.AcknowledgeAlarm(ctx, "fifou", "box", "climate.humidity.out-of-bound", "someone")
+	c.Check(acknowledgeErrorStatus(err), Equals, http.StatusNotFound)
+}
+
+func (s *OlympusSuite) TestMaintenanceWindows(c *C) {
+	var err error
+	s.o.csrfHandler, err = NewCSRFHandler([]byte("some secret"))
+	c.Assert(err, IsNil)
+	router := mux.NewRouter()
+	s.o.setRoutes(router)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	options := MaintenanceClientOptions{URL: server.URL}
+
+	add := &AddMaintenanceCommand{
+		MaintenanceClientOptions: options,
+		Zone:                     "somehost.box",
+		Duration:                 time.Hour,
+		Reason:                   "cleaning",
+	}
+	window, err := add.window(time.Now())
+	c.Assert(err, IsNil)
+	c.Assert(options.do("POST", "/api/maintenance", window, &window), IsNil)
+	c.Check(window.ID, Not(Equals), "")
+
+	invalid := window
+	invalid.End = invalid.Start
+	c.Check(options.do("POST", "/api/maintenance", invalid, nil), ErrorMatches,
+		".*400 Bad Request: olympus: invalid maintenance window: end must be after start")
+
+	zones := s.o.GetZones()
+	c.Assert(zones, HasLen, 4)
+	for _, z := range zones {
+		if z.Host == "somehost" && z.Name == "box" {
+			c.Check(z.Maintenance, DeepEquals, &window)
+		} else {
+			c.Check(z.Maintenance, IsNil, Commentf("%s.%s", z.Host, z.Name))
+		}
+	}
+
+	remove := &RemoveMaintenanceCommand{MaintenanceClientOptions: options}
+	remove.Args.ID = window.ID
+	c.Check(remove.Execute(nil), IsNil)
+	c.Check(remove.Execute(nil), ErrorMatches, ".*404 Not Found: olympus: unknown maintenance window '.*'")
+	c.Check(s.o.GetMaintenanceWindows(), HasLen, 0)
+}
+
+func (s *OlympusSuite) TestWebhookRoutesRequireAdminToken(c *C) {
+	s.o.adminToken = "some token"
+	router := mux.NewRouter()
+	s.o.setRoutes(router)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	do := func(method, path, token string, body interface{}, res interface{}) int {
+		var reader io.Reader
+		if body != nil {
+			data, err := json.Marshal(body)
+			c.Assert(err, IsNil)
+			reader = bytes.NewReader(data)
+		}
+		req, err := http.NewRequest(method, server.URL+path, reader)
+		c.Assert(err, IsNil)
+		req.Header.Set("Content-Type", "application/json")
+		if len(token) > 0 {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		c.Assert(err, IsNil)
+		defer resp.Body.Close()
+		if res != nil && resp.StatusCode == http.StatusOK {
+			c.Check(json.NewDecoder(resp.Body).Decode(res), IsNil)
+		}
+		return resp.StatusCode
+	}
+
+	c.Check(do("GET", "/api/webhooks", "", nil, nil), Equals, http.StatusUnauthorized)
+	c.Check(do("GET", "/api/webhooks", "wrong token", nil, nil), Equals, http.StatusUnauthorized)
+
+	var webhook api.Webhook
+	c.Assert(do("POST", "/api/webhooks", "some token",
+		api.Webhook{URL: "https://example.com/hook"}, &webhook), Equals, http.StatusOK)
+	c.Check(webhook.Secret, Not(Equals), "")
+
+	var webhooks []api.Webhook
+	c.Check(do("GET", "/api/webhooks", "some token", nil, &webhooks), Equals, http.StatusOK)
+	c.Check(webhooks, DeepEquals, []api.Webhook{{ID: webhook.ID, URL: webhook.URL}})
+
+	var deliveries []api.WebhookDelivery
+	c.Check(do("GET", "/api/webhooks/"+webhook.ID+"/deliveries", "some token", nil, &deliveries),
+		Equals, http.StatusOK)
+	c.Check(deliveries, HasLen, 0)
+
+	c.Check(do("POST", "/api/webhooks/"+webhook.ID+"/settings", "some token",
+		api.NotificationSettings{SubscribeToAll: true}, nil), Equals, http.StatusOK)
+	c.Check(do("DELETE", "/api/webhooks/"+webhook.ID, "", nil, nil), Equals, http.StatusUnauthorized)
+	c.Check(do("DELETE", "/api/webhooks/"+webhook.ID, "some token", nil, nil), Equals, http.StatusOK)
+	c.Check(do("DELETE", "/api/webhooks/"+webhook.ID, "some token", nil, nil), Equals, http.StatusNotFound)
+	c.Check(do("GET", "/api/webhooks/"+webhook.ID+"/deliveries", "some token", nil, nil),
+		Equals, http.StatusNotFound)
+}
+
+func (s *OlympusSuite) TestEventStream(c *C) {
+	router := mux.NewRouter()
+	s.o.setRoutes(router)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/events?host=somehost&zone=box")
+	c.Assert(err, IsNil)
+	defer resp.Body.Close()
+	c.Check(resp.Header.Get("Content-Type"), Equals, "text/event-stream")
+
+	reader := bufio.NewReader(resp.Body)
+	readEvent := func() (string, api.ZoneEvent) {
+		var name string
+		var event api.ZoneEvent
+		for {
+			line, err := reader.ReadString('\n')
+			c.Assert(err, IsNil)
+			line = strings.TrimSuffix(line, "\n")
+			if len(line) == 0 {
+				return name, event
+			}
+			if strings.HasPrefix(line, "event: ") {
+				name = strings.TrimPrefix(line, "event: ")
+			} else if strings.HasPrefix(line, "data: ") {
+				c.Assert(json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event), IsNil)
+			}
+		}
+	}
+
+	name, snapshot := readEvent()
+	c.Check(name, Equals, api.ZonesEventType)
+	c.Assert(snapshot.Zones, HasLen, 1)
+	c.Check(snapshot.Zones[0].Host, Equals, "somehost")
+	c.Check(snapshot.Zones[0].Name, Equals, "box")
+
+	s.o.events.Publish(api.ZoneEvent{Type: api.AlarmsEventType, Host: "another", Zone: "box"})
+	s.o.events.Publish(api.ZoneEvent{Type: api.ClimateEventType, Host: "somehost", Zone: "box"})
+	name, event := readEvent()
+	c.Check(name, Equals, api.ClimateEventType)
+	c.Check(event.Host, Equals, "somehost")
+
+	// closing the hub ends the stream.
+	s.o.events.Close()
+	_, err = reader.ReadString('\n')
+	c.Check(err, Equals, io.EOF)
+}
+
+func (s *OlympusSuite) TestMetrics(c *C) {
+	s.somehostBox.object.PushReports([]*api.ClimateReport{
+		{
+			Time:         timestamppb.Now(),
+			Humidity:     newInitialized[float32](55.0),
+			Temperatures: []float32{21, 22.5},
+		},
+	})
+
+	router := mux.NewRouter()
+	s.o.setRoutes(router)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/metrics")
+	c.Assert(err, IsNil)
+	defer resp.Body.Close()
+	c.Check(resp.StatusCode, Equals, http.StatusOK)
+	c.Check(resp.Header.Get("Content-Type"), Equals, "text/plain; version=0.0.4; charset=utf-8")
+	body, err := io.ReadAll(resp.Body)
+	c.Assert(err, IsNil)
+
+	for _, expected := range []string{
+		"# TYPE olympus_zone_temperature_celsius gauge\n",
+		`olympus_zone_temperature_celsius{host="somehost",zone="box"} 21` + "\n",
+		`olympus_zone_aux_temperature_celsius{host="somehost",zone="box",sensor="1"} 22.5` + "\n",
+		`olympus_zone_humidity_percent{host="somehost",zone="box"} 55` + "\n",
+		`olympus_zone_active_alarms{host="another",zone="tunnel",level="warning"} 0` + "\n",
+	} {
+		c.Check(strings.Contains(string(body), expected), Equals, true, Commentf("missing %s in:\n%s", expected, body))
+	}
+}
+
+func (s *OlympusSuite) TestMetricsNameAuxiliarySensors(c *C) {
+	m := newZoneMetrics()
+	m.addClimate("somehost", "box", &api.ZoneClimateReport{
+		TemperatureAux: []float32{22.5, 23, 24},
+		TemperatureSensors: []api.SensorDescription{
+			{Name: "air"}, {Name: "nest"}, {},
+		},
+	})
+	buffer := bytes.NewBuffer(nil)
+	c.Assert(m.temperatureAux.write(buffer), IsNil)
+	c.Check(buffer.String(), Matches, "(?s).*"+
+		`olympus_zone_aux_temperature_celsius{host="somehost",zone="box",sensor="nest"} 22.5`+"\n"+
+		`olympus_zone_aux_temperature_celsius{host="somehost",zone="box",sensor="2"} 23`+"\n"+
+		`olympus_zone_aux_temperature_celsius{host="somehost",zone="box",sensor="3"} 24`+"\n")
+}
+
+func (s *OlympusSuite) TestMetricsEscapeLabels(c *C) {
+	f := newGauge("foo", "A foo.")
+	f.add(1.5, "name", "a \"quoted\"\\name\n")
+	buffer := bytes.NewBuffer(nil)
+	c.Assert(f.write(buffer), IsNil)
+	c.Check(buffer.String(), Equals, "# HELP foo A foo.\n# TYPE foo gauge\n"+
+		`foo{name="a \"quoted\"\\name\n"} 1.5`+"\n")
+}
+
+func (s *OlympusSuite) TestRaisesStaleClimateAlarms(c *C) {
+	s.o.checkStaleClimates(time.Now().Add(2 * ClimateStalePeriod))
+
+	_, _, warnings := s.somehostBox.alarmLogger.ActiveAlarmsCount()
+	c.Check(warnings, Equals, 1)
+	reports := s.somehostBox.alarmLogger.GetReports()
+	c.Assert(reports, HasLen, 1)
+	c.Check(reports[0].Identification, Equals, "climate."+ClimateStaleAlarmIdentification)
+	c.Check(s.somehostBox.object.GetClimateReport().Stale, Equals, true)
+}
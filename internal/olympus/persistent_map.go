@@ -4,6 +4,7 @@ import (
 	"crypto/md5"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -125,6 +126,22 @@ func (m *PersistentMap[T]) SaveKey(key string) (err error) {
 	return enc.Encode(data)
 }
 
+// DeleteKey removes a key from the map and from the disk.
+func (m *PersistentMap[T]) DeleteKey(key string) error {
+	delete(m.Map, key)
+	opKey := m.opaqueKey(key)
+	if m.opaqueKeys[opKey] != key {
+		// never saved
+		return nil
+	}
+	delete(m.opaqueKeys, opKey)
+	err := os.Remove(filepath.Join(m.path, opKey[:2], opKey+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
 func (m *PersistentMap[T]) opaqueKey(key string) string {
 	// md5 collisions are very rare, but they do exists. Here it is
 	// how we would resolve them. We store each saved opaqueKey in
@@ -71,6 +71,17 @@ func (s *PersistentMapSuite) TestValuePersistence(c *C) {
 
 }
 
+func (s *PersistentMapSuite) TestKeyDeletion(c *C) {
+	s.m.Map["foo"] = "something"
+	s.m.Map["bar"] = "something else"
+	c.Assert(s.m.Save(), IsNil)
+	c.Check(s.m.DeleteKey("foo"), IsNil)
+	c.Check(s.m.DeleteKey("baz"), IsNil)
+
+	newMap := NewPersistentMap[string]("unit-test")
+	c.Check(newMap.Map, DeepEquals, map[string]string{"bar": "something else"})
+}
+
 func (s *PersistentMapSuite) TestHashCollisionResolution(c *C) {
 	s.m.Map[s.collidingKeys[0]] = "value0"
 	s.m.SaveKey(s.collidingKeys[0])
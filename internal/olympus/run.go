@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/signal"
 	"sync"
+	"time"
 
 	"github.com/formicidae-tracker/olympus/pkg/api"
 	"github.com/formicidae-tracker/olympus/pkg/tm"
@@ -15,6 +16,7 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 //go:generate  go run generate_version.go $VERSION
@@ -27,25 +29,119 @@ func Execute() error {
 type RunCommand struct {
 	Verbose []bool `long:"verbose" short:"v" description:"enables verbose logging, set multiple time to increase the level"`
 
-	Address      string   `long:"http-listen" short:"l" description:"Address for the HTTP server" default:":3000"`
-	RPC          int      `long:"rpc-listen" short:"r" description:"Port for the RPC Service" default:"3001"`
+	Config string `long:"config" short:"c" description:"YAML configuration file, overridden by the environment and by the options below" env:"OLYMPUS_CONFIG"`
+
+	Address      *string  `long:"http-listen" short:"l" description:"Address for the HTTP server"`
+	RPC          *int     `long:"rpc-listen" short:"r" description:"Port for the RPC Service"`
 	AllowCORS    []string `long:"allow-cors" description:"allow cors from domain"`
-	OtelEndpoint string   `long:"otel-exporter" description:"Open Telemetry exporter endpoint" env:"OLYMPUS_OTEL_ENDPOINT"`
+	OtelEndpoint *string  `long:"otel-exporter" description:"Open Telemetry exporter endpoint"`
+
+	ClimateRetention *time.Duration `long:"climate-retention" description:"Duration raw climate data is kept on disk, 0 to keep it forever"`
+	AlarmRetention   *time.Duration `long:"alarm-retention" description:"Duration alarm events are kept on disk, 0 to keep them forever"`
+
+	Escalation   []EscalationStep `long:"escalation" description:"Re-notifies unacknowledged emergencies after a delay, to the original subscribers ('15m') or to the escalation group ('30m:group'). Can be set multiple times"`
+	NoEscalation bool             `long:"no-escalation" description:"Disables escalation of unacknowledged emergencies"`
+
+	BoundCheck            bool           `long:"bound-check" description:"Raises alarms when climate reports are out of their declared bounds, independently of the clients"`
+	TemperatureHysteresis *float32       `long:"temperature-hysteresis" description:"Distance in °C temperature must come back inside its bounds to clear its alarm"`
+	HumidityHysteresis    *float32       `long:"humidity-hysteresis" description:"Distance in % humidity must come back inside its bounds to clear its alarm"`
+	BoundMinimumDuration  *time.Duration `long:"bound-minimum-duration" description:"Duration a value must stay out of its bounds to raise an alarm"`
+
+	TrackingErrorAlarm       bool           `long:"tracking-error-alarm" description:"Raises alarms when climate zones deviate from their target"`
+	TemperatureDeviation     *float32       `long:"temperature-deviation" description:"Maximal deviation in °C from the target temperature"`
+	HumidityDeviation        *float32       `long:"humidity-deviation" description:"Maximal deviation in % from the target humidity"`
+	DeviationMinimumDuration *time.Duration `long:"deviation-minimum-duration" description:"Duration a zone must deviate from its target to raise an alarm"`
+
+	ClimateStaleAfter *time.Duration `long:"climate-stale-after" description:"Raises a warning when a climate zone sends no report for this duration, 0 to disable"`
+
+	DiskFullWarning   *time.Duration `long:"disk-full-warning" description:"Raises a warning when a tracking disk is forecasted to be full within this duration"`
+	DiskFullEmergency *time.Duration `long:"disk-full-emergency" description:"Raises an emergency when a tracking disk is forecasted to be full within this duration"`
+
+	SessionDuration *time.Duration `long:"session-duration" description:"Duration of the web sessions opened by users"`
+
+	TLSCert     *string `long:"tls-cert" description:"Certificate of the RPC Service, enables TLS"`
+	TLSKey      *string `long:"tls-key" description:"Key of the RPC Service certificate"`
+	TLSClientCA *string `long:"tls-client-ca" description:"Authority of the RPC client certificates, requires clients to authenticate with a certificate identifying their host"`
+}
+
+// override sets the options given on the command line in the
+// configuration.
+func (c *RunCommand) override(config *Config) {
+	overrideWith(&config.HTTP.Address, c.Address)
+	overrideWith(&config.RPC.Port, c.RPC)
+	if len(c.AllowCORS) > 0 {
+		config.HTTP.AllowCORS = c.AllowCORS
+	}
+	overrideWith(&config.OtelEndpoint, c.OtelEndpoint)
+	overrideWith(&config.Retention.Climate, c.ClimateRetention)
+	overrideWith(&config.Retention.Alarms, c.AlarmRetention)
+	if len(c.Escalation) > 0 {
+		config.Notifications.Escalation = c.Escalation
+	}
+	if c.NoEscalation == true {
+		config.Notifications.Escalation = nil
+	}
+	if c.BoundCheck == true {
+		config.Climate.BoundCheck.Enabled = true
+	}
+	overrideWith(&config.Climate.BoundCheck.TemperatureHysteresis, c.TemperatureHysteresis)
+	overrideWith(&config.Climate.BoundCheck.HumidityHysteresis, c.HumidityHysteresis)
+	overrideWith(&config.Climate.BoundCheck.MinimumDuration, c.BoundMinimumDuration)
+	if c.TrackingErrorAlarm == true {
+		config.Climate.TargetTracking.Enabled = true
+	}
+	overrideWith(&config.Climate.TargetTracking.TemperatureDeviation, c.TemperatureDeviation)
+	overrideWith(&config.Climate.TargetTracking.HumidityDeviation, c.HumidityDeviation)
+	overrideWith(&config.Climate.TargetTracking.MinimumDuration, c.DeviationMinimumDuration)
+	overrideWith(&config.Climate.StaleAfter, c.ClimateStaleAfter)
+	overrideWith(&config.Tracking.DiskFullWarning, c.DiskFullWarning)
+	overrideWith(&config.Tracking.DiskFullEmergency, c.DiskFullEmergency)
+	overrideWith(&config.HTTP.SessionDuration, c.SessionDuration)
+	overrideWith(&config.RPC.TLSCert, c.TLSCert)
+	overrideWith(&config.RPC.TLSKey, c.TLSKey)
+	overrideWith(&config.RPC.TLSClientCA, c.TLSClientCA)
+}
+
+func overrideWith[T any](dst *T, option *T) {
+	if option != nil {
+		*dst = *option
+	}
+}
+
+// configuration returns the validated configuration of the service.
+func (c *RunCommand) configuration() (*Config, error) {
+	config, err := LoadConfig(c.Config)
+	if err != nil {
+		return nil, err
+	}
+	c.override(config)
+	return config, config.Validate()
 }
 
 func (c *RunCommand) Execute([]string) error {
-	c.setLogger()
+	config, err := c.configuration()
+	if err != nil {
+		return err
+	}
+	c.setLogger(config.OtelEndpoint)
 	defer tm.Shutdown(context.Background())
 
+	config.Apply()
+
+	rpcCredentials, err := rpcCredentials(config.RPC)
+	if err != nil {
+		return err
+	}
+
 	o, err := NewOlympus()
 	if err != nil {
 		return err
 	}
 
-	httpServer := c.setUpHttpServer(o)
-	rpcServer := c.setUpRpcServer(o)
+	httpServer := setUpHttpServer(o, config.HTTP)
+	rpcServer := setUpRpcServer(o, rpcCredentials)
 
-	httpLog := tm.NewLogger("http").WithField("address", c.Address)
+	httpLog := tm.NewLogger("http").WithField("address", config.HTTP.Address)
 
 	wg := sync.WaitGroup{}
 	wg.Add(1)
@@ -58,11 +154,11 @@ func (c *RunCommand) Execute([]string) error {
 		wg.Done()
 	}()
 
-	rpcLog := tm.NewLogger("gRPC").WithField("port", c.RPC)
+	rpcLog := tm.NewLogger("gRPC").WithField("port", config.RPC.Port)
 
 	wg.Add(1)
 	go func() {
-		l, err := net.Listen("tcp", fmt.Sprintf(":%d", c.RPC))
+		l, err := net.Listen("tcp", fmt.Sprintf(":%d", config.RPC.Port))
 		if err != nil {
 			rpcLog.WithError(err).Errorf("could not listen")
 			return
@@ -100,7 +196,7 @@ func (c *RunCommand) Execute([]string) error {
 
 }
 
-func (c *RunCommand) setUpHttpServer(o *Olympus) GracefulServer {
+func setUpHttpServer(o *Olympus, config HTTPConfig) GracefulServer {
 	router := mux.NewRouter()
 	o.setRoutes(router)
 	logger := tm.NewLogger("http")
@@ -110,18 +206,40 @@ func (c *RunCommand) setUpHttpServer(o *Olympus) GracefulServer {
 	} else {
 		router.Use(HTTPLogWrap(logger))
 	}
-	if len(c.AllowCORS) > 0 {
-		router.Use(EnableCORS(c.AllowCORS))
+	if len(config.AllowCORS) > 0 {
+		router.Use(EnableCORS(config.AllowCORS))
 	}
+	// rejected requests must still carry the CORS headers.
+	router.Use(o.authenticator.Middleware)
 	httpServer := &http.Server{
-		Addr:    c.Address,
+		Addr:    config.Address,
 		Handler: router,
 	}
+	// event streams never become idle, they must be closed for a
+	// graceful shutdown.
+	httpServer.RegisterOnShutdown(o.events.Close)
 	return NewGracefulServer(httpServer)
 }
 
-func (c *RunCommand) setUpRpcServer(o *Olympus) *grpc.Server {
+// rpcCredentials returns the TLS credentials of the RPC Service, or
+// nil if TLS is not enabled.
+func rpcCredentials(config RPCConfig) (credentials.TransportCredentials, error) {
+	if len(config.TLSCert) == 0 {
+		tm.NewLogger("gRPC").Warn("TLS is not enabled, any client can declare any zone")
+		return nil, nil
+	}
+	tlsConfig, err := api.NewServerTLSConfig(config.TLSCert, config.TLSKey, config.TLSClientCA)
+	if err != nil {
+		return nil, fmt.Errorf("could not load TLS configuration: %w", err)
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+func setUpRpcServer(o *Olympus, creds credentials.TransportCredentials) *grpc.Server {
 	options := append([]grpc.ServerOption{}, api.DefaultServerOptions...)
+	if creds != nil {
+		options = append(options, grpc.Creds(creds))
+	}
 	if tm.Enabled() {
 		options = append(options,
 			grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor()),
@@ -134,10 +252,10 @@ func (c *RunCommand) setUpRpcServer(o *Olympus) *grpc.Server {
 	return server
 }
 
-func (c *RunCommand) setLogger() {
-	if len(c.OtelEndpoint) > 0 {
+func (c *RunCommand) setLogger(otelEndpoint string) {
+	if len(otelEndpoint) > 0 {
 		tm.SetUpTelemetry(tm.OtelProviderArgs{
-			CollectorURL:   c.OtelEndpoint,
+			CollectorURL:   otelEndpoint,
 			ServiceName:    "olympus",
 			ServiceVersion: OLYMPUS_VERSION,
 			Level:          tm.VerboseLevel(len(c.Verbose)),
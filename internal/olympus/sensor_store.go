@@ -0,0 +1,64 @@
+package olympus
+
+import (
+	"sync"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"google.golang.org/protobuf/proto"
+)
+
+// A SensorStore persists the temperature sensors declared by each
+// zone, so they are known while the zone is offline or registered by
+// a client not declaring them.
+type SensorStore interface {
+	// Set records the temperature sensors declared by a zone.
+	Set(zone string, sensors []*api.TemperatureSensor) error
+	// Get returns the latest temperature sensors declared by a zone.
+	Get(zone string) []*api.TemperatureSensor
+}
+
+type sensorStore struct {
+	mx      sync.Mutex
+	sensors *PersistentMap[[]*api.TemperatureSensor]
+}
+
+// NewSensorStore creates a SensorStore in the data directory.
+func NewSensorStore(name string) SensorStore {
+	return &sensorStore{
+		sensors: NewPersistentMap[[]*api.TemperatureSensor](name),
+	}
+}
+
+func sameSensors(a, b []*api.TemperatureSensor) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if proto.Equal(a[i], b[i]) == false {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *sensorStore) Set(zone string, sensors []*api.TemperatureSensor) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if sameSensors(s.sensors.Map[zone], sensors) == true {
+		return nil
+	}
+	res := make([]*api.TemperatureSensor, len(sensors))
+	for i, sensor := range sensors {
+		res[i] = proto.Clone(sensor).(*api.TemperatureSensor)
+	}
+	s.sensors.Map[zone] = res
+	return s.sensors.SaveKey(zone)
+}
+
+func (s *sensorStore) Get(zone string) []*api.TemperatureSensor {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return s.sensors.Map[zone]
+}
@@ -0,0 +1,43 @@
+package olympus
+
+import (
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	. "gopkg.in/check.v1"
+)
+
+type SensorStoreSuite struct {
+	datapath string
+}
+
+var _ = Suite(&SensorStoreSuite{})
+
+func (s *SensorStoreSuite) SetUpSuite(c *C) {
+	s.datapath = _datapath
+}
+
+func (s *SensorStoreSuite) TearDownSuite(c *C) {
+	_datapath = s.datapath
+}
+
+func (s *SensorStoreSuite) SetUpTest(c *C) {
+	_datapath = c.MkDir()
+}
+
+func (s *SensorStoreSuite) TestPersistsSensors(c *C) {
+	store := NewSensorStore("climate-sensors")
+	c.Check(store.Get("foo.box"), HasLen, 0)
+	sensors := []*api.TemperatureSensor{
+		{Name: "air"},
+		{Name: "nest", Maximum: newInitialized[float32](28.0)},
+	}
+	c.Assert(store.Set("foo.box", sensors), IsNil)
+	// the store keeps its own copy
+	sensors[0].Name = "modified"
+
+	restored := NewSensorStore("climate-sensors").Get("foo.box")
+	c.Assert(restored, HasLen, 2)
+	c.Check(restored[0].Name, Equals, "air")
+	c.Check(restored[1].Name, Equals, "nest")
+	c.Check(*restored[1].Maximum, Equals, float32(28.0))
+	c.Check(store.Get("bar.box"), HasLen, 0)
+}
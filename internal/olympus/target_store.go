@@ -0,0 +1,139 @@
+package olympus
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/atuleu/go-lttb"
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"google.golang.org/protobuf/proto"
+)
+
+// A TargetStore persists the history of the climate targets of each
+// zone.
+type TargetStore interface {
+	// Append records a target change of a zone, unless it is
+	// identical to the latest one.
+	Append(zone string, change api.TargetChange) error
+	// Query returns the target changes of a zone in effect within
+	// [from,to], sorted by time. A zero from or to means no lower or
+	// upper bound.
+	Query(zone string, from, to time.Time) []api.TargetChange
+}
+
+type targetStore struct {
+	mx        sync.Mutex
+	changes   *PersistentMap[[]api.TargetChange]
+	retention time.Duration
+}
+
+// NewTargetStore creates a TargetStore in the data directory. Target
+// changes no more in effect for retention are discarded, unless
+// retention is non-positive.
+func NewTargetStore(name string, retention time.Duration) TargetStore {
+	return &targetStore{
+		changes:   NewPersistentMap[[]api.TargetChange](name),
+		retention: retention,
+	}
+}
+
+func sameTarget(a, b api.TargetChange) bool {
+	sameEnd := (a.End == nil && b.End == nil) ||
+		(a.End != nil && b.End != nil && a.End.Equal(*b.End))
+	return sameEnd &&
+		proto.Equal(a.Current, b.Current) &&
+		proto.Equal(a.CurrentEnd, b.CurrentEnd)
+}
+
+func (s *targetStore) prune(changes []api.TargetChange, now time.Time) []api.TargetChange {
+	if s.retention <= 0 {
+		return changes
+	}
+	limit := now.Add(-s.retention)
+	// keeps the change in effect at limit.
+	idx := sort.Search(len(changes), func(i int) bool {
+		return changes[i].Time.After(limit)
+	})
+	if idx > 0 {
+		idx -= 1
+	}
+	return changes[idx:]
+}
+
+func (s *targetStore) Append(zone string, change api.TargetChange) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	changes := s.changes.Map[zone]
+	if len(changes) > 0 && sameTarget(changes[len(changes)-1], change) {
+		return nil
+	}
+	changes = BackInsertionSort(changes, change, func(a, b api.TargetChange) bool {
+		return a.Time.Before(b.Time)
+	})
+	s.changes.Map[zone] = s.prune(changes, time.Now())
+	return s.changes.SaveKey(zone)
+}
+
+func (s *targetStore) Query(zone string, from, to time.Time) []api.TargetChange {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	changes := s.changes.Map[zone]
+	start := 0
+	if from.IsZero() == false {
+		start = sort.Search(len(changes), func(i int) bool {
+			return changes[i].Time.After(from)
+		})
+		if start > 0 {
+			start -= 1
+		}
+	}
+	end := len(changes)
+	if to.IsZero() == false {
+		end = sort.Search(len(changes), func(i int) bool {
+			return changes[i].Time.After(to)
+		})
+	}
+	if start >= end {
+		return nil
+	}
+	return append([]api.TargetChange(nil), changes[start:end]...)
+}
+
+// buildTargetSeries returns the temperature and humidity setpoints
+// of changes within [from,to], with the time axis of a climate time
+// series.
+func buildTargetSeries(changes []api.TargetChange, from, to, reference time.Time, unit time.Duration) (temperature, humidity api.PointSeries) {
+	factor := 1.0 / unit.Seconds()
+	addPoint := func(change api.TargetChange, t time.Time) {
+		x := float32(t.Sub(reference).Seconds() * factor)
+		tValue, hValue := interpolateTarget(t, change.Time, change.Current, change.CurrentEnd, change.End)
+		if tValue != nil {
+			temperature = append(temperature, lttb.Point[float32]{X: x, Y: float32(*tValue)})
+		}
+		if hValue != nil {
+			humidity = append(humidity, lttb.Point[float32]{X: x, Y: float32(*hValue)})
+		}
+	}
+
+	for i, c := range changes {
+		start, stop := c.Time, to
+		if start.Before(from) {
+			start = from
+		}
+		if i+1 < len(changes) && changes[i+1].Time.Before(stop) {
+			stop = changes[i+1].Time
+		}
+		if stop.After(start) == false {
+			continue
+		}
+		addPoint(c, start)
+		if c.End != nil && c.End.After(start) && c.End.Before(stop) {
+			addPoint(c, *c.End)
+		}
+		addPoint(c, stop)
+	}
+	return temperature, humidity
+}
@@ -0,0 +1,92 @@
+package olympus
+
+import (
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	. "gopkg.in/check.v1"
+)
+
+type TargetStoreSuite struct {
+	datapath string
+	start    time.Time
+	s        TargetStore
+}
+
+var _ = Suite(&TargetStoreSuite{})
+
+func (s *TargetStoreSuite) SetUpSuite(c *C) {
+	s.datapath = _datapath
+}
+
+func (s *TargetStoreSuite) TearDownSuite(c *C) {
+	_datapath = s.datapath
+}
+
+func (s *TargetStoreSuite) SetUpTest(c *C) {
+	_datapath = c.MkDir()
+	s.start = time.Now().Round(0).Add(-time.Hour).UTC()
+	s.s = NewTargetStore("climate-targets", 0)
+}
+
+func targetChange(t time.Time, temperature float32) api.TargetChange {
+	return api.TargetChange{
+		Time: t,
+		Current: &api.ClimateState{
+			Name:        "day",
+			Temperature: newInitialized(temperature),
+		},
+	}
+}
+
+func (s *TargetStoreSuite) TestRecordsChanges(c *C) {
+	c.Check(s.s.Append("foo.box", targetChange(s.start, 20.0)), IsNil)
+	// identical targets are not recorded
+	c.Check(s.s.Append("foo.box", targetChange(s.start.Add(time.Minute), 20.0)), IsNil)
+	c.Check(s.s.Append("foo.box", targetChange(s.start.Add(20*time.Minute), 25.0)), IsNil)
+	c.Check(s.s.Append("foo.box", targetChange(s.start.Add(40*time.Minute), 22.0)), IsNil)
+
+	changes := s.s.Query("foo.box", time.Time{}, time.Time{})
+	c.Assert(changes, HasLen, 3)
+	c.Check(*changes[1].Current.Temperature, Equals, float32(25.0))
+
+	// the change in effect at from is returned
+	changes = s.s.Query("foo.box", s.start.Add(30*time.Minute), s.start.Add(35*time.Minute))
+	c.Assert(changes, HasLen, 1)
+	c.Check(changes[0].Time.Equal(s.start.Add(20*time.Minute)), Equals, true)
+
+	c.Check(s.s.Query("foo.box", time.Time{}, s.start.Add(-time.Minute)), HasLen, 0)
+	c.Check(s.s.Query("bar.box", time.Time{}, time.Time{}), HasLen, 0)
+
+	restored := NewTargetStore("climate-targets", 0).Query("foo.box", time.Time{}, time.Time{})
+	c.Assert(restored, HasLen, 3)
+	for i, expected := range []float32{20.0, 25.0, 22.0} {
+		c.Check(*restored[i].Current.Temperature, Equals, expected)
+	}
+}
+
+func (s *TargetStoreSuite) TestBuildsTargetSeries(c *C) {
+	end := s.start.Add(30 * time.Minute)
+	ramp := targetChange(s.start.Add(10*time.Minute), 20.0)
+	ramp.CurrentEnd = &api.ClimateState{Temperature: newInitialized[float32](26.0)}
+	ramp.End = &end
+	changes := []api.TargetChange{
+		targetChange(s.start, 18.0),
+		ramp,
+		targetChange(s.start.Add(40*time.Minute), 22.0),
+	}
+
+	temperature, humidity := buildTargetSeries(changes,
+		s.start.Add(5*time.Minute), s.start.Add(50*time.Minute),
+		s.start.Add(50*time.Minute), time.Minute)
+	c.Check(humidity, HasLen, 0)
+	c.Check(temperature, DeepEquals, api.PointSeries{
+		{X: -45, Y: 18},
+		{X: -40, Y: 18},
+		{X: -40, Y: 20},
+		{X: -20, Y: 26},
+		{X: -10, Y: 26},
+		{X: -10, Y: 22},
+		{X: 0, Y: 22},
+	})
+}
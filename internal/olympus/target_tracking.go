@@ -0,0 +1,159 @@
+package olympus
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+)
+
+// A TargetTrackingPolicy configures the alarm raised when a zone
+// deviates from its climate target.
+type TargetTrackingPolicy struct {
+	// TemperatureThreshold is the maximal absolute deviation from the
+	// target temperature in °C. A non-positive value disables the
+	// temperature alarm.
+	TemperatureThreshold float32
+	// HumidityThreshold is the maximal absolute deviation from the
+	// target humidity in %. A non-positive value disables the
+	// humidity alarm.
+	HumidityThreshold float32
+	// MinimumDuration is how long the deviation must stay over its
+	// threshold before raising an alarm.
+	MinimumDuration time.Duration
+}
+
+type trackingErrorWindow struct {
+	name     string
+	duration time.Duration
+}
+
+// trackingErrorWindows returns the windows of the tracking error
+// statistics, matching the ClimateWindows, sorted by duration.
+func trackingErrorWindows() []trackingErrorWindow {
+	res := make([]trackingErrorWindow, 0, len(ClimateWindows))
+	for _, w := range ClimateWindows {
+		res = append(res, trackingErrorWindow{name: w.Names[0], duration: w.Duration})
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].duration < res[j].duration
+	})
+	return res
+}
+
+type deviationAccumulator struct {
+	sum, max float64
+	count    int
+}
+
+func (a *deviationAccumulator) add(deviation float64) {
+	deviation = math.Abs(deviation)
+	a.sum += deviation
+	a.max = math.Max(a.max, deviation)
+	a.count += 1
+}
+
+func (a *deviationAccumulator) merge(o deviationAccumulator) {
+	a.sum += o.sum
+	a.max = math.Max(a.max, o.max)
+	a.count += o.count
+}
+
+func (a deviationAccumulator) stats() *api.DeviationStats {
+	if a.count == 0 {
+		return nil
+	}
+	return &api.DeviationStats{
+		Mean: float32(a.sum / float64(a.count)),
+		Max:  float32(a.max),
+	}
+}
+
+type trackingErrorBucket struct {
+	start                 time.Time
+	temperature, humidity deviationAccumulator
+}
+
+// trackingErrorStatistics accumulates the deviations from the target
+// in buckets of TrackingErrorResolution, over the longest window.
+type trackingErrorStatistics struct {
+	buckets []trackingErrorBucket
+}
+
+func (s *trackingErrorStatistics) add(t time.Time, temperature, humidity *float64) {
+	start := t.Truncate(TrackingErrorResolution)
+	if len(s.buckets) == 0 || start.After(s.buckets[len(s.buckets)-1].start) {
+		s.buckets = append(s.buckets, trackingErrorBucket{start: start})
+	}
+	// out of order reports are accounted in the latest bucket.
+	last := &s.buckets[len(s.buckets)-1]
+	if temperature != nil {
+		last.temperature.add(*temperature)
+	}
+	if humidity != nil {
+		last.humidity.add(*humidity)
+	}
+
+	windows := trackingErrorWindows()
+	oldest := last.start.Add(-windows[len(windows)-1].duration)
+	i := 0
+	for ; i < len(s.buckets) && s.buckets[i].start.After(oldest) == false; i++ {
+	}
+	s.buckets = s.buckets[i:]
+}
+
+// compute returns the statistics of all windows ending with the
+// latest bucket.
+func (s *trackingErrorStatistics) compute() []api.TrackingErrorStats {
+	if len(s.buckets) == 0 {
+		return nil
+	}
+	end := s.buckets[len(s.buckets)-1].start.Add(TrackingErrorResolution)
+
+	windows := trackingErrorWindows()
+	res := make([]api.TrackingErrorStats, 0, len(windows))
+	var temperature, humidity deviationAccumulator
+	i := len(s.buckets) - 1
+	for _, w := range windows {
+		start := end.Add(-w.duration)
+		for ; i >= 0 && s.buckets[i].start.Before(start) == false; i-- {
+			temperature.merge(s.buckets[i].temperature)
+			humidity.merge(s.buckets[i].humidity)
+		}
+		res = append(res, api.TrackingErrorStats{
+			Window:      w.name,
+			Temperature: temperature.stats(),
+			Humidity:    humidity.stats(),
+		})
+	}
+	return res
+}
+
+func interpolate(start, end *float32, ratio float64) *float64 {
+	if start == nil {
+		return nil
+	}
+	res := float64(*start)
+	if end != nil {
+		res += (float64(*end) - res) * ratio
+	}
+	return &res
+}
+
+// interpolateTarget returns the target temperature and humidity at t,
+// when the current state started at start. The target is linearly
+// interpolated from current to currentEnd, reached at end.
+func interpolateTarget(t, start time.Time, current, currentEnd *api.ClimateState, end *time.Time) (temperature, humidity *float64) {
+	if current == nil {
+		return nil, nil
+	}
+	if currentEnd == nil || end == nil || end.After(start) == false {
+		return interpolate(current.Temperature, nil, 0),
+			interpolate(current.Humidity, nil, 0)
+	}
+	ratio := float64(t.Sub(start)) / float64(end.Sub(start))
+	ratio = math.Max(0, math.Min(1, ratio))
+	return interpolate(current.Temperature, currentEnd.Temperature, ratio),
+		interpolate(current.Humidity, currentEnd.Humidity, ratio)
+}
@@ -0,0 +1,142 @@
+package olympus
+
+import (
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	. "gopkg.in/check.v1"
+)
+
+type TargetTrackingSuite struct{}
+
+var _ = Suite(&TargetTrackingSuite{})
+
+func (s *TargetTrackingSuite) TestInterpolatesTarget(c *C) {
+	start := time.Now().Round(0)
+	end := start.Add(time.Hour)
+	current := &api.ClimateState{
+		Temperature: newInitialized[float32](20.0),
+		Humidity:    newInitialized[float32](60.0),
+	}
+	currentEnd := &api.ClimateState{
+		Temperature: newInitialized[float32](26.0),
+	}
+
+	testdata := []struct {
+		At          time.Duration
+		Temperature float64
+	}{
+		{-time.Minute, 20.0},
+		{0, 20.0},
+		{30 * time.Minute, 23.0},
+		{45 * time.Minute, 24.5},
+		{2 * time.Hour, 26.0},
+	}
+
+	for _, d := range testdata {
+		temperature, humidity := interpolateTarget(start.Add(d.At), start, current, currentEnd, &end)
+		c.Assert(temperature, NotNil)
+		c.Check(*temperature, Equals, d.Temperature, Commentf("at %s", d.At))
+		// no humidity end target: it stays constant
+		c.Assert(humidity, NotNil)
+		c.Check(*humidity, Equals, 60.0)
+	}
+
+	temperature, _ := interpolateTarget(end, start, current, nil, nil)
+	c.Assert(temperature, NotNil)
+	c.Check(*temperature, Equals, 20.0)
+}
+
+func (s *TargetTrackingSuite) TestComputesStatisticsPerWindow(c *C) {
+	stats := trackingErrorStatistics{}
+	start := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	deviation := func(v float64) *float64 { return &v }
+
+	stats.add(start, deviation(-2.0), nil)
+	stats.add(start.Add(30*time.Minute), deviation(1.0), deviation(4.0))
+	stats.add(start.Add(55*time.Minute), deviation(0.5), nil)
+	stats.add(start.Add(55*time.Minute+30*time.Second), deviation(-0.5), nil)
+
+	res := stats.compute()
+	c.Assert(res, HasLen, 4)
+	c.Check(res[0], DeepEquals, api.TrackingErrorStats{
+		Window:      "10m",
+		Temperature: &api.DeviationStats{Mean: 0.5, Max: 0.5},
+	})
+	c.Check(res[1], DeepEquals, api.TrackingErrorStats{
+		Window:      "1h",
+		Temperature: &api.DeviationStats{Mean: 1.0, Max: 2.0},
+		Humidity:    &api.DeviationStats{Mean: 4.0, Max: 4.0},
+	})
+	c.Check(res[3].Window, Equals, "1w")
+	c.Check(res[3].Temperature, DeepEquals, res[1].Temperature)
+
+	// old data is rolled out of the longest window
+	stats.add(start.Add(8*24*time.Hour), deviation(3.0), nil)
+	res = stats.compute()
+	c.Check(res[3].Temperature, DeepEquals, &api.DeviationStats{Mean: 3.0, Max: 3.0})
+	c.Check(res[3].Humidity, IsNil)
+}
+
+func (s *TargetTrackingSuite) TestFollowsClimateWindows(c *C) {
+	defer func(windows []ClimateWindow) { ClimateWindows = windows }(ClimateWindows)
+	ClimateWindows = []ClimateWindow{
+		{Names: []string{"day"}, Duration: 24 * time.Hour, Unit: time.Hour, Samples: 100},
+		{Names: []string{"30m", "half-hour"}, Duration: 30 * time.Minute, Unit: time.Minute, Samples: 100},
+	}
+	stats := trackingErrorStatistics{}
+	start := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	deviation := func(v float64) *float64 { return &v }
+	stats.add(start, deviation(2.0), nil)
+	stats.add(start.Add(2*time.Hour), deviation(1.0), nil)
+
+	res := stats.compute()
+	c.Assert(res, HasLen, 2)
+	c.Check(res[0].Window, Equals, "30m")
+	c.Check(res[0].Temperature, DeepEquals, &api.DeviationStats{Mean: 1.0, Max: 1.0})
+	c.Check(res[1].Window, Equals, "day")
+	c.Check(res[1].Temperature, DeepEquals, &api.DeviationStats{Mean: 1.5, Max: 2.0})
+}
+
+func (s *TargetTrackingSuite) TestRaisesTrackingErrorAlarms(c *C) {
+	l := NewClimateLogger(&api.ClimateDeclaration{Host: "foo", Name: "bar"})
+	policy := &TargetTrackingPolicy{
+		TemperatureThreshold: 1.0,
+		MinimumDuration:      10 * time.Minute,
+	}
+	start := time.Now().Round(0)
+	report := func(minutes int, temperature float32) []*api.ClimateReport {
+		return []*api.ClimateReport{{
+			Time:         timestamppb.New(start.Add(time.Duration(minutes) * time.Minute)),
+			Temperatures: []float32{temperature},
+			Humidity:     newInitialized[float32](40.0),
+		}}
+	}
+
+	// without target, nothing is tracked
+	c.Check(l.TrackTarget(report(0, 30.0), policy), HasLen, 0)
+	c.Check(l.GetClimateReport().TrackingErrors, HasLen, 0)
+
+	l.PushTarget(&api.ClimateTarget{
+		Current: &api.ClimateState{
+			Temperature: newInitialized[float32](25.0),
+			Humidity:    newInitialized[float32](60.0),
+		},
+	})
+	c.Check(l.TrackTarget(report(1, 27.0), policy), HasLen, 0)
+	alarms := l.TrackTarget(report(11, 27.0), policy)
+	c.Assert(alarms, HasLen, 1)
+	c.Check(alarms[0].Identification, Equals, "temperature.tracking-error")
+	c.Check(alarms[0].Status, Equals, api.AlarmStatus_ON)
+	c.Check(alarms[0].Description, Equals, "temperature deviation 2.0°C is above its maximum of 1.0°C")
+
+	alarms = l.TrackTarget(report(12, 25.5), policy)
+	c.Assert(alarms, HasLen, 1)
+	c.Check(alarms[0].Status, Equals, api.AlarmStatus_OFF)
+
+	errors := l.GetClimateReport().TrackingErrors
+	c.Assert(errors, HasLen, 4)
+	c.Check(errors[1].Temperature, DeepEquals, &api.DeviationStats{Mean: 1.5, Max: 2.0})
+	c.Check(errors[1].Humidity, DeepEquals, &api.DeviationStats{Mean: 20.0, Max: 20.0})
+}
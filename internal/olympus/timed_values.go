@@ -39,14 +39,13 @@ func (d *TimedValues) pushOne(t time.Time, values [][]float32, minimumPeriod tim
 	}
 
 	d.times = Insert(d.times, t, index)
-	if d.values == nil {
-		d.values = make([][]float32, len(values))
-	}
+	d.growValues(len(values))
 
 	for i, v := range values {
 		if len(v) == 0 {
 			continue
 		}
+		d.values[i] = backfill(d.values[i], v[0], len(d.times)-1)
 		d.values[i] = Insert(d.values[i], v[0], index)
 	}
 	return true
@@ -92,11 +91,13 @@ func (d *TimedValues) pushBatch(times []time.Time, values [][]float32, minimumPe
 	}
 
 	d.times = InsertSlice(d.times, times, insertionStart, insertionEnd)
-	if len(d.values) == 0 {
-		d.values = make([][]float32, len(values))
-	}
+	d.growValues(len(values))
 	for i, v := range values {
-		if len(v) == 0 || len(d.values[i]) < (len(d.times)-len(times)) {
+		if len(v) == 0 {
+			continue
+		}
+		d.values[i] = backfill(d.values[i], v[0], len(d.times)-len(times))
+		if len(d.values[i]) < (len(d.times) - len(times)) {
 			continue
 		}
 		d.values[i] = InsertSlice(d.values[i], v, insertionStart, insertionEnd)
@@ -105,6 +106,28 @@ func (d *TimedValues) pushBatch(times []time.Time, values [][]float32, minimumPe
 	return true
 }
 
+// growValues ensures there is at least size series, as new kinds of
+// values may appear in later reports.
+func (d *TimedValues) growValues(size int) {
+	for len(d.values) < size {
+		d.values = append(d.values, nil)
+	}
+}
+
+// backfill pads values up to size, for series starting after the
+// other ones or missing some values. Missing values are the last
+// known one, or v for an empty series.
+func backfill(values []float32, v float32, size int) []float32 {
+	missing := v
+	if len(values) > 0 {
+		missing = values[len(values)-1]
+	}
+	for len(values) < size {
+		values = append(values, missing)
+	}
+	return values
+}
+
 // Push adds another TimedValues to the TimedValues. values may be
 // dropped if it would make the series instantaneous frequency exceed
 // the frequency 1/minimumPeriod. This method is optimized for two
@@ -15,12 +15,17 @@ import (
 
 type TrackingLogger interface {
 	TrackingInfo() *api.TrackingInfo
-	PushDiskStatus(*api.DiskStatus)
+	// PushDiskStatus updates the disk status and its forecast. It
+	// returns the server-generated alarm updates, in the tracking
+	// domain, caused by the new forecast.
+	PushDiskStatus(*api.DiskStatus) []*api.AlarmUpdate
 }
 
 type trackingLogger struct {
-	mx    sync.RWMutex
-	infos *api.TrackingInfo
+	mx       sync.RWMutex
+	infos    *api.TrackingInfo
+	forecast diskForecaster
+	clock    Clock
 
 	logger *logrus.Entry
 }
@@ -45,6 +50,7 @@ func NewTrackingLogger(ctx context.Context, declaration *api.TrackingDeclaration
 				ThumbnailURL:   path.Join("/thumbnails/olympus/", declaration.Hostname+".jpg"),
 			},
 		},
+		clock:  SystemClock,
 		logger: logger,
 	}
 }
@@ -63,12 +69,20 @@ func Max[T constraints.Ordered](a, b T) T {
 	return b
 }
 
-func (l *trackingLogger) PushDiskStatus(s *api.DiskStatus) {
+func (l *trackingLogger) PushDiskStatus(s *api.DiskStatus) []*api.AlarmUpdate {
 	l.mx.Lock()
 	defer l.mx.Unlock()
 
+	now := l.clock.Now()
+
 	l.logger.WithField("diskStatus", proto.MarshalTextString(s)).Trace("new disk status")
 	l.infos.TotalBytes = Max(s.FreeBytes, s.TotalBytes)
 	l.infos.FreeBytes = Max(0, s.FreeBytes)
 	l.infos.BytesPerSecond = Max(0, s.BytesPerSecond)
+
+	l.forecast.push(now, l.infos.FreeBytes)
+	l.infos.ObservedBytesPerSecond, _ = l.forecast.observedBytesPerSecond()
+	l.infos.FullAt = l.forecast.forecast(now, l.infos.FreeBytes, l.infos.BytesPerSecond)
+
+	return l.forecast.alarms(now, l.infos.FullAt)
 }
@@ -0,0 +1,98 @@
+package olympus
+
+import (
+	"context"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	. "gopkg.in/check.v1"
+)
+
+type TrackingLoggerSuite struct {
+	clock *fakeClock
+	l     *trackingLogger
+}
+
+var _ = Suite(&TrackingLoggerSuite{})
+
+const GB int64 = 1000 * 1000 * 1000
+
+func (s *TrackingLoggerSuite) SetUpTest(c *C) {
+	s.clock = newFakeClock(time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC))
+	s.l = NewTrackingLogger(context.Background(), &api.TrackingDeclaration{
+		Hostname: "somehost",
+	}).(*trackingLogger)
+	s.l.clock = s.clock
+}
+
+func (s *TrackingLoggerSuite) TestForecastsFromReportedRate(c *C) {
+	alarms := s.l.PushDiskStatus(&api.DiskStatus{
+		TotalBytes:     1000 * GB,
+		FreeBytes:      360 * GB,
+		BytesPerSecond: GB / 1000,
+	})
+	c.Check(alarms, HasLen, 0)
+
+	infos := s.l.TrackingInfo()
+	c.Assert(infos.FullAt, NotNil)
+	c.Check(infos.FullAt.Sub(s.clock.Now()), Equals, 100*time.Hour)
+}
+
+func (s *TrackingLoggerSuite) TestForecastsFromObservedTrend(c *C) {
+	// the host reports no writes, but the disk fills at 1 GB/min
+	for i := int64(0); i <= 30; i++ {
+		s.l.PushDiskStatus(&api.DiskStatus{
+			TotalBytes: 1000 * GB,
+			FreeBytes:  600*GB - i*GB,
+		})
+		s.clock.Advance(time.Minute)
+	}
+
+	infos := s.l.TrackingInfo()
+	c.Check(infos.BytesPerSecond, Equals, int64(0))
+	c.Check(infos.ObservedBytesPerSecond, Equals, GB/60)
+	c.Assert(infos.FullAt, NotNil)
+	c.Check(infos.FullAt.Sub(s.clock.Now()) < 10*time.Hour, Equals, true)
+}
+
+func (s *TrackingLoggerSuite) TestRaisesAndClearsAlarm(c *C) {
+	testdata := []struct {
+		BytesPerSecond int64
+		Expected       []*api.AlarmUpdate
+	}{
+		{GB / 1000000, nil},
+		{GB / 200000, []*api.AlarmUpdate{{
+			Identification: DiskFullAlarmIdentification,
+			Level:          api.AlarmLevel_WARNING,
+			Status:         api.AlarmStatus_ON,
+			Description:    "tracking disk will be full in 55 h 33 min",
+		}}},
+		{GB / 200000, nil},
+		{GB / 10000, []*api.AlarmUpdate{{
+			Identification: DiskFullAlarmIdentification,
+			Level:          api.AlarmLevel_EMERGENCY,
+			Status:         api.AlarmStatus_ON,
+			Description:    "tracking disk will be full in 2 h 47 min",
+		}}},
+		{0, []*api.AlarmUpdate{{
+			Identification: DiskFullAlarmIdentification,
+			Level:          api.AlarmLevel_EMERGENCY,
+			Status:         api.AlarmStatus_OFF,
+		}}},
+	}
+
+	for i, d := range testdata {
+		alarms := s.l.PushDiskStatus(&api.DiskStatus{
+			TotalBytes:     1000 * GB,
+			FreeBytes:      GB,
+			BytesPerSecond: d.BytesPerSecond,
+		})
+		comment := Commentf("step %d", i)
+		c.Assert(alarms, HasLen, len(d.Expected), comment)
+		for j, a := range alarms {
+			c.Check(a.Time.AsTime(), Equals, s.clock.Now(), comment)
+			a.Time = nil
+			c.Check(a, DeepEquals, d.Expected[j], comment)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package olympus
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+type UserCommand struct{}
+
+type AddUserCommand struct {
+	ConfigOptions
+
+	Role     string `short:"r" long:"role" description:"role of the user" choice:"viewer" choice:"operator" choice:"admin" default:"viewer"`
+	Password string `short:"p" long:"password" description:"password of the user, read from stdin if not set" env:"OLYMPUS_PASSWORD"`
+
+	Args struct {
+		Name string `positional-arg-name:"name" description:"user name"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+type RemoveUserCommand struct {
+	ConfigOptions
+
+	Args struct {
+		Name string `positional-arg-name:"name" description:"user name"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+type ListUserCommand struct {
+	ConfigOptions
+}
+
+func readPassword() (string, error) {
+	fmt.Fprint(os.Stderr, "password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && len(line) == 0 {
+		return "", errors.New("could not read password from stdin")
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (c *AddUserCommand) Execute([]string) error {
+	if err := c.apply(); err != nil {
+		return err
+	}
+	role, err := ParseRole(c.Role)
+	if err != nil {
+		return err
+	}
+	password := c.Password
+	if len(password) == 0 {
+		if password, err = readPassword(); err != nil {
+			return err
+		}
+	}
+	return NewUserStore("users").Add(c.Args.Name, password, role)
+}
+
+func (c *RemoveUserCommand) Execute([]string) error {
+	if err := c.apply(); err != nil {
+		return err
+	}
+	return NewUserStore("users").Remove(c.Args.Name)
+}
+
+func (c *ListUserCommand) Execute([]string) error {
+	if err := c.apply(); err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tROLE")
+	for _, a := range NewUserStore("users").List() {
+		fmt.Fprintf(tw, "%s\t%s\n", a.Name, a.Role)
+	}
+	return tw.Flush()
+}
+
+func init() {
+	cmd, err := parser.AddCommand("user",
+		"manages user accounts.",
+		"manages the user accounts of the web API stored in the data_home of the configuration. The web API is only authenticated once an account exists. Changes apply to a running service within a few seconds.",
+		&UserCommand{})
+	if err != nil {
+		panic(err.Error())
+	}
+	cmd.AddCommand("add", "adds or updates a user", "adds a user account, or updates its password and role", &AddUserCommand{})
+	cmd.AddCommand("remove", "removes a user", "removes a user account", &RemoveUserCommand{})
+	cmd.AddCommand("list", "lists users", "lists all user accounts and their role", &ListUserCommand{})
+}
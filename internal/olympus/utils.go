@@ -1,5 +1,11 @@
 package olympus
 
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+)
+
 // searches from index n-1 to 0 the first index which is true or -1 if none are true
 
 func Insert[T any](slice []T, value T, index int) []T {
@@ -47,3 +53,24 @@ func LinearSearch[T any](slice []T, value T, less func(a, b T) bool) int {
 func ZoneIdentifier(hostname, zoneName string) string {
 	return hostname + "." + zoneName
 }
+
+// splitServiceIdentifier splits a 'host.zone.service' identifier, as
+// used by the ServiceLogger.
+func splitServiceIdentifier(identifier string) (host, zone, service string) {
+	idx := strings.LastIndex(identifier, ".")
+	if idx < 0 {
+		return "", "", identifier
+	}
+	service = identifier[idx+1:]
+	host, zone, _ = strings.Cut(identifier[:idx], ".")
+	return host, zone, service
+}
+
+// newRandomID returns a hex encoded random ID of size bytes.
+func newRandomID(size int) (string, error) {
+	id := make([]byte, size)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
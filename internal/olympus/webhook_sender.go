@@ -0,0 +1,239 @@
+package olympus
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"github.com/formicidae-tracker/olympus/pkg/tm"
+	"github.com/sirupsen/logrus"
+)
+
+// A WebhookDeliveryLog keeps the latest deliveries of each webhook.
+type WebhookDeliveryLog interface {
+	Log(webhookID string, delivery api.WebhookDelivery)
+	Deliveries(webhookID string) []api.WebhookDelivery
+}
+
+type webhookDeliveryLog struct {
+	mx         sync.RWMutex
+	size       int
+	deliveries map[string][]api.WebhookDelivery
+}
+
+// NewWebhookDeliveryLog creates an in-memory WebhookDeliveryLog
+// keeping the last size deliveries of each webhook.
+func NewWebhookDeliveryLog(size int) WebhookDeliveryLog {
+	return &webhookDeliveryLog{
+		size:       size,
+		deliveries: make(map[string][]api.WebhookDelivery),
+	}
+}
+
+func (l *webhookDeliveryLog) Log(webhookID string, delivery api.WebhookDelivery) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	deliveries := append(l.deliveries[webhookID], delivery)
+	if len(deliveries) > l.size {
+		deliveries = deliveries[len(deliveries)-l.size:]
+	}
+	l.deliveries[webhookID] = deliveries
+}
+
+func (l *webhookDeliveryLog) Deliveries(webhookID string) []api.WebhookDelivery {
+	l.mx.RLock()
+	defer l.mx.RUnlock()
+
+	// most recent first
+	deliveries := l.deliveries[webhookID]
+	res := make([]api.WebhookDelivery, len(deliveries))
+	for i, d := range deliveries {
+		res[len(res)-1-i] = d
+	}
+	return res
+}
+
+type webhookSender struct {
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	deliveries  WebhookDeliveryLog
+	log         *logrus.Entry
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWebhookNotificationSender creates a NotificationSender that POSTs
+// an api.WebhookPayload to webhooks. Failed deliveries are retried
+// with an exponential backoff. Deliveries are asynchronous, so a slow
+// webhook does not delay other notifications, and are recorded in
+// deliveries. Closing the sender aborts the pending deliveries.
+func NewWebhookNotificationSender(deliveries WebhookDeliveryLog) NotificationSender {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &webhookSender{
+		client:      &http.Client{Timeout: WebhookTimeout},
+		maxAttempts: WebhookMaxAttempts,
+		backoff:     WebhookBackoff,
+		deliveries:  deliveries,
+		log:         tm.NewLogger("webhook"),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+func (s *webhookSender) Send(n NotificationFor) error {
+	if n.Webhook == nil {
+		return fmt.Errorf("webhook: no webhook to send to")
+	}
+	if len(n.Updates) == 0 {
+		return nil
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.deliver(n, time.Now())
+	}()
+	return nil
+}
+
+// Close aborts the pending deliveries and waits for them to finish.
+func (s *webhookSender) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+// NewWebhookPayload converts a batch of updates to its webhook
+// representation.
+func NewWebhookPayload(delivery string, updates []ZonedAlarmUpdate, now time.Time) api.WebhookPayload {
+	res := api.WebhookPayload{
+		Delivery: delivery,
+		SentAt:   now,
+		Alarms:   make([]api.WebhookAlarm, 0, len(updates)),
+	}
+	for _, u := range updates {
+		host, zone, found := strings.Cut(u.Zone, ".")
+		if found == false {
+			// i.e. "services"
+			host, zone = "", u.Zone
+		}
+		alarm := api.WebhookAlarm{
+			Host:           host,
+			Zone:           zone,
+			Identification: u.Update.Identification,
+			Level:          u.Update.Level.String(),
+			Status:         u.Update.Status.String(),
+			Description:    u.Update.Description,
+		}
+		if u.Update.Time != nil {
+			alarm.Time = u.Update.Time.AsTime()
+		}
+		if u.Resolution != nil {
+			firedAt := u.Resolution.FiredAt
+			alarm.FiredAt = &firedAt
+		}
+		res.Alarms = append(res.Alarms, alarm)
+	}
+	return res
+}
+
+// SignWebhookPayload returns the value of the X-Olympus-Signature
+// header for body.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *webhookSender) deliver(n NotificationFor, now time.Time) api.WebhookDelivery {
+	delivery := api.WebhookDelivery{
+		Time:   now,
+		Alarms: len(n.Updates),
+	}
+	var err error
+	delivery.ID, err = newRandomID(8)
+	if err == nil {
+		err = s.post(n, &delivery)
+	}
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+
+	entry := s.log.WithFields(logrus.Fields{
+		"id":       n.Webhook.ID,
+		"delivery": delivery.ID,
+		"attempts": delivery.Attempts,
+	})
+	if err != nil {
+		entry.WithField("error", err).Errorf("could not deliver webhook")
+	} else {
+		entry.Debugf("delivered")
+	}
+
+	s.deliveries.Log(n.Webhook.ID, delivery)
+	return delivery
+}
+
+func (s *webhookSender) post(n NotificationFor, delivery *api.WebhookDelivery) error {
+	body, err := json.Marshal(NewWebhookPayload(delivery.ID, n.Updates, delivery.Time))
+	if err != nil {
+		return err
+	}
+	signature := SignWebhookPayload(n.Webhook.Secret, body)
+
+	backoff := s.backoff
+	for {
+		delivery.Attempts += 1
+		var retry bool
+		retry, err = s.attempt(n.Webhook.URL, body, signature, delivery)
+		if err == nil || retry == false || delivery.Attempts >= s.maxAttempts {
+			return err
+		}
+		select {
+		case <-s.ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// attempt POSTs the payload once. It returns true if the error is
+// transient and the delivery should be retried.
+func (s *webhookSender) attempt(url string, body []byte, signature string, delivery *api.WebhookDelivery) (bool, error) {
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "olympus/"+OLYMPUS_VERSION)
+	req.Header.Set("X-Olympus-Delivery", delivery.ID)
+	req.Header.Set("X-Olympus-Signature", signature)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	delivery.StatusCode = resp.StatusCode
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("webhook responded %s", resp.Status)
+}
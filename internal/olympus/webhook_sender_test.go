@@ -0,0 +1,161 @@
+package olympus
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	. "gopkg.in/check.v1"
+)
+
+type webhookRequest struct {
+	Header http.Header
+	Body   []byte
+}
+
+type WebhookSenderSuite struct {
+	mx       sync.Mutex
+	failures int
+	requests chan webhookRequest
+	server   *httptest.Server
+}
+
+var _ = Suite(&WebhookSenderSuite{})
+
+func (s *WebhookSenderSuite) SetUpTest(c *C) {
+	s.failures = 0
+	s.requests = make(chan webhookRequest, 10)
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.requests <- webhookRequest{Header: r.Header, Body: body}
+
+		s.mx.Lock()
+		defer s.mx.Unlock()
+		if s.failures > 0 {
+			s.failures -= 1
+			http.Error(w, "try again", http.StatusInternalServerError)
+		}
+	}))
+}
+
+func (s *WebhookSenderSuite) TearDownTest(c *C) {
+	s.server.Close()
+}
+
+func (s *WebhookSenderSuite) setFailures(n int) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.failures = n
+}
+
+func (s *WebhookSenderSuite) sender(log WebhookDeliveryLog) *webhookSender {
+	res := NewWebhookNotificationSender(log).(*webhookSender)
+	res.backoff = time.Millisecond
+	res.maxAttempts = 3
+	return res
+}
+
+func (s *WebhookSenderSuite) notification() NotificationFor {
+	return NotificationFor{
+		Webhook: &api.Webhook{ID: "abcd", URL: s.server.URL, Secret: "some secret"},
+		Updates: []ZonedAlarmUpdate{
+			{Zone: "somehost.box", Update: &api.AlarmUpdate{
+				Identification: "climate.temperature",
+				Level:          api.AlarmLevel_EMERGENCY,
+				Status:         api.AlarmStatus_ON,
+				Description:    "temperature is too high",
+				Time:           timestamppb.New(time.Unix(10, 0)),
+			}},
+		},
+	}
+}
+
+func (s *WebhookSenderSuite) TestSignsPayload(c *C) {
+	log := NewWebhookDeliveryLog(10)
+	delivery := s.sender(log).deliver(s.notification(), time.Unix(20, 0))
+	c.Check(delivery.Error, Equals, "")
+	c.Check(delivery.Attempts, Equals, 1)
+	c.Check(delivery.StatusCode, Equals, http.StatusOK)
+
+	r := <-s.requests
+	c.Check(r.Header.Get("Content-Type"), Equals, "application/json")
+	c.Check(r.Header.Get("X-Olympus-Delivery"), Equals, delivery.ID)
+	c.Check(r.Header.Get("X-Olympus-Signature"), Equals, SignWebhookPayload("some secret", r.Body))
+	c.Check(r.Header.Get("X-Olympus-Signature"), Not(Equals), SignWebhookPayload("other secret", r.Body))
+
+	var payload api.WebhookPayload
+	c.Assert(json.Unmarshal(r.Body, &payload), IsNil)
+	c.Check(payload.Delivery, Equals, delivery.ID)
+	c.Check(payload.SentAt.Equal(time.Unix(20, 0)), Equals, true)
+	c.Assert(payload.Alarms, HasLen, 1)
+	alarm := payload.Alarms[0]
+	c.Check(alarm.Host, Equals, "somehost")
+	c.Check(alarm.Zone, Equals, "box")
+	c.Check(alarm.Identification, Equals, "climate.temperature")
+	c.Check(alarm.Level, Equals, "EMERGENCY")
+	c.Check(alarm.Status, Equals, "ON")
+	c.Check(alarm.Description, Equals, "temperature is too high")
+	c.Check(alarm.Time.Equal(time.Unix(10, 0)), Equals, true)
+
+	c.Check(log.Deliveries("abcd"), DeepEquals, []api.WebhookDelivery{delivery})
+}
+
+func (s *WebhookSenderSuite) TestRetries(c *C) {
+	log := NewWebhookDeliveryLog(10)
+	sender := s.sender(log)
+
+	s.setFailures(2)
+	delivery := sender.deliver(s.notification(), time.Now())
+	c.Check(delivery.Error, Equals, "")
+	c.Check(delivery.Attempts, Equals, 3)
+	c.Check(delivery.StatusCode, Equals, http.StatusOK)
+
+	ids := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		ids[(<-s.requests).Header.Get("X-Olympus-Delivery")] = true
+	}
+	c.Check(ids, DeepEquals, map[string]bool{delivery.ID: true})
+
+	s.setFailures(5)
+	failed := sender.deliver(s.notification(), time.Now())
+	c.Check(failed.Error, Equals, "webhook responded 500 Internal Server Error")
+	c.Check(failed.Attempts, Equals, 3)
+	c.Check(failed.StatusCode, Equals, http.StatusInternalServerError)
+
+	c.Check(log.Deliveries("abcd"), DeepEquals, []api.WebhookDelivery{failed, delivery})
+}
+
+func (s *WebhookSenderSuite) TestDeliveryLogIsBounded(c *C) {
+	log := NewWebhookDeliveryLog(2)
+	for i := 0; i < 3; i++ {
+		log.Log("a", api.WebhookDelivery{Attempts: i})
+	}
+	deliveries := log.Deliveries("a")
+	c.Assert(deliveries, HasLen, 2)
+	c.Check(deliveries[0].Attempts, Equals, 2)
+	c.Check(deliveries[1].Attempts, Equals, 1)
+	c.Check(log.Deliveries("b"), HasLen, 0)
+}
+
+func (s *WebhookSenderSuite) TestCloseAbortsRetries(c *C) {
+	log := NewWebhookDeliveryLog(10)
+	sender := s.sender(log)
+	sender.backoff = time.Hour
+
+	s.setFailures(5)
+	c.Assert(sender.Send(s.notification()), IsNil)
+	<-s.requests
+
+	start := time.Now()
+	c.Check(sender.Close(), IsNil)
+	c.Check(time.Since(start) < time.Second, Equals, true)
+	deliveries := log.Deliveries("abcd")
+	c.Assert(deliveries, HasLen, 1)
+	c.Check(deliveries[0].Attempts, Equals, 1)
+	c.Check(deliveries[0].Error, Not(Equals), "")
+}
@@ -0,0 +1,17 @@
+package api
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// AlarmTokenMetadata is the gRPC metadata holding the token of an
+// alarm source in [OlympusClient] SendAlarm calls.
+const AlarmTokenMetadata = "authorization"
+
+// WithAlarmToken returns a context authenticating SendAlarm calls
+// with the token of an alarm source.
+func WithAlarmToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, AlarmTokenMetadata, "Bearer "+token)
+}
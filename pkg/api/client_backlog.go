@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+)
+
+// BacklogPageSize is the number of reports sent in each message by
+// [UploadClimateBacklog].
+var BacklogPageSize = 4000
+
+// BacklogProgress is called by [UploadClimateBacklog] after each
+// page, with the number of reports sent so far and the number of
+// reports to send.
+type BacklogProgress func(sent, total int)
+
+// UploadClimateBacklog uploads reports, sorted by time, of a zone
+// through the [OlympusClient] ClimateBacklog stream. Reports which
+// are not newer than the latest one received by the server are not
+// sent, so an interrupted upload can be resumed by calling it again
+// with the same reports. progress may be nil.
+func UploadClimateBacklog(
+	ctx context.Context,
+	address string,
+	zone *ClimateZone,
+	reports []*ClimateReport,
+	progress BacklogProgress,
+	options ...ConnectionOption) (*ClimateBacklogSummary, error) {
+
+	config := newConnectionOptions(options...)
+	conn, err := grpc.DialContext(ctx, address, config.dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := uploadClimateBacklog(ctx, NewOlympusClient(conn), zone, reports, progress)
+	return summary, errors.Join(err, conn.Close())
+}
+
+func uploadClimateBacklog(
+	ctx context.Context,
+	client OlympusClient,
+	zone *ClimateZone,
+	reports []*ClimateReport,
+	progress BacklogProgress) (*ClimateBacklogSummary, error) {
+
+	status, err := client.GetClimateBacklogStatus(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	if status.LastTime != nil {
+		last := status.LastTime.AsTime()
+		skipped := 0
+		for ; skipped < len(reports); skipped++ {
+			if reports[skipped].Time.AsTime().After(last) {
+				break
+			}
+		}
+		reports = reports[skipped:]
+	}
+
+	stream, err := client.ClimateBacklog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := &ClimateBacklogUpStream{Zone: zone}
+	for sent := 0; sent < len(reports) || m.Zone != nil; {
+		end := sent + BacklogPageSize
+		if end > len(reports) {
+			end = len(reports)
+		}
+		m.Reports = reports[sent:end]
+		if err := stream.Send(m); err != nil {
+			// the actual error is returned by CloseAndRecv.
+			break
+		}
+		sent = end
+		m = &ClimateBacklogUpStream{}
+		if progress != nil {
+			progress(sent, len(reports))
+		}
+	}
+	return stream.CloseAndRecv()
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	. "gopkg.in/check.v1"
+)
+
+func (s *ClientTaskSuite) TestUploadClimateBacklogResumes(c *C) {
+	defer func(size int) { BacklogPageSize = size }(BacklogPageSize)
+	BacklogPageSize = 2
+
+	start := time.Now().Round(0).UTC()
+	reports := make([]*ClimateReport, 6)
+	for i := range reports {
+		reports[i] = &ClimateReport{
+			Time:         timestamppb.New(start.Add(time.Duration(i) * time.Second)),
+			Temperatures: []float32{21.0},
+		}
+	}
+	zone := &ClimateZone{Host: "foo", Name: "box"}
+
+	s.olympus.EXPECT().
+		GetClimateBacklogStatus(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, z *ClimateZone) (*ClimateBacklogStatus, error) {
+			c.Check(z.Host, Equals, "foo")
+			c.Check(z.Name, Equals, "box")
+			return &ClimateBacklogStatus{LastTime: reports[2].Time}, nil
+		})
+
+	s.olympus.EXPECT().
+		ClimateBacklog(gomock.Any()).
+		DoAndReturn(func(stream Olympus_ClimateBacklogServer) error {
+			var received []*ClimateReport
+			for i := 0; ; i++ {
+				m, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if c.Check(err, IsNil) == false {
+					return err
+				}
+				c.Check(m.Zone != nil, Equals, i == 0)
+				received = append(received, m.Reports...)
+			}
+			c.Assert(received, HasLen, 3)
+			c.Check(received[0].Time.AsTime().Equal(start.Add(3*time.Second)), Equals, true)
+			return stream.SendAndClose(&ClimateBacklogSummary{
+				Received: int64(len(received)),
+				Stored:   int64(len(received)),
+				LastTime: received[2].Time,
+			})
+		})
+
+	var progress [][2]int
+	summary, err := UploadClimateBacklog(context.Background(), testAddress, zone, reports,
+		func(sent, total int) { progress = append(progress, [2]int{sent, total}) })
+	c.Assert(err, IsNil)
+	c.Check(summary.Received, Equals, int64(3))
+	c.Check(summary.LastTime.AsTime().Equal(start.Add(5*time.Second)), Equals, true)
+	c.Check(progress, DeepEquals, [][2]int{{2, 3}, {3, 3}})
+}
@@ -57,8 +57,68 @@ func (mr *MockOlympusClientMockRecorder) Climate(ctx interface{}, opts ...interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Climate", reflect.TypeOf((*MockOlympusClient)(nil).Climate), varargs...)
 }
 
+// ClimateBacklog mocks base method.
+func (m *MockOlympusClient) ClimateBacklog(ctx context.Context, opts ...grpc.CallOption) (Olympus_ClimateBacklogClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ClimateBacklog", varargs...)
+	ret0, _ := ret[0].(Olympus_ClimateBacklogClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClimateBacklog indicates an expected call of ClimateBacklog.
+func (mr *MockOlympusClientMockRecorder) ClimateBacklog(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClimateBacklog", reflect.TypeOf((*MockOlympusClient)(nil).ClimateBacklog), varargs...)
+}
+
+// GetClimateBacklogStatus mocks base method.
+func (m *MockOlympusClient) GetClimateBacklogStatus(ctx context.Context, in *ClimateZone, opts ...grpc.CallOption) (*ClimateBacklogStatus, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetClimateBacklogStatus", varargs...)
+	ret0, _ := ret[0].(*ClimateBacklogStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClimateBacklogStatus indicates an expected call of GetClimateBacklogStatus.
+func (mr *MockOlympusClientMockRecorder) GetClimateBacklogStatus(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClimateBacklogStatus", reflect.TypeOf((*MockOlympusClient)(nil).GetClimateBacklogStatus), varargs...)
+}
+
+// Heartbeat mocks base method.
+func (m *MockOlympusClient) Heartbeat(ctx context.Context, in *ZoneHeartbeat, opts ...grpc.CallOption) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Heartbeat", varargs...)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Heartbeat indicates an expected call of Heartbeat.
+func (mr *MockOlympusClientMockRecorder) Heartbeat(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Heartbeat", reflect.TypeOf((*MockOlympusClient)(nil).Heartbeat), varargs...)
+}
+
 // SendAlarm mocks base method.
-func (m *MockOlympusClient) SendAlarm(ctx context.Context, in *AlarmUpdate, opts ...grpc.CallOption) (*empty.Empty, error) {
+func (m *MockOlympusClient) SendAlarm(ctx context.Context, in *ZoneAlarmUpdate, opts ...grpc.CallOption) (*empty.Empty, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, in}
 	for _, a := range opts {
@@ -371,6 +431,143 @@ func (mr *MockOlympus_TrackingClientMockRecorder) Trailer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockOlympus_TrackingClient)(nil).Trailer))
 }
 
+// MockOlympus_ClimateBacklogClient is a mock of Olympus_ClimateBacklogClient interface.
+type MockOlympus_ClimateBacklogClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockOlympus_ClimateBacklogClientMockRecorder
+}
+
+// MockOlympus_ClimateBacklogClientMockRecorder is the mock recorder for MockOlympus_ClimateBacklogClient.
+type MockOlympus_ClimateBacklogClientMockRecorder struct {
+	mock *MockOlympus_ClimateBacklogClient
+}
+
+// NewMockOlympus_ClimateBacklogClient creates a new mock instance.
+func NewMockOlympus_ClimateBacklogClient(ctrl *gomock.Controller) *MockOlympus_ClimateBacklogClient {
+	mock := &MockOlympus_ClimateBacklogClient{ctrl: ctrl}
+	mock.recorder = &MockOlympus_ClimateBacklogClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOlympus_ClimateBacklogClient) EXPECT() *MockOlympus_ClimateBacklogClientMockRecorder {
+	return m.recorder
+}
+
+// CloseAndRecv mocks base method.
+func (m *MockOlympus_ClimateBacklogClient) CloseAndRecv() (*ClimateBacklogSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAndRecv")
+	ret0, _ := ret[0].(*ClimateBacklogSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseAndRecv indicates an expected call of CloseAndRecv.
+func (mr *MockOlympus_ClimateBacklogClientMockRecorder) CloseAndRecv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAndRecv", reflect.TypeOf((*MockOlympus_ClimateBacklogClient)(nil).CloseAndRecv))
+}
+
+// CloseSend mocks base method.
+func (m *MockOlympus_ClimateBacklogClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockOlympus_ClimateBacklogClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockOlympus_ClimateBacklogClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockOlympus_ClimateBacklogClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockOlympus_ClimateBacklogClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockOlympus_ClimateBacklogClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockOlympus_ClimateBacklogClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockOlympus_ClimateBacklogClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockOlympus_ClimateBacklogClient)(nil).Header))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockOlympus_ClimateBacklogClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockOlympus_ClimateBacklogClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockOlympus_ClimateBacklogClient)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockOlympus_ClimateBacklogClient) Send(arg0 *ClimateBacklogUpStream) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockOlympus_ClimateBacklogClientMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockOlympus_ClimateBacklogClient)(nil).Send), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockOlympus_ClimateBacklogClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockOlympus_ClimateBacklogClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockOlympus_ClimateBacklogClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockOlympus_ClimateBacklogClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockOlympus_ClimateBacklogClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockOlympus_ClimateBacklogClient)(nil).Trailer))
+}
+
 // MockOlympusServer is a mock of OlympusServer interface.
 type MockOlympusServer struct {
 	ctrl     *gomock.Controller
@@ -408,8 +605,52 @@ func (mr *MockOlympusServerMockRecorder) Climate(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Climate", reflect.TypeOf((*MockOlympusServer)(nil).Climate), arg0)
 }
 
+// ClimateBacklog mocks base method.
+func (m *MockOlympusServer) ClimateBacklog(arg0 Olympus_ClimateBacklogServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClimateBacklog", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClimateBacklog indicates an expected call of ClimateBacklog.
+func (mr *MockOlympusServerMockRecorder) ClimateBacklog(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClimateBacklog", reflect.TypeOf((*MockOlympusServer)(nil).ClimateBacklog), arg0)
+}
+
+// GetClimateBacklogStatus mocks base method.
+func (m *MockOlympusServer) GetClimateBacklogStatus(arg0 context.Context, arg1 *ClimateZone) (*ClimateBacklogStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClimateBacklogStatus", arg0, arg1)
+	ret0, _ := ret[0].(*ClimateBacklogStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClimateBacklogStatus indicates an expected call of GetClimateBacklogStatus.
+func (mr *MockOlympusServerMockRecorder) GetClimateBacklogStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClimateBacklogStatus", reflect.TypeOf((*MockOlympusServer)(nil).GetClimateBacklogStatus), arg0, arg1)
+}
+
+// Heartbeat mocks base method.
+func (m *MockOlympusServer) Heartbeat(arg0 context.Context, arg1 *ZoneHeartbeat) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Heartbeat", arg0, arg1)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Heartbeat indicates an expected call of Heartbeat.
+func (mr *MockOlympusServerMockRecorder) Heartbeat(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Heartbeat", reflect.TypeOf((*MockOlympusServer)(nil).Heartbeat), arg0, arg1)
+}
+
 // SendAlarm mocks base method.
-func (m *MockOlympusServer) SendAlarm(arg0 context.Context, arg1 *AlarmUpdate) (*empty.Empty, error) {
+func (m *MockOlympusServer) SendAlarm(arg0 context.Context, arg1 *ZoneAlarmUpdate) (*empty.Empty, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SendAlarm", arg0, arg1)
 	ret0, _ := ret[0].(*empty.Empty)
@@ -751,3 +992,137 @@ func (mr *MockOlympus_TrackingServerMockRecorder) SetTrailer(arg0 interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockOlympus_TrackingServer)(nil).SetTrailer), arg0)
 }
+
+// MockOlympus_ClimateBacklogServer is a mock of Olympus_ClimateBacklogServer interface.
+type MockOlympus_ClimateBacklogServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockOlympus_ClimateBacklogServerMockRecorder
+}
+
+// MockOlympus_ClimateBacklogServerMockRecorder is the mock recorder for MockOlympus_ClimateBacklogServer.
+type MockOlympus_ClimateBacklogServerMockRecorder struct {
+	mock *MockOlympus_ClimateBacklogServer
+}
+
+// NewMockOlympus_ClimateBacklogServer creates a new mock instance.
+func NewMockOlympus_ClimateBacklogServer(ctrl *gomock.Controller) *MockOlympus_ClimateBacklogServer {
+	mock := &MockOlympus_ClimateBacklogServer{ctrl: ctrl}
+	mock.recorder = &MockOlympus_ClimateBacklogServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOlympus_ClimateBacklogServer) EXPECT() *MockOlympus_ClimateBacklogServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockOlympus_ClimateBacklogServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockOlympus_ClimateBacklogServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockOlympus_ClimateBacklogServer)(nil).Context))
+}
+
+// Recv mocks base method.
+func (m *MockOlympus_ClimateBacklogServer) Recv() (*ClimateBacklogUpStream, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*ClimateBacklogUpStream)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockOlympus_ClimateBacklogServerMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockOlympus_ClimateBacklogServer)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockOlympus_ClimateBacklogServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockOlympus_ClimateBacklogServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockOlympus_ClimateBacklogServer)(nil).RecvMsg), m)
+}
+
+// SendAndClose mocks base method.
+func (m *MockOlympus_ClimateBacklogServer) SendAndClose(arg0 *ClimateBacklogSummary) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAndClose", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAndClose indicates an expected call of SendAndClose.
+func (mr *MockOlympus_ClimateBacklogServerMockRecorder) SendAndClose(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAndClose", reflect.TypeOf((*MockOlympus_ClimateBacklogServer)(nil).SendAndClose), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockOlympus_ClimateBacklogServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockOlympus_ClimateBacklogServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockOlympus_ClimateBacklogServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockOlympus_ClimateBacklogServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockOlympus_ClimateBacklogServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockOlympus_ClimateBacklogServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockOlympus_ClimateBacklogServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockOlympus_ClimateBacklogServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockOlympus_ClimateBacklogServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockOlympus_ClimateBacklogServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockOlympus_ClimateBacklogServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockOlympus_ClimateBacklogServer)(nil).SetTrailer), arg0)
+}
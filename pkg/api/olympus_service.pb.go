@@ -7,6 +7,7 @@
 package api
 
 import (
+	duration "github.com/golang/protobuf/ptypes/duration"
 	empty "github.com/golang/protobuf/ptypes/empty"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
@@ -125,6 +126,10 @@ type ClimateReport struct {
 	Temperatures []float32            `protobuf:"fixed32,2,rep,packed,name=temperatures,proto3" json:"temperatures,omitempty"`
 	Humidity     *float32             `protobuf:"fixed32,3,opt,name=humidity,proto3,oneof" json:"humidity,omitempty"`
 	Time         *timestamp.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	// actuator or sensor readings, not sent by older clients.
+	Wind         *float32 `protobuf:"fixed32,5,opt,name=wind,proto3,oneof" json:"wind,omitempty"`
+	VisibleLight *float32 `protobuf:"fixed32,6,opt,name=visible_light,json=visibleLight,proto3,oneof" json:"visible_light,omitempty"`
+	UvLight      *float32 `protobuf:"fixed32,7,opt,name=uv_light,json=uvLight,proto3,oneof" json:"uv_light,omitempty"`
 }
 
 func (x *ClimateReport) Reset() {
@@ -180,6 +185,27 @@ func (x *ClimateReport) GetTime() *timestamp.Timestamp {
 	return nil
 }
 
+func (x *ClimateReport) GetWind() float32 {
+	if x != nil && x.Wind != nil {
+		return *x.Wind
+	}
+	return 0
+}
+
+func (x *ClimateReport) GetVisibleLight() float32 {
+	if x != nil && x.VisibleLight != nil {
+		return *x.VisibleLight
+	}
+	return 0
+}
+
+func (x *ClimateReport) GetUvLight() float32 {
+	if x != nil && x.UvLight != nil {
+		return *x.UvLight
+	}
+	return 0
+}
+
 type AlarmUpdate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -346,6 +372,77 @@ func (x *ClimateState) GetUvLight() float32 {
 	return 0
 }
 
+type TemperatureSensor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Unit    string   `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	Minimum *float32 `protobuf:"fixed32,3,opt,name=minimum,proto3,oneof" json:"minimum,omitempty"`
+	Maximum *float32 `protobuf:"fixed32,4,opt,name=maximum,proto3,oneof" json:"maximum,omitempty"`
+}
+
+func (x *TemperatureSensor) Reset() {
+	*x = TemperatureSensor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_olympus_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TemperatureSensor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemperatureSensor) ProtoMessage() {}
+
+func (x *TemperatureSensor) ProtoReflect() protoreflect.Message {
+	mi := &file_olympus_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemperatureSensor.ProtoReflect.Descriptor instead.
+func (*TemperatureSensor) Descriptor() ([]byte, []int) {
+	return file_olympus_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TemperatureSensor) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TemperatureSensor) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *TemperatureSensor) GetMinimum() float32 {
+	if x != nil && x.Minimum != nil {
+		return *x.Minimum
+	}
+	return 0
+}
+
+func (x *TemperatureSensor) GetMaximum() float32 {
+	if x != nil && x.Maximum != nil {
+		return *x.Maximum
+	}
+	return 0
+}
+
 type ClimateDeclaration struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -358,12 +455,14 @@ type ClimateDeclaration struct {
 	MinHumidity    *float32             `protobuf:"fixed32,5,opt,name=min_humidity,json=minHumidity,proto3,oneof" json:"min_humidity,omitempty"`
 	MaxHumidity    *float32             `protobuf:"fixed32,6,opt,name=max_humidity,json=maxHumidity,proto3,oneof" json:"max_humidity,omitempty"`
 	Since          *timestamp.Timestamp `protobuf:"bytes,7,opt,name=since,proto3,oneof" json:"since,omitempty"`
+	// describes each entry of ClimateReport.temperatures.
+	TemperatureSensors []*TemperatureSensor `protobuf:"bytes,8,rep,name=temperature_sensors,json=temperatureSensors,proto3" json:"temperature_sensors,omitempty"`
 }
 
 func (x *ClimateDeclaration) Reset() {
 	*x = ClimateDeclaration{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_olympus_service_proto_msgTypes[3]
+		mi := &file_olympus_service_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -376,7 +475,7 @@ func (x *ClimateDeclaration) String() string {
 func (*ClimateDeclaration) ProtoMessage() {}
 
 func (x *ClimateDeclaration) ProtoReflect() protoreflect.Message {
-	mi := &file_olympus_service_proto_msgTypes[3]
+	mi := &file_olympus_service_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -389,7 +488,7 @@ func (x *ClimateDeclaration) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClimateDeclaration.ProtoReflect.Descriptor instead.
 func (*ClimateDeclaration) Descriptor() ([]byte, []int) {
-	return file_olympus_service_proto_rawDescGZIP(), []int{3}
+	return file_olympus_service_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ClimateDeclaration) GetHost() string {
@@ -441,6 +540,13 @@ func (x *ClimateDeclaration) GetSince() *timestamp.Timestamp {
 	return nil
 }
 
+func (x *ClimateDeclaration) GetTemperatureSensors() []*TemperatureSensor {
+	if x != nil {
+		return x.TemperatureSensors
+	}
+	return nil
+}
+
 type ClimateTarget struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -456,7 +562,7 @@ type ClimateTarget struct {
 func (x *ClimateTarget) Reset() {
 	*x = ClimateTarget{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_olympus_service_proto_msgTypes[4]
+		mi := &file_olympus_service_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -469,7 +575,7 @@ func (x *ClimateTarget) String() string {
 func (*ClimateTarget) ProtoMessage() {}
 
 func (x *ClimateTarget) ProtoReflect() protoreflect.Message {
-	mi := &file_olympus_service_proto_msgTypes[4]
+	mi := &file_olympus_service_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -482,7 +588,7 @@ func (x *ClimateTarget) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClimateTarget.ProtoReflect.Descriptor instead.
 func (*ClimateTarget) Descriptor() ([]byte, []int) {
-	return file_olympus_service_proto_rawDescGZIP(), []int{4}
+	return file_olympus_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ClimateTarget) GetCurrent() *ClimateState {
@@ -536,7 +642,7 @@ type ClimateUpStream struct {
 func (x *ClimateUpStream) Reset() {
 	*x = ClimateUpStream{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_olympus_service_proto_msgTypes[5]
+		mi := &file_olympus_service_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -549,7 +655,7 @@ func (x *ClimateUpStream) String() string {
 func (*ClimateUpStream) ProtoMessage() {}
 
 func (x *ClimateUpStream) ProtoReflect() protoreflect.Message {
-	mi := &file_olympus_service_proto_msgTypes[5]
+	mi := &file_olympus_service_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -562,7 +668,7 @@ func (x *ClimateUpStream) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClimateUpStream.ProtoReflect.Descriptor instead.
 func (*ClimateUpStream) Descriptor() ([]byte, []int) {
-	return file_olympus_service_proto_rawDescGZIP(), []int{5}
+	return file_olympus_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ClimateUpStream) GetDeclaration() *ClimateDeclaration {
@@ -619,7 +725,7 @@ type ClimateRegistrationConfirmation struct {
 func (x *ClimateRegistrationConfirmation) Reset() {
 	*x = ClimateRegistrationConfirmation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_olympus_service_proto_msgTypes[6]
+		mi := &file_olympus_service_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -632,7 +738,7 @@ func (x *ClimateRegistrationConfirmation) String() string {
 func (*ClimateRegistrationConfirmation) ProtoMessage() {}
 
 func (x *ClimateRegistrationConfirmation) ProtoReflect() protoreflect.Message {
-	mi := &file_olympus_service_proto_msgTypes[6]
+	mi := &file_olympus_service_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -645,7 +751,7 @@ func (x *ClimateRegistrationConfirmation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClimateRegistrationConfirmation.ProtoReflect.Descriptor instead.
 func (*ClimateRegistrationConfirmation) Descriptor() ([]byte, []int) {
-	return file_olympus_service_proto_rawDescGZIP(), []int{6}
+	return file_olympus_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ClimateRegistrationConfirmation) GetSendBacklogs() bool {
@@ -674,7 +780,7 @@ type ClimateDownStream struct {
 func (x *ClimateDownStream) Reset() {
 	*x = ClimateDownStream{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_olympus_service_proto_msgTypes[7]
+		mi := &file_olympus_service_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -687,7 +793,7 @@ func (x *ClimateDownStream) String() string {
 func (*ClimateDownStream) ProtoMessage() {}
 
 func (x *ClimateDownStream) ProtoReflect() protoreflect.Message {
-	mi := &file_olympus_service_proto_msgTypes[7]
+	mi := &file_olympus_service_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -700,7 +806,7 @@ func (x *ClimateDownStream) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClimateDownStream.ProtoReflect.Descriptor instead.
 func (*ClimateDownStream) Descriptor() ([]byte, []int) {
-	return file_olympus_service_proto_rawDescGZIP(), []int{7}
+	return file_olympus_service_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ClimateDownStream) GetRegistrationConfirmation() *ClimateRegistrationConfirmation {
@@ -731,7 +837,7 @@ type TrackingDeclaration struct {
 func (x *TrackingDeclaration) Reset() {
 	*x = TrackingDeclaration{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_olympus_service_proto_msgTypes[8]
+		mi := &file_olympus_service_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -744,7 +850,7 @@ func (x *TrackingDeclaration) String() string {
 func (*TrackingDeclaration) ProtoMessage() {}
 
 func (x *TrackingDeclaration) ProtoReflect() protoreflect.Message {
-	mi := &file_olympus_service_proto_msgTypes[8]
+	mi := &file_olympus_service_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -757,7 +863,7 @@ func (x *TrackingDeclaration) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TrackingDeclaration.ProtoReflect.Descriptor instead.
 func (*TrackingDeclaration) Descriptor() ([]byte, []int) {
-	return file_olympus_service_proto_rawDescGZIP(), []int{8}
+	return file_olympus_service_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *TrackingDeclaration) GetHostname() string {
@@ -801,7 +907,7 @@ type DiskStatus struct {
 func (x *DiskStatus) Reset() {
 	*x = DiskStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_olympus_service_proto_msgTypes[9]
+		mi := &file_olympus_service_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -814,7 +920,7 @@ func (x *DiskStatus) String() string {
 func (*DiskStatus) ProtoMessage() {}
 
 func (x *DiskStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_olympus_service_proto_msgTypes[9]
+	mi := &file_olympus_service_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -827,7 +933,7 @@ func (x *DiskStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiskStatus.ProtoReflect.Descriptor instead.
 func (*DiskStatus) Descriptor() ([]byte, []int) {
-	return file_olympus_service_proto_rawDescGZIP(), []int{9}
+	return file_olympus_service_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DiskStatus) GetTotalBytes() int64 {
@@ -865,7 +971,7 @@ type TrackingUpStream struct {
 func (x *TrackingUpStream) Reset() {
 	*x = TrackingUpStream{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_olympus_service_proto_msgTypes[10]
+		mi := &file_olympus_service_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -878,7 +984,7 @@ func (x *TrackingUpStream) String() string {
 func (*TrackingUpStream) ProtoMessage() {}
 
 func (x *TrackingUpStream) ProtoReflect() protoreflect.Message {
-	mi := &file_olympus_service_proto_msgTypes[10]
+	mi := &file_olympus_service_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -891,7 +997,7 @@ func (x *TrackingUpStream) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TrackingUpStream.ProtoReflect.Descriptor instead.
 func (*TrackingUpStream) Descriptor() ([]byte, []int) {
-	return file_olympus_service_proto_rawDescGZIP(), []int{10}
+	return file_olympus_service_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *TrackingUpStream) GetDeclaration() *TrackingDeclaration {
@@ -933,7 +1039,7 @@ type TrackingDownStream struct {
 func (x *TrackingDownStream) Reset() {
 	*x = TrackingDownStream{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_olympus_service_proto_msgTypes[11]
+		mi := &file_olympus_service_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -946,7 +1052,7 @@ func (x *TrackingDownStream) String() string {
 func (*TrackingDownStream) ProtoMessage() {}
 
 func (x *TrackingDownStream) ProtoReflect() protoreflect.Message {
-	mi := &file_olympus_service_proto_msgTypes[11]
+	mi := &file_olympus_service_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -959,7 +1065,7 @@ func (x *TrackingDownStream) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TrackingDownStream.ProtoReflect.Descriptor instead.
 func (*TrackingDownStream) Descriptor() ([]byte, []int) {
-	return file_olympus_service_proto_rawDescGZIP(), []int{11}
+	return file_olympus_service_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *TrackingDownStream) GetMetadata() map[string]string {
@@ -969,6 +1075,352 @@ func (x *TrackingDownStream) GetMetadata() map[string]string {
 	return nil
 }
 
+type ClimateZone struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *ClimateZone) Reset() {
+	*x = ClimateZone{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_olympus_service_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClimateZone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClimateZone) ProtoMessage() {}
+
+func (x *ClimateZone) ProtoReflect() protoreflect.Message {
+	mi := &file_olympus_service_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClimateZone.ProtoReflect.Descriptor instead.
+func (*ClimateZone) Descriptor() ([]byte, []int) {
+	return file_olympus_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ClimateZone) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *ClimateZone) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ClimateBacklogStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// latest report received through ClimateBacklog, uploads should
+	// resume after it.
+	LastTime *timestamp.Timestamp `protobuf:"bytes,1,opt,name=last_time,json=lastTime,proto3,oneof" json:"last_time,omitempty"`
+}
+
+func (x *ClimateBacklogStatus) Reset() {
+	*x = ClimateBacklogStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_olympus_service_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClimateBacklogStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClimateBacklogStatus) ProtoMessage() {}
+
+func (x *ClimateBacklogStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_olympus_service_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClimateBacklogStatus.ProtoReflect.Descriptor instead.
+func (*ClimateBacklogStatus) Descriptor() ([]byte, []int) {
+	return file_olympus_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ClimateBacklogStatus) GetLastTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastTime
+	}
+	return nil
+}
+
+type ClimateBacklogUpStream struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// required in the first message of the stream.
+	Zone    *ClimateZone     `protobuf:"bytes,1,opt,name=zone,proto3,oneof" json:"zone,omitempty"`
+	Reports []*ClimateReport `protobuf:"bytes,2,rep,name=reports,proto3" json:"reports,omitempty"`
+}
+
+func (x *ClimateBacklogUpStream) Reset() {
+	*x = ClimateBacklogUpStream{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_olympus_service_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClimateBacklogUpStream) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClimateBacklogUpStream) ProtoMessage() {}
+
+func (x *ClimateBacklogUpStream) ProtoReflect() protoreflect.Message {
+	mi := &file_olympus_service_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClimateBacklogUpStream.ProtoReflect.Descriptor instead.
+func (*ClimateBacklogUpStream) Descriptor() ([]byte, []int) {
+	return file_olympus_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ClimateBacklogUpStream) GetZone() *ClimateZone {
+	if x != nil {
+		return x.Zone
+	}
+	return nil
+}
+
+func (x *ClimateBacklogUpStream) GetReports() []*ClimateReport {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+type ClimateBacklogSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Received int64 `protobuf:"varint,1,opt,name=received,proto3" json:"received,omitempty"`
+	// received reports which were not already stored.
+	Stored   int64                `protobuf:"varint,2,opt,name=stored,proto3" json:"stored,omitempty"`
+	LastTime *timestamp.Timestamp `protobuf:"bytes,3,opt,name=last_time,json=lastTime,proto3,oneof" json:"last_time,omitempty"`
+}
+
+func (x *ClimateBacklogSummary) Reset() {
+	*x = ClimateBacklogSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_olympus_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClimateBacklogSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClimateBacklogSummary) ProtoMessage() {}
+
+func (x *ClimateBacklogSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_olympus_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClimateBacklogSummary.ProtoReflect.Descriptor instead.
+func (*ClimateBacklogSummary) Descriptor() ([]byte, []int) {
+	return file_olympus_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ClimateBacklogSummary) GetReceived() int64 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *ClimateBacklogSummary) GetStored() int64 {
+	if x != nil {
+		return x.Stored
+	}
+	return 0
+}
+
+func (x *ClimateBacklogSummary) GetLastTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastTime
+	}
+	return nil
+}
+
+type ZoneAlarmUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the zone must be allowed for the alarm source whose token is
+	// sent in the 'authorization' metadata.
+	Zone   *ClimateZone `protobuf:"bytes,1,opt,name=zone,proto3" json:"zone,omitempty"`
+	Update *AlarmUpdate `protobuf:"bytes,2,opt,name=update,proto3" json:"update,omitempty"`
+}
+
+func (x *ZoneAlarmUpdate) Reset() {
+	*x = ZoneAlarmUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_olympus_service_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZoneAlarmUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZoneAlarmUpdate) ProtoMessage() {}
+
+func (x *ZoneAlarmUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_olympus_service_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZoneAlarmUpdate.ProtoReflect.Descriptor instead.
+func (*ZoneAlarmUpdate) Descriptor() ([]byte, []int) {
+	return file_olympus_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ZoneAlarmUpdate) GetZone() *ClimateZone {
+	if x != nil {
+		return x.Zone
+	}
+	return nil
+}
+
+func (x *ZoneAlarmUpdate) GetUpdate() *AlarmUpdate {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+type ZoneHeartbeat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// expected period between heartbeats. A zero interval
+	// unregisters the zone.
+	Interval *duration.Duration `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
+}
+
+func (x *ZoneHeartbeat) Reset() {
+	*x = ZoneHeartbeat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_olympus_service_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZoneHeartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZoneHeartbeat) ProtoMessage() {}
+
+func (x *ZoneHeartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_olympus_service_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZoneHeartbeat.ProtoReflect.Descriptor instead.
+func (*ZoneHeartbeat) Descriptor() ([]byte, []int) {
+	return file_olympus_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ZoneHeartbeat) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *ZoneHeartbeat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ZoneHeartbeat) GetInterval() *duration.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
 var File_olympus_service_proto protoreflect.FileDescriptor
 
 var file_olympus_service_proto_rawDesc = []byte{
@@ -978,7 +1430,9 @@ var file_olympus_service_proto_rawDesc = []byte{
 	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0x91, 0x01, 0x0a, 0x0d, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x52,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x9c, 0x02, 0x0a, 0x0d, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x52,
 	0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61,
 	0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x02, 0x52, 0x0c, 0x74, 0x65, 0x6d,
 	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x08, 0x68, 0x75, 0x6d,
@@ -986,212 +1440,294 @@ var file_olympus_service_proto_rawDesc = []byte{
 	0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69,
 	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
 	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x68,
-	0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x22, 0xf2, 0x01, 0x0a, 0x0b, 0x41, 0x6c, 0x61, 0x72,
-	0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x69, 0x64, 0x65, 0x6e, 0x74,
-	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0e, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x2e, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18,
-	0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x41, 0x6c,
-	0x61, 0x72, 0x6d, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12,
-	0x31, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x19, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x41,
-	0x6c, 0x61, 0x72, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69,
-	0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x92, 0x02, 0x0a,
-	0x0c, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x25, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00, 0x52, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x68, 0x75, 0x6d, 0x69,
-	0x64, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x48, 0x01, 0x52, 0x08, 0x68, 0x75,
-	0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x88, 0x01, 0x01, 0x12, 0x17, 0x0a, 0x04, 0x77, 0x69, 0x6e,
-	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x48, 0x02, 0x52, 0x04, 0x77, 0x69, 0x6e, 0x64, 0x88,
-	0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d, 0x76, 0x69, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x5f, 0x6c, 0x69,
-	0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x48, 0x03, 0x52, 0x0c, 0x76, 0x69, 0x73,
-	0x69, 0x62, 0x6c, 0x65, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x88, 0x01, 0x01, 0x12, 0x1e, 0x0a, 0x08,
-	0x75, 0x76, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x02, 0x48, 0x04,
-	0x52, 0x07, 0x75, 0x76, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c,
-	0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x0b, 0x0a, 0x09,
-	0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x77, 0x69,
-	0x6e, 0x64, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x76, 0x69, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x5f, 0x6c,
-	0x69, 0x67, 0x68, 0x74, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x75, 0x76, 0x5f, 0x6c, 0x69, 0x67, 0x68,
-	0x74, 0x22, 0xf3, 0x02, 0x0a, 0x12, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x44, 0x65, 0x63,
-	0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x2c, 0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74,
-	0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00, 0x52, 0x0e, 0x6d, 0x69, 0x6e,
-	0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2c,
-	0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x48, 0x01, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x54, 0x65,
-	0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x88, 0x01, 0x01, 0x12, 0x26, 0x0a, 0x0c,
-	0x6d, 0x69, 0x6e, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x02, 0x48, 0x02, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x48, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74,
-	0x79, 0x88, 0x01, 0x01, 0x12, 0x26, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x68, 0x75, 0x6d, 0x69,
-	0x64, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x02, 0x48, 0x03, 0x52, 0x0b, 0x6d, 0x61,
-	0x78, 0x48, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x88, 0x01, 0x01, 0x12, 0x35, 0x0a, 0x05,
-	0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x04, 0x77, 0x69,
+	0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x48, 0x01, 0x52, 0x04, 0x77, 0x69, 0x6e, 0x64,
+	0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d, 0x76, 0x69, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x5f, 0x6c,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x02, 0x48, 0x02, 0x52, 0x0c, 0x76, 0x69,
+	0x73, 0x69, 0x62, 0x6c, 0x65, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x88, 0x01, 0x01, 0x12, 0x1e, 0x0a,
+	0x08, 0x75, 0x76, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x02, 0x48,
+	0x03, 0x52, 0x07, 0x75, 0x76, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a,
+	0x09, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x77,
+	0x69, 0x6e, 0x64, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x76, 0x69, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x5f,
+	0x6c, 0x69, 0x67, 0x68, 0x74, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x75, 0x76, 0x5f, 0x6c, 0x69, 0x67,
+	0x68, 0x74, 0x22, 0xf2, 0x01, 0x0a, 0x0b, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x05, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x66, 0x6f, 0x72, 0x74,
+	0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x66, 0x6f, 0x72,
+	0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2e, 0x0a,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
 	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x04, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65,
-	0x88, 0x01, 0x01, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x74, 0x65, 0x6d, 0x70,
-	0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x6d, 0x61, 0x78, 0x5f,
-	0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x0f, 0x0a, 0x0d, 0x5f,
-	0x6d, 0x69, 0x6e, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x42, 0x0f, 0x0a, 0x0d,
-	0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x42, 0x08, 0x0a,
-	0x06, 0x5f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x22, 0xea, 0x02, 0x0a, 0x0d, 0x43, 0x6c, 0x69, 0x6d,
-	0x61, 0x74, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x75, 0x72,
-	0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x66, 0x6f, 0x72,
-	0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74,
-	0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12,
-	0x40, 0x0a, 0x0b, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d,
-	0x70, 0x75, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65,
-	0x48, 0x00, 0x52, 0x0a, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x64, 0x88, 0x01,
-	0x01, 0x12, 0x33, 0x0a, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43,
-	0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x01, 0x52, 0x04, 0x6e,
-	0x65, 0x78, 0x74, 0x88, 0x01, 0x01, 0x12, 0x3a, 0x0a, 0x08, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x65,
-	0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e,
-	0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x48, 0x02, 0x52, 0x07, 0x6e, 0x65, 0x78, 0x74, 0x45, 0x6e, 0x64, 0x88,
-	0x01, 0x01, 0x12, 0x3c, 0x0a, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x48, 0x03, 0x52, 0x08, 0x6e, 0x65, 0x78, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x88, 0x01, 0x01,
-	0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x65, 0x6e, 0x64,
-	0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6e, 0x65, 0x78, 0x74, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6e, 0x65,
-	0x78, 0x74, 0x5f, 0x65, 0x6e, 0x64, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x6e, 0x65, 0x78, 0x74, 0x5f,
-	0x74, 0x69, 0x6d, 0x65, 0x22, 0xb9, 0x03, 0x0a, 0x0f, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65,
-	0x55, 0x70, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x47, 0x0a, 0x0b, 0x64, 0x65, 0x63, 0x6c,
-	0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
-	0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43, 0x6c, 0x69,
-	0x6d, 0x61, 0x74, 0x65, 0x44, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48,
-	0x00, 0x52, 0x0b, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01,
-	0x01, 0x12, 0x35, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75,
-	0x73, 0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52,
-	0x07, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x38, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e,
-	0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x54,
-	0x61, 0x72, 0x67, 0x65, 0x74, 0x48, 0x01, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x88,
-	0x01, 0x01, 0x12, 0x31, 0x0a, 0x06, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75,
-	0x73, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x06, 0x61,
-	0x6c, 0x61, 0x72, 0x6d, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x6c, 0x6f, 0x67,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x6c, 0x6f, 0x67, 0x12,
-	0x47, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0a, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x2b, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73,
-	0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x55, 0x70, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
-	0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08,
-	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
-	0x22, 0x63, 0x0a, 0x1f, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73,
-	0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x62, 0x61, 0x63, 0x6b,
-	0x6c, 0x6f, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x65, 0x6e, 0x64,
-	0x42, 0x61, 0x63, 0x6b, 0x6c, 0x6f, 0x67, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65,
-	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67,
-	0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x87, 0x02, 0x0a, 0x11, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74,
-	0x65, 0x44, 0x6f, 0x77, 0x6e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x6a, 0x0a, 0x19, 0x72,
-	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x92, 0x02, 0x0a, 0x0c, 0x43, 0x6c, 0x69, 0x6d,
+	0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0b,
+	0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x02, 0x48, 0x00, 0x52, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x02, 0x48, 0x01, 0x52, 0x08, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74,
+	0x79, 0x88, 0x01, 0x01, 0x12, 0x17, 0x0a, 0x04, 0x77, 0x69, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x02, 0x48, 0x02, 0x52, 0x04, 0x77, 0x69, 0x6e, 0x64, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a,
+	0x0d, 0x76, 0x69, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x02, 0x48, 0x03, 0x52, 0x0c, 0x76, 0x69, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x4c,
+	0x69, 0x67, 0x68, 0x74, 0x88, 0x01, 0x01, 0x12, 0x1e, 0x0a, 0x08, 0x75, 0x76, 0x5f, 0x6c, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x02, 0x48, 0x04, 0x52, 0x07, 0x75, 0x76, 0x4c,
+	0x69, 0x67, 0x68, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x74, 0x65, 0x6d, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x68, 0x75, 0x6d, 0x69,
+	0x64, 0x69, 0x74, 0x79, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x42, 0x10, 0x0a,
+	0x0e, 0x5f, 0x76, 0x69, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x42,
+	0x0b, 0x0a, 0x09, 0x5f, 0x75, 0x76, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x22, 0x91, 0x01, 0x0a,
+	0x11, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x53, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x12, 0x1d, 0x0a, 0x07, 0x6d, 0x69,
+	0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00, 0x52, 0x07, 0x6d,
+	0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x07, 0x6d, 0x61, 0x78,
+	0x69, 0x6d, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x48, 0x01, 0x52, 0x07, 0x6d, 0x61,
+	0x78, 0x69, 0x6d, 0x75, 0x6d, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x69, 0x6e,
+	0x69, 0x6d, 0x75, 0x6d, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d,
+	0x22, 0xc5, 0x03, 0x0a, 0x12, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x44, 0x65, 0x63, 0x6c,
+	0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x2c, 0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00, 0x52, 0x0e, 0x6d, 0x69, 0x6e, 0x54,
+	0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a,
+	0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x48, 0x01, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x54, 0x65, 0x6d,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x88, 0x01, 0x01, 0x12, 0x26, 0x0a, 0x0c, 0x6d,
+	0x69, 0x6e, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x02, 0x48, 0x02, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x48, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79,
+	0x88, 0x01, 0x01, 0x12, 0x26, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64,
+	0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x02, 0x48, 0x03, 0x52, 0x0b, 0x6d, 0x61, 0x78,
+	0x48, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x88, 0x01, 0x01, 0x12, 0x35, 0x0a, 0x05, 0x73,
+	0x69, 0x6e, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x04, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x88,
+	0x01, 0x01, 0x12, 0x50, 0x0a, 0x13, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x5f, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x54,
+	0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x52, 0x12, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x53, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x73, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x74, 0x65, 0x6d,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x6d, 0x61, 0x78,
+	0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x0f, 0x0a, 0x0d,
+	0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x42, 0x0f, 0x0a,
+	0x0d, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x42, 0x08,
+	0x0a, 0x06, 0x5f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x22, 0xea, 0x02, 0x0a, 0x0d, 0x43, 0x6c, 0x69,
+	0x6d, 0x61, 0x74, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x66, 0x6f,
+	0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61,
+	0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x12, 0x40, 0x0a, 0x0b, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x65, 0x6e, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79,
+	0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x64, 0x88,
+	0x01, 0x01, 0x12, 0x33, 0x0a, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e,
+	0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x01, 0x52, 0x04,
+	0x6e, 0x65, 0x78, 0x74, 0x88, 0x01, 0x01, 0x12, 0x3a, 0x0a, 0x08, 0x6e, 0x65, 0x78, 0x74, 0x5f,
+	0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x66, 0x6f, 0x72, 0x74,
+	0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x02, 0x52, 0x07, 0x6e, 0x65, 0x78, 0x74, 0x45, 0x6e, 0x64,
+	0x88, 0x01, 0x01, 0x12, 0x3c, 0x0a, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x48, 0x03, 0x52, 0x08, 0x6e, 0x65, 0x78, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x88, 0x01,
+	0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x65, 0x6e,
+	0x64, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6e, 0x65, 0x78, 0x74, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6e,
+	0x65, 0x78, 0x74, 0x5f, 0x65, 0x6e, 0x64, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x6e, 0x65, 0x78, 0x74,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xb9, 0x03, 0x0a, 0x0f, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74,
+	0x65, 0x55, 0x70, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x47, 0x0a, 0x0b, 0x64, 0x65, 0x63,
+	0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
 	0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43, 0x6c,
-	0x69, 0x6d, 0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x18, 0x72,
-	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x49, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x66, 0x6f, 0x72, 0x74,
+	0x69, 0x6d, 0x61, 0x74, 0x65, 0x44, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x48, 0x00, 0x52, 0x0b, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x88,
+	0x01, 0x01, 0x12, 0x35, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70,
+	0x75, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x52, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x38, 0x0a, 0x06, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x66, 0x6f, 0x72, 0x74,
+	0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65,
+	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x48, 0x01, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x88, 0x01, 0x01, 0x12, 0x31, 0x0a, 0x06, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70,
+	0x75, 0x73, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x06,
+	0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x6c, 0x6f,
+	0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x6c, 0x6f, 0x67,
+	0x12, 0x47, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0a, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75,
+	0x73, 0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x55, 0x70, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x63, 0x6c, 0x61,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x22, 0x63, 0x0a, 0x1f, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x62, 0x61, 0x63,
+	0x6b, 0x6c, 0x6f, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x65, 0x6e,
+	0x64, 0x42, 0x61, 0x63, 0x6b, 0x6c, 0x6f, 0x67, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61,
+	0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x87, 0x02, 0x0a, 0x11, 0x43, 0x6c, 0x69, 0x6d, 0x61,
+	0x74, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x6a, 0x0a, 0x19,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2d, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43,
+	0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x18,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x49, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x66, 0x6f, 0x72,
+	0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74,
+	0x65, 0x44, 0x6f, 0x77, 0x6e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0xc0, 0x01, 0x0a, 0x13, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x63,
+	0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x70,
+	0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x35, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x00, 0x52,
+	0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x73, 0x69,
+	0x6e, 0x63, 0x65, 0x22, 0x76, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x72, 0x65, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x66, 0x72, 0x65, 0x65, 0x42, 0x79, 0x74, 0x65,
+	0x73, 0x12, 0x28, 0x0a, 0x10, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x73,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x22, 0xf6, 0x02, 0x0a, 0x10,
+	0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x55, 0x70, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x12, 0x48, 0x0a, 0x0b, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79,
+	0x6d, 0x70, 0x75, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x63,
+	0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0b, 0x64, 0x65, 0x63, 0x6c,
+	0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x31, 0x0a, 0x06, 0x61, 0x6c,
+	0x61, 0x72, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x66, 0x6f, 0x72,
+	0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x06, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x12, 0x3e, 0x0a,
+	0x0b, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75,
+	0x73, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x01, 0x52, 0x0a,
+	0x64, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x88, 0x01, 0x01, 0x12, 0x48, 0x0a,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2c, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x54,
+	0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x55, 0x70, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x22, 0x9d, 0x01, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e,
+	0x67, 0x44, 0x6f, 0x77, 0x6e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x4a, 0x0a, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e,
+	0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x54, 0x72, 0x61,
+	0x63, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x6f, 0x77, 0x6e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x35, 0x0a, 0x0b, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x5a,
+	0x6f, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x62, 0x0a, 0x14, 0x43,
+	0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x6c, 0x6f, 0x67, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x3c, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x48, 0x00, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x88, 0x01,
+	0x01, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x22,
+	0x8c, 0x01, 0x0a, 0x16, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x6c,
+	0x6f, 0x67, 0x55, 0x70, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x32, 0x0a, 0x04, 0x7a, 0x6f,
+	0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e,
+	0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x5a,
+	0x6f, 0x6e, 0x65, 0x48, 0x00, 0x52, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x88, 0x01, 0x01, 0x12, 0x35,
+	0x0a, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43,
+	0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x07, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x73, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x22, 0x97,
+	0x01, 0x0a, 0x15, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x6c, 0x6f,
+	0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x12, 0x3c, 0x0a, 0x09,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x00, 0x52, 0x08, 0x6c,
+	0x61, 0x73, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x73, 0x0a, 0x0f, 0x5a, 0x6f, 0x6e, 0x65,
+	0x41, 0x6c, 0x61, 0x72, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x7a,
+	0x6f, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x66, 0x6f, 0x72, 0x74,
 	0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65,
-	0x44, 0x6f, 0x77, 0x6e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
-	0xc0, 0x01, 0x0a, 0x13, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x63, 0x6c,
-	0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x74, 0x72, 0x65,
-	0x61, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x65,
-	0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x35, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x00, 0x52, 0x05,
-	0x73, 0x69, 0x6e, 0x63, 0x65, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x73, 0x69, 0x6e,
-	0x63, 0x65, 0x22, 0x76, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65,
-	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x72, 0x65, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x66, 0x72, 0x65, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73,
-	0x12, 0x28, 0x0a, 0x10, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x73, 0x65,
-	0x63, 0x6f, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x62, 0x79, 0x74, 0x65,
-	0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x22, 0xf6, 0x02, 0x0a, 0x10, 0x54,
-	0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x55, 0x70, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12,
-	0x48, 0x0a, 0x0b, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d,
-	0x70, 0x75, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x63, 0x6c,
-	0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0b, 0x64, 0x65, 0x63, 0x6c, 0x61,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x31, 0x0a, 0x06, 0x61, 0x6c, 0x61,
-	0x72, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x66, 0x6f, 0x72, 0x74,
-	0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x52, 0x06, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x12, 0x3e, 0x0a, 0x0b,
-	0x64, 0x69, 0x73, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x18, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73,
-	0x2e, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x01, 0x52, 0x0a, 0x64,
-	0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x88, 0x01, 0x01, 0x12, 0x48, 0x0a, 0x08,
-	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c,
-	0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x54, 0x72,
-	0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x55, 0x70, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
-	0x02, 0x38, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x22, 0x9d, 0x01, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67,
-	0x44, 0x6f, 0x77, 0x6e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x4a, 0x0a, 0x08, 0x6d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x66,
-	0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x63,
-	0x6b, 0x69, 0x6e, 0x67, 0x44, 0x6f, 0x77, 0x6e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
-	0x02, 0x38, 0x01, 0x2a, 0x1e, 0x0a, 0x0b, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4e, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4f, 0x46,
-	0x46, 0x10, 0x01, 0x2a, 0x35, 0x0a, 0x0a, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x4c, 0x65, 0x76, 0x65,
-	0x6c, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0d,
-	0x0a, 0x09, 0x45, 0x4d, 0x45, 0x52, 0x47, 0x45, 0x4e, 0x43, 0x59, 0x10, 0x01, 0x12, 0x0b, 0x0a,
-	0x07, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x10, 0x02, 0x32, 0xea, 0x01, 0x0a, 0x07, 0x4f,
-	0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x12, 0x4d, 0x0a, 0x07, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74,
-	0x65, 0x12, 0x1d, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73,
-	0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x55, 0x70, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
-	0x1a, 0x1f, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e,
-	0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x53, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x28, 0x01, 0x30, 0x01, 0x12, 0x50, 0x0a, 0x08, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e,
-	0x67, 0x12, 0x1e, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73,
-	0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x55, 0x70, 0x53, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x1a, 0x20, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73,
-	0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x6f, 0x77, 0x6e, 0x53, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x28, 0x01, 0x30, 0x01, 0x12, 0x3e, 0x0a, 0x09, 0x53, 0x65, 0x6e, 0x64, 0x41,
-	0x6c, 0x61, 0x72, 0x6d, 0x12, 0x19, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d,
-	0x70, 0x75, 0x73, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x1a,
-	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x3b, 0x61, 0x70, 0x69,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x5a, 0x6f, 0x6e, 0x65, 0x52, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x66, 0x6f, 0x72,
+	0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0x6e, 0x0a,
+	0x0d, 0x5a, 0x6f, 0x6e, 0x65, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x2a, 0x1e, 0x0a,
+	0x0b, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x06, 0x0a, 0x02,
+	0x4f, 0x4e, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4f, 0x46, 0x46, 0x10, 0x01, 0x2a, 0x35, 0x0a,
+	0x0a, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x0b, 0x0a, 0x07, 0x57,
+	0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x4d, 0x45, 0x52,
+	0x47, 0x45, 0x4e, 0x43, 0x59, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x46, 0x41, 0x49, 0x4c, 0x55,
+	0x52, 0x45, 0x10, 0x02, 0x32, 0xe9, 0x03, 0x0a, 0x07, 0x4f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73,
+	0x12, 0x4d, 0x0a, 0x07, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x2e, 0x66, 0x6f,
+	0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61,
+	0x74, 0x65, 0x55, 0x70, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x1a, 0x1f, 0x2e, 0x66, 0x6f, 0x72,
+	0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74,
+	0x65, 0x44, 0x6f, 0x77, 0x6e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x28, 0x01, 0x30, 0x01, 0x12,
+	0x50, 0x0a, 0x08, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x1e, 0x2e, 0x66, 0x6f,
+	0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b,
+	0x69, 0x6e, 0x67, 0x55, 0x70, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x1a, 0x20, 0x2e, 0x66, 0x6f,
+	0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b,
+	0x69, 0x6e, 0x67, 0x44, 0x6f, 0x77, 0x6e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x28, 0x01, 0x30,
+	0x01, 0x12, 0x42, 0x0a, 0x09, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x12, 0x1d,
+	0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x5a, 0x6f,
+	0x6e, 0x65, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x1a, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x58, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x69, 0x6d,
+	0x61, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x6c, 0x6f, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x19, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e,
+	0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x5a, 0x6f, 0x6e, 0x65, 0x1a, 0x22, 0x2e, 0x66, 0x6f,
+	0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61,
+	0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x6c, 0x6f, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x5d, 0x0a, 0x0e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x6c, 0x6f,
+	0x67, 0x12, 0x24, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73,
+	0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x6c, 0x6f, 0x67, 0x55,
+	0x70, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x1a, 0x23, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6f,
+	0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x42, 0x61,
+	0x63, 0x6b, 0x6c, 0x6f, 0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x28, 0x01, 0x12, 0x40,
+	0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x1b, 0x2e, 0x66, 0x6f,
+	0x72, 0x74, 0x2e, 0x6f, 0x6c, 0x79, 0x6d, 0x70, 0x75, 0x73, 0x2e, 0x5a, 0x6f, 0x6e, 0x65, 0x48,
+	0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x42, 0x07, 0x5a, 0x05, 0x2e, 0x3b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -1207,64 +1743,86 @@ func file_olympus_service_proto_rawDescGZIP() []byte {
 }
 
 var file_olympus_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_olympus_service_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_olympus_service_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_olympus_service_proto_goTypes = []interface{}{
 	(AlarmStatus)(0),                        // 0: fort.olympus.AlarmStatus
 	(AlarmLevel)(0),                         // 1: fort.olympus.AlarmLevel
 	(*ClimateReport)(nil),                   // 2: fort.olympus.ClimateReport
 	(*AlarmUpdate)(nil),                     // 3: fort.olympus.AlarmUpdate
 	(*ClimateState)(nil),                    // 4: fort.olympus.ClimateState
-	(*ClimateDeclaration)(nil),              // 5: fort.olympus.ClimateDeclaration
-	(*ClimateTarget)(nil),                   // 6: fort.olympus.ClimateTarget
-	(*ClimateUpStream)(nil),                 // 7: fort.olympus.ClimateUpStream
-	(*ClimateRegistrationConfirmation)(nil), // 8: fort.olympus.ClimateRegistrationConfirmation
-	(*ClimateDownStream)(nil),               // 9: fort.olympus.ClimateDownStream
-	(*TrackingDeclaration)(nil),             // 10: fort.olympus.TrackingDeclaration
-	(*DiskStatus)(nil),                      // 11: fort.olympus.DiskStatus
-	(*TrackingUpStream)(nil),                // 12: fort.olympus.TrackingUpStream
-	(*TrackingDownStream)(nil),              // 13: fort.olympus.TrackingDownStream
-	nil,                                     // 14: fort.olympus.ClimateUpStream.MetadataEntry
-	nil,                                     // 15: fort.olympus.ClimateDownStream.MetadataEntry
-	nil,                                     // 16: fort.olympus.TrackingUpStream.MetadataEntry
-	nil,                                     // 17: fort.olympus.TrackingDownStream.MetadataEntry
-	(*timestamp.Timestamp)(nil),             // 18: google.protobuf.Timestamp
-	(*empty.Empty)(nil),                     // 19: google.protobuf.Empty
+	(*TemperatureSensor)(nil),               // 5: fort.olympus.TemperatureSensor
+	(*ClimateDeclaration)(nil),              // 6: fort.olympus.ClimateDeclaration
+	(*ClimateTarget)(nil),                   // 7: fort.olympus.ClimateTarget
+	(*ClimateUpStream)(nil),                 // 8: fort.olympus.ClimateUpStream
+	(*ClimateRegistrationConfirmation)(nil), // 9: fort.olympus.ClimateRegistrationConfirmation
+	(*ClimateDownStream)(nil),               // 10: fort.olympus.ClimateDownStream
+	(*TrackingDeclaration)(nil),             // 11: fort.olympus.TrackingDeclaration
+	(*DiskStatus)(nil),                      // 12: fort.olympus.DiskStatus
+	(*TrackingUpStream)(nil),                // 13: fort.olympus.TrackingUpStream
+	(*TrackingDownStream)(nil),              // 14: fort.olympus.TrackingDownStream
+	(*ClimateZone)(nil),                     // 15: fort.olympus.ClimateZone
+	(*ClimateBacklogStatus)(nil),            // 16: fort.olympus.ClimateBacklogStatus
+	(*ClimateBacklogUpStream)(nil),          // 17: fort.olympus.ClimateBacklogUpStream
+	(*ClimateBacklogSummary)(nil),           // 18: fort.olympus.ClimateBacklogSummary
+	(*ZoneAlarmUpdate)(nil),                 // 19: fort.olympus.ZoneAlarmUpdate
+	(*ZoneHeartbeat)(nil),                   // 20: fort.olympus.ZoneHeartbeat
+	nil,                                     // 21: fort.olympus.ClimateUpStream.MetadataEntry
+	nil,                                     // 22: fort.olympus.ClimateDownStream.MetadataEntry
+	nil,                                     // 23: fort.olympus.TrackingUpStream.MetadataEntry
+	nil,                                     // 24: fort.olympus.TrackingDownStream.MetadataEntry
+	(*timestamp.Timestamp)(nil),             // 25: google.protobuf.Timestamp
+	(*duration.Duration)(nil),               // 26: google.protobuf.Duration
+	(*empty.Empty)(nil),                     // 27: google.protobuf.Empty
 }
 var file_olympus_service_proto_depIdxs = []int32{
-	18, // 0: fort.olympus.ClimateReport.time:type_name -> google.protobuf.Timestamp
+	25, // 0: fort.olympus.ClimateReport.time:type_name -> google.protobuf.Timestamp
 	1,  // 1: fort.olympus.AlarmUpdate.level:type_name -> fort.olympus.AlarmLevel
 	0,  // 2: fort.olympus.AlarmUpdate.status:type_name -> fort.olympus.AlarmStatus
-	18, // 3: fort.olympus.AlarmUpdate.time:type_name -> google.protobuf.Timestamp
-	18, // 4: fort.olympus.ClimateDeclaration.since:type_name -> google.protobuf.Timestamp
-	4,  // 5: fort.olympus.ClimateTarget.current:type_name -> fort.olympus.ClimateState
-	4,  // 6: fort.olympus.ClimateTarget.current_end:type_name -> fort.olympus.ClimateState
-	4,  // 7: fort.olympus.ClimateTarget.next:type_name -> fort.olympus.ClimateState
-	4,  // 8: fort.olympus.ClimateTarget.next_end:type_name -> fort.olympus.ClimateState
-	18, // 9: fort.olympus.ClimateTarget.next_time:type_name -> google.protobuf.Timestamp
-	5,  // 10: fort.olympus.ClimateUpStream.declaration:type_name -> fort.olympus.ClimateDeclaration
-	2,  // 11: fort.olympus.ClimateUpStream.reports:type_name -> fort.olympus.ClimateReport
-	6,  // 12: fort.olympus.ClimateUpStream.target:type_name -> fort.olympus.ClimateTarget
-	3,  // 13: fort.olympus.ClimateUpStream.alarms:type_name -> fort.olympus.AlarmUpdate
-	14, // 14: fort.olympus.ClimateUpStream.metadata:type_name -> fort.olympus.ClimateUpStream.MetadataEntry
-	8,  // 15: fort.olympus.ClimateDownStream.registration_confirmation:type_name -> fort.olympus.ClimateRegistrationConfirmation
-	15, // 16: fort.olympus.ClimateDownStream.metadata:type_name -> fort.olympus.ClimateDownStream.MetadataEntry
-	18, // 17: fort.olympus.TrackingDeclaration.since:type_name -> google.protobuf.Timestamp
-	10, // 18: fort.olympus.TrackingUpStream.declaration:type_name -> fort.olympus.TrackingDeclaration
-	3,  // 19: fort.olympus.TrackingUpStream.alarms:type_name -> fort.olympus.AlarmUpdate
-	11, // 20: fort.olympus.TrackingUpStream.disk_status:type_name -> fort.olympus.DiskStatus
-	16, // 21: fort.olympus.TrackingUpStream.metadata:type_name -> fort.olympus.TrackingUpStream.MetadataEntry
-	17, // 22: fort.olympus.TrackingDownStream.metadata:type_name -> fort.olympus.TrackingDownStream.MetadataEntry
-	7,  // 23: fort.olympus.Olympus.Climate:input_type -> fort.olympus.ClimateUpStream
-	12, // 24: fort.olympus.Olympus.Tracking:input_type -> fort.olympus.TrackingUpStream
-	3,  // 25: fort.olympus.Olympus.SendAlarm:input_type -> fort.olympus.AlarmUpdate
-	9,  // 26: fort.olympus.Olympus.Climate:output_type -> fort.olympus.ClimateDownStream
-	13, // 27: fort.olympus.Olympus.Tracking:output_type -> fort.olympus.TrackingDownStream
-	19, // 28: fort.olympus.Olympus.SendAlarm:output_type -> google.protobuf.Empty
-	26, // [26:29] is the sub-list for method output_type
-	23, // [23:26] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	25, // 3: fort.olympus.AlarmUpdate.time:type_name -> google.protobuf.Timestamp
+	25, // 4: fort.olympus.ClimateDeclaration.since:type_name -> google.protobuf.Timestamp
+	5,  // 5: fort.olympus.ClimateDeclaration.temperature_sensors:type_name -> fort.olympus.TemperatureSensor
+	4,  // 6: fort.olympus.ClimateTarget.current:type_name -> fort.olympus.ClimateState
+	4,  // 7: fort.olympus.ClimateTarget.current_end:type_name -> fort.olympus.ClimateState
+	4,  // 8: fort.olympus.ClimateTarget.next:type_name -> fort.olympus.ClimateState
+	4,  // 9: fort.olympus.ClimateTarget.next_end:type_name -> fort.olympus.ClimateState
+	25, // 10: fort.olympus.ClimateTarget.next_time:type_name -> google.protobuf.Timestamp
+	6,  // 11: fort.olympus.ClimateUpStream.declaration:type_name -> fort.olympus.ClimateDeclaration
+	2,  // 12: fort.olympus.ClimateUpStream.reports:type_name -> fort.olympus.ClimateReport
+	7,  // 13: fort.olympus.ClimateUpStream.target:type_name -> fort.olympus.ClimateTarget
+	3,  // 14: fort.olympus.ClimateUpStream.alarms:type_name -> fort.olympus.AlarmUpdate
+	21, // 15: fort.olympus.ClimateUpStream.metadata:type_name -> fort.olympus.ClimateUpStream.MetadataEntry
+	9,  // 16: fort.olympus.ClimateDownStream.registration_confirmation:type_name -> fort.olympus.ClimateRegistrationConfirmation
+	22, // 17: fort.olympus.ClimateDownStream.metadata:type_name -> fort.olympus.ClimateDownStream.MetadataEntry
+	25, // 18: fort.olympus.TrackingDeclaration.since:type_name -> google.protobuf.Timestamp
+	11, // 19: fort.olympus.TrackingUpStream.declaration:type_name -> fort.olympus.TrackingDeclaration
+	3,  // 20: fort.olympus.TrackingUpStream.alarms:type_name -> fort.olympus.AlarmUpdate
+	12, // 21: fort.olympus.TrackingUpStream.disk_status:type_name -> fort.olympus.DiskStatus
+	23, // 22: fort.olympus.TrackingUpStream.metadata:type_name -> fort.olympus.TrackingUpStream.MetadataEntry
+	24, // 23: fort.olympus.TrackingDownStream.metadata:type_name -> fort.olympus.TrackingDownStream.MetadataEntry
+	25, // 24: fort.olympus.ClimateBacklogStatus.last_time:type_name -> google.protobuf.Timestamp
+	15, // 25: fort.olympus.ClimateBacklogUpStream.zone:type_name -> fort.olympus.ClimateZone
+	2,  // 26: fort.olympus.ClimateBacklogUpStream.reports:type_name -> fort.olympus.ClimateReport
+	25, // 27: fort.olympus.ClimateBacklogSummary.last_time:type_name -> google.protobuf.Timestamp
+	15, // 28: fort.olympus.ZoneAlarmUpdate.zone:type_name -> fort.olympus.ClimateZone
+	3,  // 29: fort.olympus.ZoneAlarmUpdate.update:type_name -> fort.olympus.AlarmUpdate
+	26, // 30: fort.olympus.ZoneHeartbeat.interval:type_name -> google.protobuf.Duration
+	8,  // 31: fort.olympus.Olympus.Climate:input_type -> fort.olympus.ClimateUpStream
+	13, // 32: fort.olympus.Olympus.Tracking:input_type -> fort.olympus.TrackingUpStream
+	19, // 33: fort.olympus.Olympus.SendAlarm:input_type -> fort.olympus.ZoneAlarmUpdate
+	15, // 34: fort.olympus.Olympus.GetClimateBacklogStatus:input_type -> fort.olympus.ClimateZone
+	17, // 35: fort.olympus.Olympus.ClimateBacklog:input_type -> fort.olympus.ClimateBacklogUpStream
+	20, // 36: fort.olympus.Olympus.Heartbeat:input_type -> fort.olympus.ZoneHeartbeat
+	10, // 37: fort.olympus.Olympus.Climate:output_type -> fort.olympus.ClimateDownStream
+	14, // 38: fort.olympus.Olympus.Tracking:output_type -> fort.olympus.TrackingDownStream
+	27, // 39: fort.olympus.Olympus.SendAlarm:output_type -> google.protobuf.Empty
+	16, // 40: fort.olympus.Olympus.GetClimateBacklogStatus:output_type -> fort.olympus.ClimateBacklogStatus
+	18, // 41: fort.olympus.Olympus.ClimateBacklog:output_type -> fort.olympus.ClimateBacklogSummary
+	27, // 42: fort.olympus.Olympus.Heartbeat:output_type -> google.protobuf.Empty
+	37, // [37:43] is the sub-list for method output_type
+	31, // [31:37] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_olympus_service_proto_init() }
@@ -1310,7 +1868,7 @@ func file_olympus_service_proto_init() {
 			}
 		}
 		file_olympus_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClimateDeclaration); i {
+			switch v := v.(*TemperatureSensor); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1322,7 +1880,7 @@ func file_olympus_service_proto_init() {
 			}
 		}
 		file_olympus_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClimateTarget); i {
+			switch v := v.(*ClimateDeclaration); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1334,7 +1892,7 @@ func file_olympus_service_proto_init() {
 			}
 		}
 		file_olympus_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClimateUpStream); i {
+			switch v := v.(*ClimateTarget); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1346,7 +1904,7 @@ func file_olympus_service_proto_init() {
 			}
 		}
 		file_olympus_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClimateRegistrationConfirmation); i {
+			switch v := v.(*ClimateUpStream); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1358,7 +1916,7 @@ func file_olympus_service_proto_init() {
 			}
 		}
 		file_olympus_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClimateDownStream); i {
+			switch v := v.(*ClimateRegistrationConfirmation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1370,7 +1928,7 @@ func file_olympus_service_proto_init() {
 			}
 		}
 		file_olympus_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TrackingDeclaration); i {
+			switch v := v.(*ClimateDownStream); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1382,7 +1940,7 @@ func file_olympus_service_proto_init() {
 			}
 		}
 		file_olympus_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DiskStatus); i {
+			switch v := v.(*TrackingDeclaration); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1394,7 +1952,7 @@ func file_olympus_service_proto_init() {
 			}
 		}
 		file_olympus_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TrackingUpStream); i {
+			switch v := v.(*DiskStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1406,6 +1964,18 @@ func file_olympus_service_proto_init() {
 			}
 		}
 		file_olympus_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrackingUpStream); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_olympus_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TrackingDownStream); i {
 			case 0:
 				return &v.state
@@ -1417,21 +1987,97 @@ func file_olympus_service_proto_init() {
 				return nil
 			}
 		}
+		file_olympus_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClimateZone); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_olympus_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClimateBacklogStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_olympus_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClimateBacklogUpStream); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_olympus_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClimateBacklogSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_olympus_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZoneAlarmUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_olympus_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZoneHeartbeat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_olympus_service_proto_msgTypes[0].OneofWrappers = []interface{}{}
 	file_olympus_service_proto_msgTypes[2].OneofWrappers = []interface{}{}
 	file_olympus_service_proto_msgTypes[3].OneofWrappers = []interface{}{}
 	file_olympus_service_proto_msgTypes[4].OneofWrappers = []interface{}{}
 	file_olympus_service_proto_msgTypes[5].OneofWrappers = []interface{}{}
-	file_olympus_service_proto_msgTypes[8].OneofWrappers = []interface{}{}
-	file_olympus_service_proto_msgTypes[10].OneofWrappers = []interface{}{}
+	file_olympus_service_proto_msgTypes[6].OneofWrappers = []interface{}{}
+	file_olympus_service_proto_msgTypes[9].OneofWrappers = []interface{}{}
+	file_olympus_service_proto_msgTypes[11].OneofWrappers = []interface{}{}
+	file_olympus_service_proto_msgTypes[14].OneofWrappers = []interface{}{}
+	file_olympus_service_proto_msgTypes[15].OneofWrappers = []interface{}{}
+	file_olympus_service_proto_msgTypes[16].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_olympus_service_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   16,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -25,7 +25,10 @@ const _ = grpc.SupportPackageIsVersion7
 type OlympusClient interface {
 	Climate(ctx context.Context, opts ...grpc.CallOption) (Olympus_ClimateClient, error)
 	Tracking(ctx context.Context, opts ...grpc.CallOption) (Olympus_TrackingClient, error)
-	SendAlarm(ctx context.Context, in *AlarmUpdate, opts ...grpc.CallOption) (*empty.Empty, error)
+	SendAlarm(ctx context.Context, in *ZoneAlarmUpdate, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetClimateBacklogStatus(ctx context.Context, in *ClimateZone, opts ...grpc.CallOption) (*ClimateBacklogStatus, error)
+	ClimateBacklog(ctx context.Context, opts ...grpc.CallOption) (Olympus_ClimateBacklogClient, error)
+	Heartbeat(ctx context.Context, in *ZoneHeartbeat, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
 type olympusClient struct {
@@ -98,7 +101,7 @@ func (x *olympusTrackingClient) Recv() (*TrackingDownStream, error) {
 	return m, nil
 }
 
-func (c *olympusClient) SendAlarm(ctx context.Context, in *AlarmUpdate, opts ...grpc.CallOption) (*empty.Empty, error) {
+func (c *olympusClient) SendAlarm(ctx context.Context, in *ZoneAlarmUpdate, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/fort.olympus.Olympus/SendAlarm", in, out, opts...)
 	if err != nil {
@@ -107,13 +110,68 @@ func (c *olympusClient) SendAlarm(ctx context.Context, in *AlarmUpdate, opts ...
 	return out, nil
 }
 
+func (c *olympusClient) GetClimateBacklogStatus(ctx context.Context, in *ClimateZone, opts ...grpc.CallOption) (*ClimateBacklogStatus, error) {
+	out := new(ClimateBacklogStatus)
+	err := c.cc.Invoke(ctx, "/fort.olympus.Olympus/GetClimateBacklogStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *olympusClient) ClimateBacklog(ctx context.Context, opts ...grpc.CallOption) (Olympus_ClimateBacklogClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Olympus_ServiceDesc.Streams[2], "/fort.olympus.Olympus/ClimateBacklog", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &olympusClimateBacklogClient{stream}
+	return x, nil
+}
+
+type Olympus_ClimateBacklogClient interface {
+	Send(*ClimateBacklogUpStream) error
+	CloseAndRecv() (*ClimateBacklogSummary, error)
+	grpc.ClientStream
+}
+
+type olympusClimateBacklogClient struct {
+	grpc.ClientStream
+}
+
+func (x *olympusClimateBacklogClient) Send(m *ClimateBacklogUpStream) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *olympusClimateBacklogClient) CloseAndRecv() (*ClimateBacklogSummary, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ClimateBacklogSummary)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *olympusClient) Heartbeat(ctx context.Context, in *ZoneHeartbeat, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/fort.olympus.Olympus/Heartbeat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OlympusServer is the server API for Olympus service.
 // All implementations must embed UnimplementedOlympusServer
 // for forward compatibility
 type OlympusServer interface {
 	Climate(Olympus_ClimateServer) error
 	Tracking(Olympus_TrackingServer) error
-	SendAlarm(context.Context, *AlarmUpdate) (*empty.Empty, error)
+	SendAlarm(context.Context, *ZoneAlarmUpdate) (*empty.Empty, error)
+	GetClimateBacklogStatus(context.Context, *ClimateZone) (*ClimateBacklogStatus, error)
+	ClimateBacklog(Olympus_ClimateBacklogServer) error
+	Heartbeat(context.Context, *ZoneHeartbeat) (*empty.Empty, error)
 	mustEmbedUnimplementedOlympusServer()
 }
 
@@ -127,9 +185,18 @@ func (UnimplementedOlympusServer) Climate(Olympus_ClimateServer) error {
 func (UnimplementedOlympusServer) Tracking(Olympus_TrackingServer) error {
 	return status.Errorf(codes.Unimplemented, "method Tracking not implemented")
 }
-func (UnimplementedOlympusServer) SendAlarm(context.Context, *AlarmUpdate) (*empty.Empty, error) {
+func (UnimplementedOlympusServer) SendAlarm(context.Context, *ZoneAlarmUpdate) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendAlarm not implemented")
 }
+func (UnimplementedOlympusServer) GetClimateBacklogStatus(context.Context, *ClimateZone) (*ClimateBacklogStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClimateBacklogStatus not implemented")
+}
+func (UnimplementedOlympusServer) ClimateBacklog(Olympus_ClimateBacklogServer) error {
+	return status.Errorf(codes.Unimplemented, "method ClimateBacklog not implemented")
+}
+func (UnimplementedOlympusServer) Heartbeat(context.Context, *ZoneHeartbeat) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
 func (UnimplementedOlympusServer) mustEmbedUnimplementedOlympusServer() {}
 
 // UnsafeOlympusServer may be embedded to opt out of forward compatibility for this service.
@@ -196,7 +263,7 @@ func (x *olympusTrackingServer) Recv() (*TrackingUpStream, error) {
 }
 
 func _Olympus_SendAlarm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AlarmUpdate)
+	in := new(ZoneAlarmUpdate)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -208,7 +275,69 @@ func _Olympus_SendAlarm_Handler(srv interface{}, ctx context.Context, dec func(i
 		FullMethod: "/fort.olympus.Olympus/SendAlarm",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OlympusServer).SendAlarm(ctx, req.(*AlarmUpdate))
+		return srv.(OlympusServer).SendAlarm(ctx, req.(*ZoneAlarmUpdate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Olympus_GetClimateBacklogStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClimateZone)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OlympusServer).GetClimateBacklogStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fort.olympus.Olympus/GetClimateBacklogStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OlympusServer).GetClimateBacklogStatus(ctx, req.(*ClimateZone))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Olympus_ClimateBacklog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(OlympusServer).ClimateBacklog(&olympusClimateBacklogServer{stream})
+}
+
+type Olympus_ClimateBacklogServer interface {
+	SendAndClose(*ClimateBacklogSummary) error
+	Recv() (*ClimateBacklogUpStream, error)
+	grpc.ServerStream
+}
+
+type olympusClimateBacklogServer struct {
+	grpc.ServerStream
+}
+
+func (x *olympusClimateBacklogServer) SendAndClose(m *ClimateBacklogSummary) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *olympusClimateBacklogServer) Recv() (*ClimateBacklogUpStream, error) {
+	m := new(ClimateBacklogUpStream)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Olympus_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZoneHeartbeat)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OlympusServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fort.olympus.Olympus/Heartbeat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OlympusServer).Heartbeat(ctx, req.(*ZoneHeartbeat))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -224,6 +353,14 @@ var Olympus_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendAlarm",
 			Handler:    _Olympus_SendAlarm_Handler,
 		},
+		{
+			MethodName: "GetClimateBacklogStatus",
+			Handler:    _Olympus_GetClimateBacklogStatus_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _Olympus_Heartbeat_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -238,6 +375,11 @@ var Olympus_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "ClimateBacklog",
+			Handler:       _Olympus_ClimateBacklog_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "olympus_service.proto",
 }
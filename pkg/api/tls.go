@@ -0,0 +1,87 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// loadCertPool loads the PEM encoded certificates of a file.
+func loadCertPool(filename string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	res := x509.NewCertPool()
+	if res.AppendCertsFromPEM(data) == false {
+		return nil, fmt.Errorf("no PEM certificate found in '%s'", filename)
+	}
+	return res, nil
+}
+
+// NewClientTLSConfig builds the TLS configuration of a client
+// authenticating with the certificate certFile and its key keyFile,
+// and verifying the server certificate with the authority of
+// caFile. If caFile is empty, the system authorities are used.
+func NewClientTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	res := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if len(caFile) > 0 {
+		if res.RootCAs, err = loadCertPool(caFile); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// NewServerTLSConfig builds the TLS configuration of a server using
+// the certificate certFile and its key keyFile. If clientCAFile is
+// not empty, clients must present a certificate issued by this
+// authority.
+func NewServerTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	if len(certFile) == 0 || len(keyFile) == 0 {
+		return nil, errors.New("both a certificate and a key are required")
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	res := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if len(clientCAFile) > 0 {
+		if res.ClientCAs, err = loadCertPool(clientCAFile); err != nil {
+			return nil, err
+		}
+		res.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return res, nil
+}
+
+// WithTLS connects using TLS with config instead of the insecure
+// credentials of [DefaultDialOptions].
+func WithTLS(config *tls.Config) ConnectionOption {
+	return WithDialOptions(grpc.WithTransportCredentials(credentials.NewTLS(config)))
+}
+
+// WithClientCertificate connects using TLS, authenticating with a
+// client certificate. See [NewClientTLSConfig].
+func WithClientCertificate(certFile, keyFile, caFile string) (ConnectionOption, error) {
+	config, err := NewClientTLSConfig(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	return WithTLS(config), nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/atuleu/go-lttb"
@@ -15,16 +16,45 @@ import (
 //go:generate go run ./examples/generate.go
 //go:generate mockgen -source olympus_service_grpc.pb.go -package=api -destination=mock_olympus_service_test.go  -self_package=github.com/formicidae-tracker/olympus/pkg/api
 
+// AlarmAcknowledgement records who acknowledged an alarm event, and
+// when.
+type AlarmAcknowledgement struct {
+	By   string    `json:"by"`
+	Time time.Time `json:"time"`
+}
+
+// LoginRequest is the body of a login request.
+type LoginRequest struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+// UserSession describes the user authenticated by a request. Role is
+// "none" for unauthenticated requests.
+type UserSession struct {
+	User    string     `json:"user,omitempty"`
+	Role    string     `json:"role"`
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+// AlarmAcknowledgementRequest is the body of an alarm
+// acknowledgement request.
+type AlarmAcknowledgementRequest struct {
+	By string `json:"by"`
+}
+
 type AlarmEvent struct {
-	Start time.Time  `json:"start,omitempty"`
-	End   *time.Time `json:"end,omitempty"`
+	Start           time.Time             `json:"start,omitempty"`
+	End             *time.Time            `json:"end,omitempty"`
+	Acknowledgement *AlarmAcknowledgement `json:"acknowledgement,omitempty"`
 }
 
 type AlarmReport struct {
-	Identification string       `json:"identification,omitempty"`
-	Level          AlarmLevel   `json:"level"`
-	Events         []AlarmEvent `json:"events"`
-	Description    string       `json:"description"`
+	Identification  string                `json:"identification,omitempty"`
+	Level           AlarmLevel            `json:"level"`
+	Events          []AlarmEvent          `json:"events"`
+	Description     string                `json:"description"`
+	Acknowledgement *AlarmAcknowledgement `json:"acknowledgement,omitempty"`
 }
 
 func (r *AlarmReport) On() bool {
@@ -80,6 +110,56 @@ type ClimateTimeSeries struct {
 	Humidity       PointSeries   `json:"humidity,omitempty"`
 	Temperature    PointSeries   `json:"temperature,omitempty"`
 	TemperatureAux []PointSeries `json:"temperatureAux,omitempty"`
+	Wind           PointSeries   `json:"wind,omitempty"`
+	VisibleLight   PointSeries   `json:"visibleLight,omitempty"`
+	UvLight        PointSeries   `json:"uvLight,omitempty"`
+	// setpoints of the zone over the same period.
+	TemperatureTarget PointSeries `json:"temperatureTarget,omitempty"`
+	HumidityTarget    PointSeries `json:"humidityTarget,omitempty"`
+	// describes Temperature, followed by each TemperatureAux.
+	TemperatureSensors []SensorDescription `json:"temperatureSensors,omitempty"`
+}
+
+// TargetChange is a change of the climate target of a zone. The
+// target is linearly interpolated from Current to CurrentEnd, reached
+// at End.
+type TargetChange struct {
+	Time       time.Time     `json:"time"`
+	Current    *ClimateState `json:"current,omitempty"`
+	CurrentEnd *ClimateState `json:"current_end,omitempty"`
+	End        *time.Time    `json:"end,omitempty"`
+}
+
+// ClimateRecord is a raw climate report, as exported in NDJSON
+// format. TemperatureAux holds the values of the auxiliary
+// temperature sensors.
+type ClimateRecord struct {
+	Time           time.Time `json:"time"`
+	Humidity       *float32  `json:"humidity,omitempty"`
+	Temperature    *float32  `json:"temperature,omitempty"`
+	TemperatureAux []float32 `json:"temperatureAux,omitempty"`
+	Wind           *float32  `json:"wind,omitempty"`
+	VisibleLight   *float32  `json:"visibleLight,omitempty"`
+	UvLight        *float32  `json:"uvLight,omitempty"`
+}
+
+// NewClimateRecord converts a ClimateReport to a ClimateRecord.
+func NewClimateRecord(r *ClimateReport) ClimateRecord {
+	res := ClimateRecord{
+		Time:         r.Time.AsTime(),
+		Humidity:     r.Humidity,
+		Wind:         r.Wind,
+		VisibleLight: r.VisibleLight,
+		UvLight:      r.UvLight,
+	}
+	if len(r.Temperatures) > 0 {
+		res.Temperature = new(float32)
+		*res.Temperature = r.Temperatures[0]
+	}
+	if len(r.Temperatures) > 1 {
+		res.TemperatureAux = append([]float32(nil), r.Temperatures[1:]...)
+	}
+	return res
 }
 
 type Bounds struct {
@@ -87,6 +167,40 @@ type Bounds struct {
 	Maximum *float32 `json:"maximum,omitempty"`
 }
 
+// DefaultTemperatureUnit is the unit of temperature sensors not
+// declaring one.
+const DefaultTemperatureUnit = "°C"
+
+// SensorDescription describes an entry of ClimateReport.Temperatures.
+type SensorDescription struct {
+	Name   string `json:"name,omitempty"`
+	Unit   string `json:"unit,omitempty"`
+	Bounds Bounds `json:"bounds,omitempty"`
+}
+
+// NewSensorDescriptions converts the temperature sensors of a
+// ClimateDeclaration to SensorDescriptions.
+func NewSensorDescriptions(sensors []*TemperatureSensor) []SensorDescription {
+	if len(sensors) == 0 {
+		return nil
+	}
+	res := make([]SensorDescription, len(sensors))
+	for i, s := range sensors {
+		res[i] = SensorDescription{
+			Name: s.Name,
+			Unit: s.Unit,
+			Bounds: Bounds{
+				Minimum: s.Minimum,
+				Maximum: s.Maximum,
+			},
+		}
+		if len(res[i].Unit) == 0 {
+			res[i].Unit = DefaultTemperatureUnit
+		}
+	}
+	return res
+}
+
 type mayFloat float32
 
 func (f *mayFloat) String() string {
@@ -97,16 +211,37 @@ func (f *mayFloat) String() string {
 }
 
 type ZoneClimateReport struct {
-	Since             time.Time     `json:"since,omitempty"`
-	Temperature       *float32      `json:"temperature,omitempty"`
-	Humidity          *float32      `json:"humidity,omitempty"`
-	TemperatureBounds Bounds        `json:"temperature_bounds,omitempty"`
-	HumidityBounds    Bounds        `json:"humidity_bounds,omitempty"`
-	Current           *ClimateState `json:"current,omitempty,omitempty"`
-	CurrentEnd        *ClimateState `json:"current_end,omitempty,omitempty"`
-	Next              *ClimateState `json:"next,omitempty"`
-	NextEnd           *ClimateState `json:"next_end,omitempty"`
-	NextTime          *time.Time    `json:"next_time,omitempty"`
+	Since             time.Time            `json:"since,omitempty"`
+	Temperature       *float32             `json:"temperature,omitempty"`
+	TemperatureAux    []float32            `json:"temperature_aux,omitempty"`
+	Humidity          *float32             `json:"humidity,omitempty"`
+	TemperatureBounds Bounds               `json:"temperature_bounds,omitempty"`
+	HumidityBounds    Bounds               `json:"humidity_bounds,omitempty"`
+	Current           *ClimateState        `json:"current,omitempty,omitempty"`
+	CurrentEnd        *ClimateState        `json:"current_end,omitempty,omitempty"`
+	Next              *ClimateState        `json:"next,omitempty"`
+	NextEnd           *ClimateState        `json:"next_end,omitempty"`
+	NextTime          *time.Time           `json:"next_time,omitempty"`
+	LastReportTime    *time.Time           `json:"last_report_time,omitempty"`
+	Stale             bool                 `json:"stale,omitempty"`
+	TrackingErrors    []TrackingErrorStats `json:"tracking_errors,omitempty"`
+	// describes Temperature, followed by each TemperatureAux.
+	TemperatureSensors []SensorDescription `json:"temperature_sensors,omitempty"`
+}
+
+// DeviationStats are the mean and maximal absolute deviations of a
+// quantity from its target.
+type DeviationStats struct {
+	Mean float32 `json:"mean"`
+	Max  float32 `json:"max"`
+}
+
+// TrackingErrorStats are the deviations of a zone from its climate
+// target over a time window.
+type TrackingErrorStats struct {
+	Window      string          `json:"window"`
+	Temperature *DeviationStats `json:"temperature,omitempty"`
+	Humidity    *DeviationStats `json:"humidity,omitempty"`
 }
 
 func (r *ZoneClimateReport) String() string {
@@ -123,11 +258,21 @@ type StreamInfo struct {
 }
 
 type TrackingInfo struct {
-	Since          time.Time   `json:"since,omitempty"`
-	TotalBytes     int64       `json:"total_bytes,omitempty"`
-	FreeBytes      int64       `json:"free_bytes,omitempty"`
-	BytesPerSecond int64       `json:"bytes_per_second,omitempty"`
-	Stream         *StreamInfo `json:"stream,omitempty"`
+	Since                  time.Time   `json:"since,omitempty"`
+	TotalBytes             int64       `json:"total_bytes,omitempty"`
+	FreeBytes              int64       `json:"free_bytes,omitempty"`
+	BytesPerSecond         int64       `json:"bytes_per_second,omitempty"`
+	ObservedBytesPerSecond int64       `json:"observed_bytes_per_second,omitempty"`
+	FullAt                 *time.Time  `json:"full_at,omitempty"`
+	Stream                 *StreamInfo `json:"stream,omitempty"`
+}
+
+// HeartbeatInfo describes the heartbeats of a virtual zone.
+type HeartbeatInfo struct {
+	Since         time.Time     `json:"since,omitempty"`
+	Interval      time.Duration `json:"interval"`
+	LastHeartbeat time.Time     `json:"last_heartbeat,omitempty"`
+	Missed        bool          `json:"missed,omitempty"`
 }
 
 type ZoneReportSummary struct {
@@ -135,8 +280,35 @@ type ZoneReportSummary struct {
 	Name              string             `json:"name,omitempty"`
 	Climate           *ZoneClimateReport `json:"climate,omitempty"`
 	Tracking          *TrackingInfo      `json:"tracking,omitempty"`
+	Heartbeat         *HeartbeatInfo     `json:"heartbeat,omitempty"`
 	ActiveWarnings    int                `json:"active_warnings,omitempty"`
 	ActiveEmergencies int                `json:"active_emergencies,omitempty"`
+	Maintenance       *MaintenanceWindow `json:"maintenance,omitempty"`
+}
+
+// A MaintenanceWindow silences the notifications of a zone, or only
+// of the alarms whose identification starts with AlarmPrefix, between
+// Start and End. Alarms are still logged during the window.
+type MaintenanceWindow struct {
+	ID          string    `json:"id,omitempty"`
+	Zone        string    `json:"zone"`
+	AlarmPrefix string    `json:"alarm_prefix,omitempty"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	Reason      string    `json:"reason,omitempty"`
+}
+
+// Active returns true if t is within the window.
+func (w MaintenanceWindow) Active(t time.Time) bool {
+	return t.Before(w.Start) == false && t.Before(w.End) == true
+}
+
+// Silences returns true if the window silences the alarm
+// identification of zone at time t.
+func (w MaintenanceWindow) Silences(zone, identification string, t time.Time) bool {
+	return w.Zone == zone &&
+		strings.HasPrefix(identification, w.AlarmPrefix) &&
+		w.Active(t)
 }
 
 type ServiceEvent struct {
@@ -176,11 +348,12 @@ func (l *ServiceLog) SetOff(t time.Time, graceful bool) {
 }
 
 type ZoneReport struct {
-	Host     string             `json:"host,omitempty"`
-	Name     string             `json:"name,omitempty"`
-	Climate  *ZoneClimateReport `json:"climate,omitempty"`
-	Tracking *TrackingInfo      `json:"tracking,omitempty"`
-	Alarms   []AlarmReport      `json:"alarms,omitempty"`
+	Host      string             `json:"host,omitempty"`
+	Name      string             `json:"name,omitempty"`
+	Climate   *ZoneClimateReport `json:"climate,omitempty"`
+	Tracking  *TrackingInfo      `json:"tracking,omitempty"`
+	Heartbeat *HeartbeatInfo     `json:"heartbeat,omitempty"`
+	Alarms    []AlarmReport      `json:"alarms,omitempty"`
 }
 
 func (r *ZoneReport) String() string {
@@ -193,6 +366,12 @@ type NotificationSettings struct {
 	NotifyNonGraceful bool     `json:"notifyNonGraceful,omitempty"`
 	SubscribeToAll    bool     `json:"subscribeToAll,omitempty"`
 	Subscriptions     []string `json:"subscriptions,omitempty"`
+	// NotifyOnEscalation adds the subscription to the escalation
+	// group, notified of any unacknowledged emergency.
+	NotifyOnEscalation bool `json:"notifyOnEscalation,omitempty"`
+	// NotifyOnResolve sends a notification when an alarm previously
+	// notified to the subscription is resolved.
+	NotifyOnResolve bool `json:"notifyOnResolve,omitempty"`
 }
 
 func (s NotificationSettings) SubscribedTo(zone string) bool {
@@ -207,7 +386,115 @@ func (s NotificationSettings) SubscribedTo(zone string) bool {
 	return false
 }
 
+// EmailSubscription registers an email address to receive
+// notifications.
+type EmailSubscription struct {
+	Email    string               `json:"email"`
+	Settings NotificationSettings `json:"settings,omitempty"`
+}
+
+// A Webhook receives notifications as a WebhookPayload POSTed to
+// its URL. Secret is only returned upon creation.
+type Webhook struct {
+	ID       string               `json:"id,omitempty"`
+	URL      string               `json:"url"`
+	Secret   string               `json:"secret,omitempty"`
+	Settings NotificationSettings `json:"settings,omitempty"`
+}
+
+// WebhookAlarm describes a single alarm update in a WebhookPayload.
+type WebhookAlarm struct {
+	Host           string    `json:"host,omitempty"`
+	Zone           string    `json:"zone"`
+	Identification string    `json:"identification"`
+	Level          string    `json:"level"`
+	Status         string    `json:"status"`
+	Description    string    `json:"description,omitempty"`
+	Time           time.Time `json:"time"`
+	// FiredAt is set for resolved alarms, i.e. with an "OFF" status,
+	// to the time the alarm started.
+	FiredAt *time.Time `json:"fired_at,omitempty"`
+}
+
+// WebhookPayload is the JSON body POSTed to webhooks for each batch of
+// notifications. The request has the following headers:
+//
+//   - X-Olympus-Delivery: a unique delivery ID, identical across
+//     retries.
+//   - X-Olympus-Signature: 'sha256=' followed by the hex encoded
+//     HMAC-SHA256 of the body, keyed with the webhook secret.
+type WebhookPayload struct {
+	Delivery string         `json:"delivery"`
+	SentAt   time.Time      `json:"sent_at"`
+	Alarms   []WebhookAlarm `json:"alarms"`
+}
+
+// WebhookDelivery is an entry of the delivery log of a webhook.
+type WebhookDelivery struct {
+	ID         string    `json:"id"`
+	Time       time.Time `json:"time"`
+	Alarms     int       `json:"alarms"`
+	Attempts   int       `json:"attempts"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
 type NotificationSettingsUpdate struct {
 	Endpoint string               `json:"endpoint,omitempty"`
 	Settings NotificationSettings `json:"settings,omitempty"`
 }
+
+// Types of the ZoneEvent sent by the /api/events Server-Sent Events
+// stream.
+const (
+	// ZonesEventType is the first event of a stream, with a snapshot of
+	// all zones in Zones.
+	ZonesEventType = "zones"
+	// ZoneSummaryEventType is sent when a zone changes, with its new
+	// Summary. Summary is nil if the zone was removed.
+	ZoneSummaryEventType = "zone"
+	// ClimateEventType sends new climate samples in Climate.
+	ClimateEventType = "climate"
+	// TargetEventType sends the new climate targets of a zone in Target.
+	TargetEventType = "target"
+	// AlarmsEventType sends alarm transitions in Alarms.
+	AlarmsEventType = "alarms"
+	// ServiceEventType sends a service registration or unregistration in
+	// Service.
+	ServiceEventType = "service"
+)
+
+// ZoneAlarmTransition is an alarm turning on or off, as streamed in a
+// ZoneEvent.
+type ZoneAlarmTransition struct {
+	Identification string     `json:"identification"`
+	Level          AlarmLevel `json:"level"`
+	On             bool       `json:"on"`
+	Description    string     `json:"description,omitempty"`
+	Time           time.Time  `json:"time"`
+}
+
+// ServiceStatus is a service registration or unregistration, as
+// streamed in a ZoneEvent.
+type ServiceStatus struct {
+	Identifier string `json:"identifier"`
+	On         bool   `json:"on"`
+	Graceful   bool   `json:"graceful"`
+}
+
+// A ZoneEvent is sent by the /api/events Server-Sent Events
+// stream. Its Type is also the SSE event name, and only the field
+// matching the Type is set. Host and Zone are empty for
+// ZonesEventType.
+type ZoneEvent struct {
+	Type    string                `json:"type"`
+	Host    string                `json:"host,omitempty"`
+	Zone    string                `json:"zone,omitempty"`
+	Time    time.Time             `json:"time"`
+	Zones   []ZoneReportSummary   `json:"zones,omitempty"`
+	Summary *ZoneReportSummary    `json:"summary,omitempty"`
+	Climate []ClimateRecord       `json:"climate,omitempty"`
+	Target  *ZoneClimateReport    `json:"target,omitempty"`
+	Alarms  []ZoneAlarmTransition `json:"alarms,omitempty"`
+	Service *ServiceStatus        `json:"service,omitempty"`
+}
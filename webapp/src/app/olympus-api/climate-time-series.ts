@@ -1,9 +1,17 @@
+import { SensorDescription } from './sensor-description';
+
 export class ClimateTimeSeries {
   units: string = '';
   reference: Date = new Date(0);
   humidity: number[][] = [];
   temperature: number[][] = [];
   temperatureAux: number[][][] = [];
+  wind: number[][] = [];
+  visibleLight: number[][] = [];
+  uvLight: number[][] = [];
+  temperatureTarget: number[][] = [];
+  humidityTarget: number[][] = [];
+  temperatureSensors: SensorDescription[] = [];
 
   static fromPlain(plain: any): ClimateTimeSeries {
     let res = new ClimateTimeSeries();
@@ -12,6 +20,14 @@ export class ClimateTimeSeries {
     res.humidity = plain.humidity || [];
     res.temperature = plain.temperature || [];
     res.temperatureAux = plain.temperatureAux || [];
+    res.wind = plain.wind || [];
+    res.visibleLight = plain.visibleLight || [];
+    res.uvLight = plain.uvLight || [];
+    res.temperatureTarget = plain.temperatureTarget || [];
+    res.humidityTarget = plain.humidityTarget || [];
+    res.temperatureSensors = (plain.temperatureSensors || []).map(
+      SensorDescription.fromPlain
+    );
     return res;
   }
 }
@@ -0,0 +1,17 @@
+export class HeartbeatInfo {
+  public since: Date = new Date(0);
+  // expected interval between heartbeats, in milliseconds.
+  public interval: number = 0;
+  public last_heartbeat: Date = new Date(0);
+  public missed: boolean = false;
+
+  static fromPlain(plain: any): HeartbeatInfo {
+    let res = new HeartbeatInfo();
+    res.since = new Date(plain.since || 0);
+    // durations are serialized in nanoseconds.
+    res.interval = (plain.interval || 0) / 1e6;
+    res.last_heartbeat = new Date(plain.last_heartbeat || 0);
+    res.missed = plain.missed || false;
+    return res;
+  }
+}
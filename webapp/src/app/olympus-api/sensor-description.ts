@@ -0,0 +1,15 @@
+import { Bounds } from './bounds';
+
+export class SensorDescription {
+  public name: string = '';
+  public unit: string = '°C';
+  public bounds: Bounds = new Bounds();
+
+  static fromPlain(plain: any): SensorDescription {
+    let res = new SensorDescription();
+    res.name = plain.name || '';
+    res.unit = plain.unit || '°C';
+    res.bounds = Bounds.fromPlain(plain.bounds || {});
+    return res;
+  }
+}
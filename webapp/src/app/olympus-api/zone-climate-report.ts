@@ -1,5 +1,6 @@
 import { ClimateState } from './climate-state';
 import { Bounds } from './bounds';
+import { SensorDescription } from './sensor-description';
 
 export class ZoneClimateReport {
   since: Date = new Date(0);
@@ -9,6 +10,8 @@ export class ZoneClimateReport {
   temperature_bounds?: Bounds;
   humidity_bounds?: Bounds;
 
+  temperature_sensors: SensorDescription[] = [];
+
   current?: ClimateState;
   current_end?: ClimateState;
 
@@ -30,6 +33,9 @@ export class ZoneClimateReport {
     if (plain.humidity_bounds != undefined) {
       ret.humidity_bounds = Bounds.fromPlain(plain.humidity_bounds);
     }
+    ret.temperature_sensors = (plain.temperature_sensors || []).map(
+      SensorDescription.fromPlain
+    );
 
     if (plain.current != undefined) {
       ret.current = ClimateState.fromPlain(plain.current);
@@ -1,4 +1,5 @@
 import { ZoneClimateReport } from './zone-climate-report';
+import { HeartbeatInfo } from './heartbeat-info';
 import { TrackingInfo } from './tracking-info';
 import { Bounds } from './bounds';
 
@@ -8,6 +9,7 @@ export class ZoneReportSummary {
 
   climate?: ZoneClimateReport;
   tracking?: TrackingInfo;
+  heartbeat?: HeartbeatInfo;
 
   active_warnings: number = 0;
   active_emergencies: number = 0;
@@ -37,6 +39,9 @@ export class ZoneReportSummary {
     if (plain.tracking != undefined) {
       ret.tracking = TrackingInfo.fromPlain(plain.tracking);
     }
+    if (plain.heartbeat != undefined) {
+      ret.heartbeat = HeartbeatInfo.fromPlain(plain.heartbeat);
+    }
     ret.active_emergencies = plain.active_emergencies || 0;
     ret.active_warnings = plain.active_warnings || 0;
     return ret;
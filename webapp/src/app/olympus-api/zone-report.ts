@@ -1,4 +1,5 @@
 import { AlarmReport } from './alarm-report';
+import { HeartbeatInfo } from './heartbeat-info';
 import { TrackingInfo } from './tracking-info';
 import { ZoneClimateReport } from './zone-climate-report';
 
@@ -7,6 +8,7 @@ export class ZoneReport {
   public name: string = '';
   public climate?: ZoneClimateReport;
   public tracking?: TrackingInfo;
+  public heartbeat?: HeartbeatInfo;
   public alarms: AlarmReport[] = [];
 
   static fromPlain(plain: any): ZoneReport {
@@ -19,6 +21,9 @@ export class ZoneReport {
     if (plain.tracking != undefined) {
       res.tracking = TrackingInfo.fromPlain(plain.tracking);
     }
+    if (plain.heartbeat != undefined) {
+      res.heartbeat = HeartbeatInfo.fromPlain(plain.heartbeat);
+    }
     for (const a of plain.alarms || []) {
       res.alarms.push(AlarmReport.fromPlain(a));
     }